@@ -17,11 +17,13 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	// Initialize the CLI command structure
-	cmd := config.InitAppConfig(mcpreportportal.RunStreamingServer, mcphandlers.RunStdioServer)
-
-	// Run the CLI command and handle any errors
-	if err := cmd.Run(ctx, os.Args); err != nil {
+	// Load the optional config file, then run the CLI command and handle any errors
+	if err := config.RunApp(
+		ctx,
+		os.Args,
+		mcpreportportal.RunStreamingServer,
+		mcphandlers.RunStdioServer,
+	); err != nil {
 		log.Fatal(err)
 	}
 }
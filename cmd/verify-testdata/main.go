@@ -13,11 +13,13 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fatih/color"
 
 	"github.com/reportportal/reportportal-mcp-server/internal/integration/testdata"
+	"github.com/reportportal/reportportal-mcp-server/internal/mcpclient"
 )
 
 const (
@@ -56,14 +58,6 @@ type MCPError struct {
 	Message string `json:"message"`
 }
 
-// InitializeRequest represents MCP initialize request
-type InitializeRequest struct {
-	JSONRPC string                 `json:"jsonrpc"`
-	Method  string                 `json:"method"`
-	ID      int                    `json:"id"`
-	Params  map[string]interface{} `json:"params"`
-}
-
 // JSONRPCRequest represents a generic JSON-RPC 2.0 request for validation
 type JSONRPCRequest struct {
 	JSONRPC string      `json:"jsonrpc"`
@@ -79,6 +73,17 @@ var (
 		"internal/integration/testdata",
 		"Test data directory (searched recursively for .json files)",
 	)
+	filePattern = flag.String(
+		"pattern",
+		"",
+		"Glob matched against fixture file names to verify a subset (e.g. \"*launch*\"); "+
+			"empty verifies all files",
+	)
+	parallel = flag.Int(
+		"parallel",
+		1,
+		"Number of test cases verified concurrently (1 verifies them serially)",
+	)
 	verbose = flag.Bool("v", false, "Verbose output")
 
 	// httpClient is a shared HTTP client with timeout for all requests
@@ -136,6 +141,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *parallel < 1 {
+		_, _ = red.Printf("Error: -parallel must be at least 1, got %d\n", *parallel)
+		os.Exit(1)
+	}
+
 	// Normalize MCP server URL to ensure it points to the correct endpoint
 	normalizedURL := normalizeMCPURL(*mcpServerURL)
 
@@ -153,7 +163,11 @@ func main() {
 	initCtx, initCancel := context.WithTimeout(parentCtx, httpTimeout)
 	defer initCancel()
 
-	sessionID, err := initializeMCPSession(initCtx, normalizedURL, rpToken, rpProject)
+	sessionID, err := mcpclient.Initialize(initCtx, httpClient, normalizedURL, mcpclient.Options{
+		Token:      rpToken,
+		Project:    rpProject,
+		ClientName: "testdata-verifier",
+	})
 	if err != nil {
 		_, _ = red.Printf("Failed to initialize MCP session: %v\n", err)
 		os.Exit(1)
@@ -164,13 +178,17 @@ func main() {
 	// Step 2: Discover test files
 	_, _ = yellow.Println("\n[2/3] Discovering test fixtures...")
 
-	testFiles, err := discoverTestFiles(*testDataDir)
+	testFiles, err := discoverTestFiles(*testDataDir, *filePattern)
 	if err != nil {
 		_, _ = red.Printf("Failed to discover test files: %v\n", err)
 		os.Exit(1)
 	}
 
 	if len(testFiles) == 0 {
+		if *filePattern != "" {
+			_, _ = yellow.Printf("No test files matching %q found in %s\n", *filePattern, *testDataDir)
+			os.Exit(0)
+		}
 		_, _ = yellow.Printf("No test files found in %s\n", *testDataDir)
 		os.Exit(0)
 	}
@@ -187,42 +205,39 @@ func main() {
 		Skipped int
 	}{}
 
-	for _, testFile := range testFiles {
-		results.Total++
-		_, _ = cyan.Printf("\n  Testing: %s\n", filepath.Base(testFile))
-
-		// Create per-request context (bounded by both httpTimeout and parentCtx)
-		// This ensures each test gets its own timeout, not "whatever time is left"
-		testCtx, testCancel := context.WithTimeout(parentCtx, httpTimeout)
-		success, err := verifyTestCase(
-			testCtx,
-			testFile,
-			normalizedURL,
-			sessionID,
-			rpToken,
-			rpProject,
-		)
-		testCancel() // Clean up context resources immediately after request
+	// Outcomes are reported in file order, so the output does not depend on completion order
+	runVerifications(
+		parentCtx,
+		testFiles,
+		*parallel,
+		func(ctx context.Context, testFile string, out io.Writer) (bool, error) {
+			return verifyTestCase(ctx, testFile, normalizedURL, sessionID, rpToken, rpProject, out)
+		},
+		func(testFile string, outcome *testOutcome) {
+			results.Total++
+			_, _ = cyan.Printf("\n  Testing: %s\n", filepath.Base(testFile))
+			_, _ = os.Stdout.Write(outcome.output.Bytes())
+
+			if outcome.err != nil {
+				if errors.Is(outcome.err, ErrSkipped) {
+					_, _ = yellow.Printf("    ⚠ Skipped: %v\n", outcome.err)
+					results.Skipped++
+				} else {
+					_, _ = red.Printf("    ✗ Failed: %v\n", outcome.err)
+					results.Failed++
+				}
+				return
+			}
 
-		if err != nil {
-			if errors.Is(err, ErrSkipped) {
-				_, _ = yellow.Printf("    ⚠ Skipped: %v\n", err)
-				results.Skipped++
+			if outcome.success {
+				_, _ = green.Println("    ✓ Passed: Received valid response from MCP server")
+				results.Success++
 			} else {
-				_, _ = red.Printf("    ✗ Failed: %v\n", err)
+				_, _ = red.Println("    ✗ Failed: Tool execution returned error")
 				results.Failed++
 			}
-			continue
-		}
-
-		if success {
-			_, _ = green.Println("    ✓ Passed: Received valid response from MCP server")
-			results.Success++
-		} else {
-			_, _ = red.Println("    ✗ Failed: Tool execution returned error")
-			results.Failed++
-		}
-	}
+		},
+	)
 
 	// Summary
 	_, _ = cyan.Println("\n" + strings.Repeat("=", 60))
@@ -246,67 +261,79 @@ func main() {
 	}
 }
 
-// initializeMCPSession initializes an MCP session and returns the session ID
-func initializeMCPSession(ctx context.Context, serverURL, token, project string) (string, error) {
-	initReq := InitializeRequest{
-		JSONRPC: "2.0",
-		Method:  "initialize",
-		ID:      0,
-		Params: map[string]interface{}{
-			"protocolVersion": "2024-11-05",
-			"capabilities":    map[string]interface{}{},
-			"clientInfo": map[string]interface{}{
-				"name":    "testdata-verifier",
-				"version": "1.0.0",
-			},
-		},
-	}
-
-	body, err := json.Marshal(initReq)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal initialize request: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "POST", serverURL, bytes.NewReader(body))
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("X-Project", project)
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
+// testOutcome is the result of verifying a single test file
+type testOutcome struct {
+	success bool
+	err     error
+	output  bytes.Buffer // details written by the verification, replayed when reported
+}
 
-	// Read response body for debugging
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+// runVerifications verifies the test files with up to parallel concurrent workers. Each test
+// case gets its own per-request context (bounded by both httpTimeout and parentCtx), so a test
+// gets its own timeout, not "whatever time is left". report is called on the calling goroutine
+// for every outcome in the order of testFiles, as soon as the outcome and all preceding ones
+// are available.
+func runVerifications(
+	parentCtx context.Context,
+	testFiles []string,
+	parallel int,
+	verify func(ctx context.Context, testFile string, out io.Writer) (bool, error),
+	report func(testFile string, outcome *testOutcome),
+) {
+	if len(testFiles) == 0 {
+		return
+	}
+
+	outcomes := make([]*testOutcome, len(testFiles))
+	jobs := make(chan int)
+	done := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(max(parallel, 1), len(testFiles)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				outcome := &testOutcome{}
+				testCtx, testCancel := context.WithTimeout(parentCtx, httpTimeout)
+				outcome.success, outcome.err = verify(testCtx, testFiles[i], &outcome.output)
+				testCancel() // Clean up context resources immediately after request
+				outcomes[i] = outcome
+				done <- i
+			}
+		}()
 	}
 
-	// Check HTTP status code - accept any 2xx status
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", fmt.Errorf(
-			"initialize failed with status %d: %s",
-			resp.StatusCode,
-			string(bodyBytes),
-		)
+	go func() {
+		for i := range testFiles {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+		close(done)
+	}()
+
+	completed := make([]bool, len(testFiles))
+	next := 0
+	for i := range done {
+		completed[i] = true
+		for next < len(testFiles) && completed[next] {
+			report(testFiles[next], outcomes[next])
+			next++
+		}
 	}
+}
 
-	sessionID := resp.Header.Get("mcp-session-id")
-	if sessionID == "" {
-		return "", fmt.Errorf("no mcp-session-id in response headers, body: %s", string(bodyBytes))
+// discoverTestFiles finds all JSON test files in the directory (recursively).
+// A non-empty pattern keeps only the files whose base name matches it (filepath.Match syntax).
+func discoverTestFiles(dir, pattern string) ([]string, error) {
+	if pattern != "" {
+		// filepath.Match reports a malformed pattern only when it is evaluated
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
 	}
 
-	return sessionID, nil
-}
-
-// discoverTestFiles finds all JSON test files in the directory (recursively)
-func discoverTestFiles(dir string) ([]string, error) {
 	var files []string
 
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
@@ -315,10 +342,21 @@ func discoverTestFiles(dir string) ([]string, error) {
 		}
 
 		// Only include .json files
-		if !info.IsDir() && filepath.Ext(path) == ".json" {
-			files = append(files, path)
+		if info.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		if pattern != "" {
+			matched, err := filepath.Match(pattern, filepath.Base(path))
+			if err != nil {
+				return err
+			}
+			if !matched {
+				return nil
+			}
 		}
 
+		files = append(files, path)
 		return nil
 	})
 	if err != nil {
@@ -471,6 +509,7 @@ func validateJSONRPCRequest(rawBody string) error {
 func verifyTestCase(
 	ctx context.Context,
 	testFile, serverURL, sessionID, token, project string,
+	out io.Writer,
 ) (bool, error) {
 	// Read and parse test case
 	data, err := os.ReadFile(testFile) //nolint:gosec // testFile is from controlled test directory
@@ -523,7 +562,7 @@ func verifyTestCase(
 	}
 
 	if *verbose {
-		fmt.Printf("      Response: %s\n", string(bodyBytes))
+		_, _ = fmt.Fprintf(out, "      Response: %s\n", string(bodyBytes))
 	}
 
 	// Parse MCP response
@@ -539,7 +578,8 @@ func verifyTestCase(
 
 	if mcpResp.Result != nil && mcpResp.Result.IsError {
 		if len(mcpResp.Result.Content) > 0 {
-			fmt.Printf(
+			_, _ = fmt.Fprintf(
+				out,
 				"      %s\n",
 				color.New(color.FgHiBlack).Sprint(mcpResp.Result.Content[0].Text),
 			)
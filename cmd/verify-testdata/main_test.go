@@ -1,8 +1,17 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestNormalizeMCPURL(t *testing.T) {
@@ -211,3 +220,183 @@ func TestValidateJSONRPCRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestDiscoverTestFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"get_launches_test.json",
+		"get_launch_by_id_test.json",
+		"get_test_item_test.json",
+		"README.md",
+		filepath.Join("mock", "launch_request_test.json"),
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("{}"), 0o600); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    []string
+	}{
+		{
+			name:    "empty pattern discovers all JSON files",
+			pattern: "",
+			want: []string{
+				"get_launch_by_id_test.json",
+				"get_launches_test.json",
+				"get_test_item_test.json",
+				filepath.Join("mock", "launch_request_test.json"),
+			},
+		},
+		{
+			name:    "pattern matches base names in subdirectories",
+			pattern: "*launch*",
+			want: []string{
+				"get_launch_by_id_test.json",
+				"get_launches_test.json",
+				filepath.Join("mock", "launch_request_test.json"),
+			},
+		},
+		{
+			name:    "exact file name",
+			pattern: "get_test_item_test.json",
+			want:    []string{"get_test_item_test.json"},
+		},
+		{
+			name:    "no matches",
+			pattern: "*dashboard*",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := discoverTestFiles(dir, tt.pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, file := range files {
+				rel, err := filepath.Rel(dir, file)
+				if err != nil {
+					t.Fatalf("failed to get relative path: %v", err)
+				}
+				got = append(got, rel)
+			}
+			slices.Sort(got)
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("discoverTestFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverTestFiles_InvalidPattern(t *testing.T) {
+	_, err := discoverTestFiles(t.TempDir(), "[launch")
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+	if !strings.Contains(err.Error(), "invalid pattern") {
+		t.Errorf("expected error to contain %q but got %q", "invalid pattern", err.Error())
+	}
+}
+
+func TestRunVerifications(t *testing.T) {
+	testFiles := make([]string, 8)
+	for i := range testFiles {
+		testFiles[i] = fmt.Sprintf("case_%d_test.json", i)
+	}
+
+	for _, parallel := range []int{1, 3, 20} {
+		t.Run(fmt.Sprintf("parallel=%d", parallel), func(t *testing.T) {
+			var (
+				mu          sync.Mutex
+				inFlight    int
+				maxInFlight int
+			)
+			verify := func(ctx context.Context, testFile string, out io.Writer) (bool, error) {
+				if _, ok := ctx.Deadline(); !ok {
+					t.Errorf("expected a per-request deadline for %s", testFile)
+				}
+
+				mu.Lock()
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				mu.Unlock()
+
+				// Later files finish first, so completion order differs from file order
+				index := slices.Index(testFiles, testFile)
+				time.Sleep(time.Duration(len(testFiles)-index) * time.Millisecond)
+				_, _ = fmt.Fprintf(out, "output of %s", testFile)
+
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+
+				switch index % 3 {
+				case 0:
+					return true, nil
+				case 1:
+					return false, fmt.Errorf("no request body found: %w", ErrSkipped)
+				default:
+					return false, nil
+				}
+			}
+
+			var reported []string
+			runVerifications(
+				t.Context(),
+				testFiles,
+				parallel,
+				verify,
+				func(testFile string, outcome *testOutcome) {
+					reported = append(reported, testFile)
+
+					if got, want := outcome.output.String(), "output of "+testFile; got != want {
+						t.Errorf("output of %s = %q, want %q", testFile, got, want)
+					}
+					index := slices.Index(testFiles, testFile)
+					if outcome.success != (index%3 == 0) {
+						t.Errorf("success of %s = %v", testFile, outcome.success)
+					}
+					if errors.Is(outcome.err, ErrSkipped) != (index%3 == 1) {
+						t.Errorf("error of %s = %v", testFile, outcome.err)
+					}
+				},
+			)
+
+			if !slices.Equal(reported, testFiles) {
+				t.Errorf("reported order = %v, want %v", reported, testFiles)
+			}
+			if maxInFlight > parallel {
+				t.Errorf("max in-flight verifications = %d, want at most %d", maxInFlight, parallel)
+			}
+			if parallel == 1 && maxInFlight != 1 {
+				t.Errorf("serial run had %d in-flight verifications", maxInFlight)
+			}
+		})
+	}
+}
+
+func TestRunVerifications_NoFiles(t *testing.T) {
+	runVerifications(
+		t.Context(),
+		nil,
+		4,
+		func(context.Context, string, io.Writer) (bool, error) {
+			t.Error("verify must not be called without test files")
+			return false, nil
+		},
+		func(string, *testOutcome) {
+			t.Error("report must not be called without test files")
+		},
+	)
+}
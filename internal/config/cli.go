@@ -8,6 +8,9 @@ import (
 	"strings"
 
 	"github.com/urfave/cli/v3"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/promptreader"
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
 )
 
 var (
@@ -24,10 +27,12 @@ ENVIRONMENT VARIABLES:
                      Controls which server type to run and which flags are available
    RP_INSECURE_TLS   Skip TLS certificate verification (boolean, default false)
                      Equivalent to --insecure flag; use for self-signed or mismatched certs
+                     Also read from RP_TLS_SKIP_VERIFY; a warning is logged when enabled
                      Mutually exclusive with RP_TLS_CA_CERT / --tls-ca-cert (cannot set both)
                      Example: RP_INSECURE_TLS=true
    RP_TLS_CA_CERT    Path to a PEM file containing trusted CA certificate(s) for TLS verification
                      Equivalent to --tls-ca-cert flag; appended to the system cert pool
+                     Also read from RP_CA_CERT_FILE
                      Mutually exclusive with RP_INSECURE_TLS / --insecure (cannot set both)
                      Example: RP_TLS_CA_CERT=/etc/ssl/certs/my-ca.pem
    RP_PROJECT        Default project key for all MCP tool calls (optional).
@@ -38,23 +43,56 @@ ENVIRONMENT VARIABLES:
                      The per-call 'projectKey' argument is only used as a fallback when no
                      project is available from the context (env variable or HTTP header).
                      Example: RP_PROJECT=my_project
+   RP_DEFAULT_PAGE_SIZE
+                     Page size used when a tool call omits page-size (1-300, default 50)
+                     Equivalent to --default-page-size flag
+                     Example: RP_DEFAULT_PAGE_SIZE=20
+   RP_DEFAULT_SORT_LAUNCHES, RP_DEFAULT_SORT_ITEMS, RP_DEFAULT_SORT_SUITES, RP_DEFAULT_SORT_LOGS
+                     Sort order used when a tool call omits page-sort: field[,field...][,ASC|DESC]
+                     Equivalent to the --default-sort-* flags; invalid values fail at startup
+                     Example: RP_DEFAULT_SORT_LAUNCHES=number,DESC
+   RP_MAX_PAGES, RP_MAX_TOTAL_RESULTS
+                     Caps of tool calls with fetch_all: pages read (default 20) and results
+                     returned (default 5000); the result is flagged as truncated beyond them
+   RP_UI_LAUNCH_PATH, RP_UI_ITEM_PATH
+                     UI paths used for the webUrl links of tools called with include_links
+                     Defaults: /ui/#{project}/launches/all/{launchId} and
+                     /ui/#{project}/launches/all/{launchId}/{itemPath}
+   RP_PRETTY_JSON    Indent JSON tool results for readable transcripts (boolean, default false)
+                     Equivalent to --pretty flag; minified output costs fewer tokens
+   RP_MAX_PROMPT_OUTPUT_BYTES
+                     Maximum size of the messages rendered by one prompt request (default 1048576)
+                     Equivalent to --max-prompt-output-bytes flag; larger prompts fail
+   RP_CONFIG_FILE    Path to a .env or YAML file with any of the variables above (including MCP_MODE)
+                     Equivalent to --config flag; keys may also be flag names (e.g. rp-host)
+                     Environment variables and flags take precedence over the file
+                     Example: RP_CONFIG_FILE=/etc/reportportal-mcp/config.yaml
+
+DIAGNOSTICS:
+   Run the 'doctor' subcommand to check RP_HOST reachability, the API token and the project
+   and print a pass/fail report without starting the server
 
 AUTHENTICATION:
    stdio mode: RP_API_TOKEN is REQUIRED (must be set via environment variable or --token flag)
    http mode:  RP_API_TOKEN and --token are COMPLETELY IGNORED
                Tokens MUST be passed per-request via 'Authorization: Bearer <token>' header
+               (or the bare token in the RP_TOKEN_HEADER header, e.g. X-RP-Token)
 
 ANALYTICS:
    stdio mode: RP_API_TOKEN is required for analytics (used for secure user identification)
    http mode:  Analytics uses RP_USER_ID env var for identification
-               Use --analytics-off or RP_MCP_ANALYTICS_OFF=true to disable analytics`
+               Use --analytics-off or RP_MCP_ANALYTICS_OFF=true to disable analytics
+   local:      Set RP_METRICS_FILE to also write usage counters to a local JSON file
+   interval:   RP_ANALYTICS_FLUSH_INTERVAL sets the seconds between flushes (default 10)`
 
 // GetCommonFlags returns the common CLI flags used by all server modes (both stdio and http)
 func GetCommonFlags() []cli.Flag {
 	return []cli.Flag{
 		&cli.StringFlag{
-			Name:     "rp-host",
-			Required: true,
+			Name: "rp-host",
+			// Not required here so that the doctor subcommand can report a missing host;
+			// the server action checks it instead
+			Required: false,
 			Sources:  cli.EnvVars("RP_HOST"),
 			Usage:    "ReportPortal host URL",
 		},
@@ -86,19 +124,150 @@ func GetCommonFlags() []cli.Flag {
 			Usage:    "Disable Google Analytics tracking",
 			Value:    false,
 		},
+		&cli.StringFlag{
+			Name:     "metrics-file",
+			Required: false,
+			Sources:  cli.EnvVars("RP_METRICS_FILE"),
+			Usage:    "Path to a local JSON file where per-tool usage counters are written on each analytics flush (works with --analytics-off)",
+		},
+		&cli.StringFlag{
+			Name:     "ga4-endpoint",
+			Required: false,
+			Sources:  cli.EnvVars("RP_GA4_ENDPOINT"),
+			Usage:    "Override the Google Analytics 4 Measurement Protocol endpoint (e.g. a proxy or self-hosted collector)",
+		},
+		&cli.IntFlag{
+			Name:     "analytics-flush-interval",
+			Required: false,
+			Sources:  cli.EnvVars("RP_ANALYTICS_FLUSH_INTERVAL"),
+			Usage:    "Time in seconds between analytics flushes to GA4 and the metrics file (minimum 1)",
+			Value:    10,
+		},
+		&cli.StringFlag{
+			Name:     "user-agent-suffix",
+			Required: false,
+			Sources:  cli.EnvVars("RP_USER_AGENT_SUFFIX"),
+			Usage:    "Text appended to the User-Agent header (reportportal-mcp-server/<version>) of outbound ReportPortal requests",
+		},
+		&cli.BoolFlag{
+			Name:     "read-only",
+			Required: false,
+			Sources:  cli.EnvVars("RP_READ_ONLY"),
+			Usage:    "Read-only mode: do not expose tools that create, modify or delete data in ReportPortal",
+			Value:    false,
+		},
+		&cli.BoolFlag{
+			Name:     "require-confirm",
+			Required: false,
+			Sources:  cli.EnvVars("RP_REQUIRE_CONFIRM"),
+			Usage:    "Require destructive tools (e.g. launch_delete) to be called with confirm: true",
+			Value:    false,
+		},
 		&cli.BoolFlag{
 			Name:     "insecure",
 			Required: false,
-			Sources:  cli.EnvVars("RP_INSECURE_TLS"),
+			Sources:  cli.EnvVars("RP_INSECURE_TLS", "RP_TLS_SKIP_VERIFY"),
 			Usage:    "Skip TLS certificate verification (use for self-signed or mismatched certs). Mutually exclusive with --tls-ca-cert",
 			Value:    false,
 		},
 		&cli.StringFlag{
 			Name:     "tls-ca-cert",
 			Required: false,
-			Sources:  cli.EnvVars("RP_TLS_CA_CERT"),
+			Sources:  cli.EnvVars("RP_TLS_CA_CERT", "RP_CA_CERT_FILE"),
 			Usage:    "Path to a PEM file containing trusted CA certificate(s) for TLS verification (appended to the system cert pool). Mutually exclusive with --insecure",
 		},
+		&cli.IntFlag{
+			Name:     "cache-size",
+			Required: false,
+			Sources:  cli.EnvVars("RP_CACHE_SIZE"),
+			Usage:    "Maximum number of read-only tool results kept in the response cache (0 = caching disabled)",
+			Value:    0,
+		},
+		&cli.IntFlag{
+			Name:     "cache-ttl",
+			Required: false,
+			Sources:  cli.EnvVars("RP_CACHE_TTL"),
+			Usage:    "Time in seconds a cached tool result is served before ReportPortal is queried again",
+			Value:    60,
+		},
+		&cli.IntFlag{
+			Name:     "default-page-size",
+			Required: false,
+			Sources:  cli.EnvVars("RP_DEFAULT_PAGE_SIZE"),
+			Usage:    fmt.Sprintf("Page size used when a tool call does not specify page-size (1-%d)", utils.MaxDefaultPageSize),
+			Value:    utils.DefaultPageSize,
+		},
+		&cli.IntFlag{
+			Name:     "max-pages",
+			Required: false,
+			Sources:  cli.EnvVars("RP_MAX_PAGES"),
+			Usage:    "Maximum number of pages read by a tool call with fetch_all",
+			Value:    utils.DefaultFetchAllMaxPages,
+		},
+		&cli.IntFlag{
+			Name:     "max-total-results",
+			Required: false,
+			Sources:  cli.EnvVars("RP_MAX_TOTAL_RESULTS"),
+			Usage:    "Maximum number of results returned by a tool call with fetch_all",
+			Value:    utils.DefaultFetchAllMaxTotalResults,
+		},
+		&cli.StringFlag{
+			Name:     "default-sort-launches",
+			Required: false,
+			Sources:  cli.EnvVars("RP_DEFAULT_SORT_LAUNCHES"),
+			Usage:    "Sort order used for launches when a tool call does not specify page-sort",
+			Value:    utils.DefaultSortingForLaunches,
+		},
+		&cli.StringFlag{
+			Name:     "default-sort-items",
+			Required: false,
+			Sources:  cli.EnvVars("RP_DEFAULT_SORT_ITEMS"),
+			Usage:    "Sort order used for test items when a tool call does not specify page-sort",
+			Value:    utils.DefaultSortingForItems,
+		},
+		&cli.StringFlag{
+			Name:     "default-sort-suites",
+			Required: false,
+			Sources:  cli.EnvVars("RP_DEFAULT_SORT_SUITES"),
+			Usage:    "Sort order used for test suites when a tool call does not specify page-sort",
+			Value:    utils.DefaultSortingForSuites,
+		},
+		&cli.StringFlag{
+			Name:     "default-sort-logs",
+			Required: false,
+			Sources:  cli.EnvVars("RP_DEFAULT_SORT_LOGS"),
+			Usage:    "Sort order used for logs when a tool call does not specify page-sort",
+			Value:    utils.DefaultSortingForLogs,
+		},
+		&cli.StringFlag{
+			Name:     "ui-launch-path",
+			Required: false,
+			Sources:  cli.EnvVars("RP_UI_LAUNCH_PATH"),
+			Usage:    "ReportPortal UI path of a launch used for include_links web URLs; placeholders {project} and {launchId}",
+			Value:    utils.DefaultLaunchUIPath,
+		},
+		&cli.StringFlag{
+			Name:     "ui-item-path",
+			Required: false,
+			Sources:  cli.EnvVars("RP_UI_ITEM_PATH"),
+			Usage:    "ReportPortal UI path of a test item used for include_links web URLs; placeholders {project}, {launchId}, {itemId} and {itemPath} (ancestor IDs joined by '/')",
+			Value:    utils.DefaultItemUIPath,
+		},
+		&cli.IntFlag{
+			Name:     "max-prompt-output-bytes",
+			Required: false,
+			Sources:  cli.EnvVars("RP_MAX_PROMPT_OUTPUT_BYTES"),
+			Usage:    "Maximum size in bytes of the messages rendered by one prompt request; larger prompts fail",
+			Value:    promptreader.DefaultMaxOutputBytes,
+		},
+		&cli.BoolFlag{
+			Name:     "pretty",
+			Required: false,
+			Sources:  cli.EnvVars("RP_PRETTY_JSON"),
+			Usage:    "Indent JSON tool results for human-readable transcripts (costs more tokens)",
+			Value:    false,
+		},
+		GetConfigFileFlag(),
 	}
 }
 
@@ -133,6 +302,83 @@ func GetHTTPFlags() []cli.Flag {
 			Usage:    "[HTTP-ONLY] Connection timeout in seconds",
 			Value:    30,
 		},
+		&cli.IntFlag{
+			Name:     "shutdown-timeout",
+			Required: false,
+			Sources:  cli.EnvVars("RP_SHUTDOWN_TIMEOUT"),
+			Usage:    "[HTTP-ONLY] Time in seconds to wait for in-flight requests to complete on shutdown",
+			Value:    5,
+		},
+		&cli.IntFlag{
+			Name:     "session-idle-timeout",
+			Required: false,
+			Sources:  cli.EnvVars("RP_SESSION_IDLE_TIMEOUT"),
+			Usage:    "[HTTP-ONLY] Time in seconds after which a streamable HTTP session without requests is closed and its resources freed (0 = sessions are never closed); not applied to the SSE transport",
+			Value:    1800,
+		},
+		&cli.IntFlag{
+			Name:     "max-request-bytes",
+			Required: false,
+			Sources:  cli.EnvVars("RP_MAX_REQUEST_BYTES"),
+			Usage:    "[HTTP-ONLY] Maximum request body size in bytes; larger requests are rejected with 413. Also caps import_launch_from_file uploads (base64 content is a third larger than the file)",
+			Value:    4 << 20,
+		},
+		&cli.IntFlag{
+			Name:     "max-idle-conns",
+			Required: false,
+			Sources:  cli.EnvVars("RP_MAX_IDLE_CONNS"),
+			Usage:    "[HTTP-ONLY] Maximum number of idle connections kept open to ReportPortal",
+			Value:    100,
+		},
+		&cli.IntFlag{
+			Name:     "max-idle-conns-per-host",
+			Required: false,
+			Sources:  cli.EnvVars("RP_MAX_IDLE_CONNS_PER_HOST"),
+			Usage:    "[HTTP-ONLY] Maximum number of idle connections kept open per ReportPortal host",
+			Value:    10,
+		},
+		&cli.IntFlag{
+			Name:     "per-token-concurrency",
+			Required: false,
+			Sources:  cli.EnvVars("RP_PER_TOKEN_CONCURRENCY"),
+			Usage:    "[HTTP-ONLY] Maximum number of in-flight requests per API token; further requests get 429 (0 = no per-token limit)",
+			Value:    0,
+		},
+		&cli.BoolFlag{
+			Name:     "validate-token",
+			Required: false,
+			Sources:  cli.EnvVars("RP_VALIDATE_TOKEN"),
+			Usage:    "[HTTP-ONLY] Validate bearer tokens against ReportPortal before dispatching MCP requests and reply 401 for rejected tokens",
+			Value:    false,
+		},
+		&cli.IntFlag{
+			Name:     "validate-token-ttl",
+			Required: false,
+			Sources:  cli.EnvVars("RP_VALIDATE_TOKEN_TTL"),
+			Usage:    "[HTTP-ONLY] Time in seconds a successfully validated token is cached",
+			Value:    300,
+		},
+		&cli.StringFlag{
+			Name:     "access-log-level",
+			Required: false,
+			Sources:  cli.EnvVars("RP_ACCESS_LOG_LEVEL"),
+			Usage:    "[HTTP-ONLY] Level of the per-request access log entries (method, path, status, latency, tool); set it below --log-level to hide them",
+			Value:    slog.LevelInfo.String(),
+		},
+		&cli.StringFlag{
+			Name:     "transport",
+			Required: false,
+			Sources:  cli.EnvVars("MCP_TRANSPORT"),
+			Usage:    "[HTTP-ONLY] MCP transport served over HTTP: streamable (streamable HTTP on /mcp and /api/mcp) or sse (legacy HTTP+SSE transport on /sse)",
+			Value:    "streamable",
+		},
+		&cli.StringFlag{
+			Name:     "token-header",
+			Required: false,
+			Sources:  cli.EnvVars("RP_TOKEN_HEADER"),
+			Usage:    "[HTTP-ONLY] Request header carrying the RP API token, e.g. X-RP-Token for gateways that strip Authorization; only Authorization expects the 'Bearer ' prefix",
+			Value:    "Authorization",
+		},
 	}
 }
 
@@ -173,11 +419,14 @@ func InitLogger() func(ctx context.Context, command *cli.Command) (context.Conte
 		if err := logLevel.UnmarshalText([]byte(command.String("log-level"))); err != nil {
 			return nil, err
 		}
+		// Request-scoped log lines (slog.*Context) get the X-Request-ID attached
 		slog.SetDefault(
 			slog.New(
-				slog.NewTextHandler(
-					os.Stderr,
-					&slog.HandlerOptions{Level: logLevel},
+				utils.NewRequestIDLogHandler(
+					slog.NewTextHandler(
+						os.Stderr,
+						&slog.HandlerOptions{Level: logLevel},
+					),
 				),
 			),
 		)
@@ -208,8 +457,12 @@ func InitAppConfig(
 		Version:     fmt.Sprintf("%s (%s) %s", Version, Commit, Date),
 		Description: ServerDescription,
 		Flags:       allFlags,
+		Commands:    []*cli.Command{GetDoctorCommand()},
 		Before:      InitLogger(),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if strings.TrimSpace(cmd.String("rp-host")) == "" {
+				return fmt.Errorf("required flag \"rp-host\" (RP_HOST) not set")
+			}
 			if cmd.Bool("insecure") && cmd.String("tls-ca-cert") != "" {
 				return fmt.Errorf(
 					"--insecure and --tls-ca-cert are mutually exclusive: use one or the other, not both",
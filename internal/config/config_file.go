@@ -0,0 +1,226 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// configFileFlag is the flag pointing to a .env or YAML configuration file
+	configFileFlag = "config"
+	// configFileEnvVar is the environment variable alternative to --config
+	configFileEnvVar = "RP_CONFIG_FILE"
+	// mcpModeEnvVar selects the server mode; it is not a flag but may be set in the config file
+	mcpModeEnvVar = "MCP_MODE"
+)
+
+// GetConfigFileFlag returns the --config flag. The file is applied by LoadConfigFile before the
+// flags are parsed; the flag is declared so that it is accepted and shown in the help.
+func GetConfigFileFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:     configFileFlag,
+		Required: false,
+		Sources:  cli.EnvVars(configFileEnvVar),
+		Usage:    "Path to a .env or YAML (.yaml, .yml) file with configuration keys named like the environment variables (e.g. RP_HOST) or the flags (e.g. rp-host). Environment variables and flags take precedence over the file",
+	}
+}
+
+// RunApp loads the configuration file given by --config or RP_CONFIG_FILE, if any, then builds
+// the CLI command and runs it with args
+func RunApp(
+	ctx context.Context,
+	args []string,
+	runHTTPServer, runStdioServer func(context.Context, *cli.Command) error,
+) error {
+	if err := LoadConfigFile(args); err != nil {
+		return err
+	}
+	return InitAppConfig(runHTTPServer, runStdioServer).Run(ctx, args)
+}
+
+// LoadConfigFile reads the configuration file given by --config (or RP_CONFIG_FILE) in args and
+// exports its values as the environment variables of the matching flags. Environment variables
+// that are already set are left untouched, so explicit environment variables and flags override
+// the file. It does nothing when no configuration file is given.
+func LoadConfigFile(args []string) error {
+	path := configFilePath(args)
+	if path == "" {
+		return nil
+	}
+
+	values, err := parseConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	envVars := configEnvVars()
+	for key, value := range values {
+		envVar, ok := envVars[key]
+		if !ok {
+			slog.Warn("ignoring unknown key in config file", "key", key, "file", path)
+			continue
+		}
+		if _, set := os.LookupEnv(envVar); set {
+			continue
+		}
+		if err := os.Setenv(envVar, value); err != nil {
+			return fmt.Errorf("failed to apply config file key %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// configFilePath returns the value of --config in args (the program name first), falling back to
+// RP_CONFIG_FILE
+func configFilePath(args []string) string {
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		name := strings.TrimLeft(arg, "-")
+		if name == arg {
+			continue
+		}
+		if value, ok := strings.CutPrefix(name, configFileFlag+"="); ok {
+			return value
+		}
+		if name == configFileFlag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return os.Getenv(configFileEnvVar)
+}
+
+// configEnvVars maps every accepted config file key to the environment variable it sets: the
+// environment variables of all flags map to themselves and flag names map to the first
+// environment variable of the flag
+func configEnvVars() map[string]string {
+	envVars := map[string]string{
+		mcpModeEnvVar: mcpModeEnvVar,
+		"mcp-mode":    mcpModeEnvVar,
+	}
+
+	var flags []cli.Flag
+	flags = append(flags, GetCommonFlags()...)
+	flags = append(flags, GetHTTPFlags()...)
+	flags = append(flags, GetStdioFlags()...)
+	for _, flag := range flags {
+		docFlag, ok := flag.(cli.DocGenerationFlag)
+		if !ok {
+			continue
+		}
+		flagEnvVars := docFlag.GetEnvVars()
+		if len(flagEnvVars) == 0 || slices.Contains(flag.Names(), configFileFlag) {
+			continue
+		}
+		for _, envVar := range flagEnvVars {
+			envVars[envVar] = envVar
+		}
+		for _, name := range flag.Names() {
+			envVars[name] = flagEnvVars[0]
+		}
+	}
+
+	return envVars
+}
+
+// parseConfigFile reads a YAML file (.yaml or .yml extension) or a .env file into a map of keys
+// to values
+func parseConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var values map[string]string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		values, err = parseYAMLConfig(data)
+	default:
+		values, err = parseDotEnvConfig(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+// parseYAMLConfig parses a flat YAML mapping of keys to scalar values
+func parseYAMLConfig(data []byte) (map[string]string, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		switch v := value.(type) {
+		case nil:
+			values[key] = ""
+		case map[string]any, []any:
+			return nil, fmt.Errorf("key %q must have a scalar value", key)
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+
+	return values, nil
+}
+
+// parseDotEnvConfig parses KEY=VALUE lines. Blank lines and lines starting with '#' are skipped,
+// an "export " prefix is allowed, and values may be single or double quoted; unquoted values
+// end at an inline " #" comment.
+func parseDotEnvConfig(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)
+		}
+
+		value = strings.TrimSpace(value)
+		switch {
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid quoted value: %w", lineNumber, err)
+			}
+			value = unquoted
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		default:
+			if comment := strings.Index(value, " #"); comment >= 0 {
+				value = strings.TrimSpace(value[:comment])
+			}
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+// writeConfigFile writes content to a temp file with the given name and returns its path
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	return path
+}
+
+// unsetEnv unsets the given environment variables for the duration of the test and restores
+// them afterwards, so values exported by LoadConfigFile do not leak into other tests
+func unsetEnv(t *testing.T, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		t.Setenv(key, "")
+		if err := os.Unsetenv(key); err != nil {
+			t.Fatalf("unset %s: %v", key, err)
+		}
+	}
+}
+
+func TestLoadConfigFile_DotEnv(t *testing.T) {
+	unsetEnv(t, configFileEnvVar, "RP_HOST", "RP_PROJECT", "LOG_LEVEL", "RP_DEFAULT_PAGE_SIZE")
+	path := writeConfigFile(t, ".env", `# ReportPortal settings
+RP_HOST=https://rp.example.com
+export RP_PROJECT="my_project"
+log-level='DEBUG'
+default-page-size=20 # inline comment
+`)
+
+	if err := LoadConfigFile([]string{"app", "--config", path}); err != nil {
+		t.Fatalf("LoadConfigFile: %v", err)
+	}
+
+	for key, want := range map[string]string{
+		"RP_HOST":              "https://rp.example.com",
+		"RP_PROJECT":           "my_project",
+		"LOG_LEVEL":            "DEBUG",
+		"RP_DEFAULT_PAGE_SIZE": "20",
+	} {
+		if got := os.Getenv(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestLoadConfigFile_YAML(t *testing.T) {
+	unsetEnv(t, configFileEnvVar, "RP_HOST", "RP_INSECURE_TLS", "MCP_MODE")
+	path := writeConfigFile(t, "config.yaml", `RP_HOST: https://rp.example.com
+insecure: true
+MCP_MODE: http
+`)
+
+	if err := LoadConfigFile([]string{"app", "--config=" + path}); err != nil {
+		t.Fatalf("LoadConfigFile: %v", err)
+	}
+
+	for key, want := range map[string]string{
+		"RP_HOST":         "https://rp.example.com",
+		"RP_INSECURE_TLS": "true",
+		"MCP_MODE":        "http",
+	} {
+		if got := os.Getenv(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestLoadConfigFile_EnvOverridesFile(t *testing.T) {
+	unsetEnv(t, "RP_HOST")
+	t.Setenv("RP_PROJECT", "from_env")
+	path := writeConfigFile(t, "config.env", "RP_HOST=https://rp.example.com\nRP_PROJECT=from_file\n")
+	t.Setenv(configFileEnvVar, path)
+
+	if err := LoadConfigFile([]string{"app"}); err != nil {
+		t.Fatalf("LoadConfigFile: %v", err)
+	}
+
+	if got := os.Getenv("RP_PROJECT"); got != "from_env" {
+		t.Errorf("RP_PROJECT = %q, want %q", got, "from_env")
+	}
+	if got := os.Getenv("RP_HOST"); got != "https://rp.example.com" {
+		t.Errorf("RP_HOST = %q, want %q", got, "https://rp.example.com")
+	}
+}
+
+func TestLoadConfigFile_NoConfig(t *testing.T) {
+	unsetEnv(t, configFileEnvVar)
+	if err := LoadConfigFile([]string{"app", "--rp-host", "https://rp.example.com"}); err != nil {
+		t.Fatalf("LoadConfigFile: %v", err)
+	}
+}
+
+func TestLoadConfigFile_Errors(t *testing.T) {
+	unsetEnv(t, configFileEnvVar)
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.env")},
+		{name: "malformed env line", path: writeConfigFile(t, "bad.env", "RP_HOST\n")},
+		{name: "nested yaml value", path: writeConfigFile(t, "bad.yml", "RP_HOST:\n  url: x\n")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := LoadConfigFile([]string{"app", "--config", tt.path}); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestRunApp_ConfigFile(t *testing.T) {
+	unsetEnv(t, configFileEnvVar, "MCP_MODE", "RP_HOST", "RP_API_TOKEN", "LOG_LEVEL")
+	t.Setenv("RP_PROJECT", "from_env")
+	path := writeConfigFile(t, "config.yml", `RP_HOST: https://rp.example.com
+RP_API_TOKEN: secret
+RP_PROJECT: from_file
+LOG_LEVEL: ERROR
+`)
+
+	var host, project, logLevel string
+	runStdio := func(_ context.Context, cmd *cli.Command) error {
+		host = cmd.String("rp-host")
+		project = cmd.String("project")
+		logLevel = cmd.String("log-level")
+		return nil
+	}
+	runHTTP := func(context.Context, *cli.Command) error {
+		t.Fatal("unexpected http mode")
+		return nil
+	}
+
+	args := []string{"app", "--config", path, "--log-level", "WARN"}
+	if err := RunApp(context.Background(), args, runHTTP, runStdio); err != nil {
+		t.Fatalf("RunApp: %v", err)
+	}
+
+	if host != "https://rp.example.com" {
+		t.Errorf("rp-host = %q, want value from the config file", host)
+	}
+	if project != "from_env" {
+		t.Errorf("project = %q, want the environment variable to override the file", project)
+	}
+	if logLevel != "WARN" {
+		t.Errorf("log-level = %q, want the flag to override the file", logLevel)
+	}
+}
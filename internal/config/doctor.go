@@ -0,0 +1,253 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
+)
+
+// doctorRequestTimeout bounds each request made by the doctor checks
+const doctorRequestTimeout = 15 * time.Second
+
+// DoctorCheck is the outcome of a single doctor check
+type DoctorCheck struct {
+	Name    string
+	Passed  bool
+	Skipped bool
+	Detail  string
+}
+
+// GetDoctorCommand returns the doctor subcommand. It checks that RP_HOST is reachable, that the
+// API token is accepted and that the project exists, prints a pass/fail report and exits without
+// starting the server. The ReportPortal connection flags are inherited from the root command.
+func GetDoctorCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "doctor",
+		Usage: "Check the ReportPortal host, API token and project, print a report and exit without starting the server",
+		Flags: []cli.Flag{
+			// Declared here as well because the root command only has it in stdio mode
+			&cli.StringFlag{
+				Name:     "token",
+				Required: false,
+				Sources:  cli.EnvVars("RP_API_TOKEN"),
+				Usage:    "API token to validate",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			tlsCfg, err := BuildTLSConfig(cmd.Bool("insecure"), cmd.String("tls-ca-cert"))
+			if err != nil {
+				return err
+			}
+			client := &http.Client{Timeout: doctorRequestTimeout}
+			if tlsCfg != nil {
+				transport := http.DefaultTransport.(*http.Transport).Clone()
+				transport.TLSClientConfig = tlsCfg
+				client.Transport = transport
+			}
+
+			checks := RunDoctorChecks(
+				ctx,
+				client,
+				cmd.String("rp-host"),
+				cmd.String("token"),
+				cmd.String("project"),
+			)
+
+			out := cmd.Root().Writer
+			if out == nil {
+				out = os.Stdout
+			}
+			return WriteDoctorReport(out, checks)
+		},
+	}
+}
+
+// RunDoctorChecks runs the doctor checks in order. A check whose prerequisite failed is skipped.
+func RunDoctorChecks(
+	ctx context.Context,
+	client *http.Client,
+	host, token, project string,
+) []DoctorCheck {
+	host = strings.TrimRight(strings.TrimSpace(host), "/")
+	project = strings.TrimSpace(project)
+
+	hostCheck := checkHostReachable(ctx, client, host)
+	checks := []DoctorCheck{hostCheck}
+
+	tokenCheck := DoctorCheck{Name: "API token is valid"}
+	switch {
+	case !hostCheck.Passed:
+		tokenCheck.Skipped = true
+		tokenCheck.Detail = "RP_HOST is not reachable"
+	case token == "":
+		tokenCheck.Detail = "RP_API_TOKEN / --token is not set"
+	default:
+		tokenCheck = checkToken(ctx, client, host, token)
+	}
+	checks = append(checks, tokenCheck)
+
+	projectCheck := DoctorCheck{Name: "Project exists"}
+	switch {
+	case project == "":
+		projectCheck.Skipped = true
+		projectCheck.Detail = "RP_PROJECT / --project is not set; " +
+			"the projectKey tool argument will be required"
+	case !tokenCheck.Passed:
+		projectCheck.Skipped = true
+		projectCheck.Detail = "API token is not valid"
+	default:
+		projectCheck = checkProject(ctx, client, host, token, project)
+	}
+
+	return append(checks, projectCheck)
+}
+
+// WriteDoctorReport prints one line per check and returns an error when any check failed
+func WriteDoctorReport(w io.Writer, checks []DoctorCheck) error {
+	failed := 0
+	for _, check := range checks {
+		status := "PASS"
+		switch {
+		case check.Skipped:
+			status = "SKIP"
+		case !check.Passed:
+			status = "FAIL"
+			failed++
+		}
+		if _, err := fmt.Fprintf(w, "[%s] %s: %s\n", status, check.Name, check.Detail); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+	_, err := fmt.Fprintln(w, "All checks passed")
+	return err
+}
+
+// checkHostReachable passes when the host answers with any HTTP response
+func checkHostReachable(ctx context.Context, client *http.Client, host string) DoctorCheck {
+	check := DoctorCheck{Name: "RP_HOST is reachable"}
+
+	if host == "" {
+		check.Detail = "RP_HOST / --rp-host is not set"
+		return check
+	}
+	parsed, err := url.Parse(host)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		check.Detail = fmt.Sprintf(
+			"%q is not a valid URL, expected e.g. https://reportportal.example.com",
+			host,
+		)
+		return check
+	}
+
+	resp, err := doctorRequest(ctx, client, host+"/", "")
+	if err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+	_ = resp.Body.Close()
+
+	check.Passed = true
+	check.Detail = fmt.Sprintf("%s responded with HTTP %d", host, resp.StatusCode)
+	return check
+}
+
+// checkToken validates the token by requesting the current user
+func checkToken(ctx context.Context, client *http.Client, host, token string) DoctorCheck {
+	check := DoctorCheck{Name: "API token is valid"}
+
+	resp, err := doctorRequest(ctx, client, host+"/api/v1/users", token)
+	if err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var user struct {
+			UserID string `json:"userId"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&user)
+		check.Passed = true
+		check.Detail = "token accepted"
+		if user.UserID != "" {
+			check.Detail = fmt.Sprintf("authenticated as %s", user.UserID)
+		}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		check.Detail = fmt.Sprintf(
+			"token rejected with HTTP %d; generate a new API token in your ReportPortal profile",
+			resp.StatusCode,
+		)
+	default:
+		check.Detail = fmt.Sprintf(
+			"unexpected HTTP %d from %s; check that RP_HOST points to the ReportPortal root URL",
+			resp.StatusCode,
+			resp.Request.URL,
+		)
+	}
+	return check
+}
+
+// checkProject confirms the project exists and is visible to the token
+func checkProject(
+	ctx context.Context,
+	client *http.Client,
+	host, token, project string,
+) DoctorCheck {
+	check := DoctorCheck{Name: "Project exists"}
+
+	resp, err := doctorRequest(ctx, client, host+"/api/v1/project/"+url.PathEscape(project), token)
+	if err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+	_ = resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		check.Passed = true
+		check.Detail = fmt.Sprintf("project %q found", project)
+	case http.StatusNotFound:
+		check.Detail = fmt.Sprintf(
+			"project %q not found; use the project key shown in the UI URL after '#', not the display name",
+			project,
+		)
+	case http.StatusForbidden:
+		check.Detail = fmt.Sprintf("the token has no access to project %q", project)
+	default:
+		check.Detail = fmt.Sprintf("unexpected HTTP %d for project %q", resp.StatusCode, project)
+	}
+	return check
+}
+
+// doctorRequest sends a GET request, authenticated when token is set
+func doctorRequest(
+	ctx context.Context,
+	client *http.Client,
+	requestURL, token string,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", utils.BuildUserAgent(Version, "doctor"))
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return client.Do(req)
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+const doctorTestToken = "valid-token"
+
+// newDoctorTestServer serves the endpoints used by the doctor checks; only doctorTestToken is
+// accepted and only the "my_project" project exists
+func newDoctorTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	authorized := func(r *http.Request) bool {
+		return r.Header.Get("Authorization") == "Bearer "+doctorTestToken
+	}
+	mux.HandleFunc("/api/v1/users", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(r) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"userId":"jdoe"}`))
+	})
+	mux.HandleFunc("/api/v1/project/{projectKey}", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(r) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.PathValue("projectKey") != "my_project" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"projectName":"my_project"}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func checkStatuses(checks []DoctorCheck) string {
+	statuses := make([]string, 0, len(checks))
+	for _, check := range checks {
+		switch {
+		case check.Skipped:
+			statuses = append(statuses, "SKIP")
+		case check.Passed:
+			statuses = append(statuses, "PASS")
+		default:
+			statuses = append(statuses, "FAIL")
+		}
+	}
+	return strings.Join(statuses, ",")
+}
+
+func TestRunDoctorChecks(t *testing.T) {
+	server := newDoctorTestServer(t)
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name    string
+		host    string
+		token   string
+		project string
+		want    string
+	}{
+		{
+			name:    "all pass",
+			host:    server.URL + "/",
+			token:   doctorTestToken,
+			project: "my_project",
+			want:    "PASS,PASS,PASS",
+		},
+		{
+			name:  "no project",
+			host:  server.URL,
+			token: doctorTestToken,
+			want:  "PASS,PASS,SKIP",
+		},
+		{
+			name:    "unknown project",
+			host:    server.URL,
+			token:   doctorTestToken,
+			project: "other",
+			want:    "PASS,PASS,FAIL",
+		},
+		{
+			name:    "invalid token",
+			host:    server.URL,
+			token:   "bad",
+			project: "my_project",
+			want:    "PASS,FAIL,SKIP",
+		},
+		{
+			name:    "missing token",
+			host:    server.URL,
+			project: "my_project",
+			want:    "PASS,FAIL,SKIP",
+		},
+		{
+			name:    "unreachable host",
+			host:    closed.URL,
+			token:   doctorTestToken,
+			project: "my_project",
+			want:    "FAIL,SKIP,SKIP",
+		},
+		{
+			name:  "invalid host",
+			host:  "reportportal",
+			token: doctorTestToken,
+			want:  "FAIL,SKIP,SKIP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks := RunDoctorChecks(
+				context.Background(),
+				server.Client(),
+				tt.host,
+				tt.token,
+				tt.project,
+			)
+			if got := checkStatuses(checks); got != tt.want {
+				t.Errorf("statuses = %s, want %s (checks: %+v)", got, tt.want, checks)
+			}
+		})
+	}
+}
+
+func TestWriteDoctorReport(t *testing.T) {
+	var out bytes.Buffer
+	err := WriteDoctorReport(&out, []DoctorCheck{
+		{Name: "first", Passed: true, Detail: "ok"},
+		{Name: "second", Detail: "broken"},
+		{Name: "third", Skipped: true, Detail: "not set"},
+	})
+	if err == nil || err.Error() != "1 of 3 checks failed" {
+		t.Fatalf("err = %v, want 1 of 3 checks failed", err)
+	}
+	want := "[PASS] first: ok\n[FAIL] second: broken\n[SKIP] third: not set\n"
+	if out.String() != want {
+		t.Errorf("report = %q, want %q", out.String(), want)
+	}
+}
+
+func TestDoctorCommand(t *testing.T) {
+	server := newDoctorTestServer(t)
+	unsetEnv(t, "MCP_MODE", configFileEnvVar)
+	t.Setenv("RP_HOST", server.URL)
+	t.Setenv("RP_API_TOKEN", doctorTestToken)
+	t.Setenv("RP_PROJECT", "my_project")
+
+	runServer := func(context.Context, *cli.Command) error {
+		t.Fatal("doctor must not start the server")
+		return nil
+	}
+	cmd := InitAppConfig(runServer, runServer)
+	var out bytes.Buffer
+	cmd.Writer = &out
+
+	if err := cmd.Run(context.Background(), []string{"app", "doctor"}); err != nil {
+		t.Fatalf("doctor: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "authenticated as jdoe") ||
+		!strings.HasSuffix(out.String(), "All checks passed\n") {
+		t.Errorf("unexpected report:\n%s", out.String())
+	}
+}
+
+// TestDoctorCommand_MissingHost verifies that doctor reports a missing RP_HOST instead of failing
+// on flag parsing, while starting the server without it is still rejected
+func TestDoctorCommand_MissingHost(t *testing.T) {
+	unsetEnv(t, "MCP_MODE", configFileEnvVar, "RP_HOST", "RP_PROJECT")
+	t.Setenv("RP_API_TOKEN", doctorTestToken)
+
+	runServer := func(context.Context, *cli.Command) error {
+		t.Fatal("the server must not start without RP_HOST")
+		return nil
+	}
+
+	cmd := InitAppConfig(runServer, runServer)
+	var out bytes.Buffer
+	cmd.Writer = &out
+	if err := cmd.Run(context.Background(), []string{"app", "doctor"}); err == nil {
+		t.Fatalf("doctor passed without RP_HOST:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "[FAIL] RP_HOST is reachable: RP_HOST / --rp-host is not set") {
+		t.Errorf("unexpected report:\n%s", out.String())
+	}
+
+	err := InitAppConfig(runServer, runServer).Run(context.Background(), []string{"app"})
+	if err == nil || !strings.Contains(err.Error(), "rp-host") {
+		t.Errorf("err = %v, want a missing rp-host error", err)
+	}
+}
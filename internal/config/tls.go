@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"log/slog"
 	"os"
 )
 
@@ -28,6 +29,13 @@ func BuildTLSConfig(insecure bool, caCertPath string) (*tls.Config, error) {
 		return nil, nil
 	}
 
+	if insecure {
+		slog.Warn(
+			"TLS certificate verification is DISABLED: connections to ReportPortal are open to " +
+				"man-in-the-middle attacks; prefer --tls-ca-cert for self-signed certificates",
+		)
+	}
+
 	tlsCfg := &tls.Config{
 		InsecureSkipVerify: insecure, //nolint:gosec
 		MinVersion:         tls.VersionTLS12,
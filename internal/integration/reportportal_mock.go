@@ -1,11 +1,16 @@
 package integration
 
 import (
+	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	neturl "net/url"
+	"slices"
 	"strings"
 	"sync"
 
@@ -246,22 +251,28 @@ func (m *ReportPortalMockServer) matchesRequest(
 	}
 
 	// Check body (if present)
-	// NOTE: This implementation only supports matching "raw" body mode (JSON payloads).
-	// Other body modes (urlencoded, formdata, etc.) are not currently matched.
-	//
-	// This limitation is acceptable because:
-	// - The ReportPortal client library (goRP) communicates exclusively using JSON payloads
-	// - All ReportPortal API endpoints expect and return JSON
-	//
-	// If future test cases require matching non-raw body modes for ReportPortal requests,
-	// this logic must be extended to handle:
-	// - expected.Body.URLEncoded: Compare form-urlencoded key-value pairs
-	// - expected.Body.FormData: Compare multipart form data key-value pairs
-	// - Other modes as needed
-	if expected.Body != nil && expected.Body.Raw != "" {
-		// Normalize JSON for comparison
-		if !m.matchesBody(body, expected.Body.Raw) {
-			return false
+	// NOTE: This implementation matches "raw" body mode (JSON payloads), "graphql",
+	// "urlencoded" and "formdata" (multipart) body modes. Form bodies are compared as
+	// key/value pairs ignoring order; multipart file parts are compared by file name.
+	if expected.Body != nil {
+		switch expected.Body.Mode {
+		case testdata.BodyModeGraphQL:
+			if !m.matchesGraphQLBody(body, expected.Body.GraphQL) {
+				return false
+			}
+		case testdata.BodyModeURLEncoded:
+			if !m.matchesURLEncodedBody(body, expected.Body.URLEncoded) {
+				return false
+			}
+		case testdata.BodyModeFormData:
+			if !m.matchesFormDataBody(r.Header.Get("Content-Type"), body, expected.Body.FormData) {
+				return false
+			}
+		default:
+			// Normalize JSON for comparison
+			if expected.Body.Raw != "" && !m.matchesBody(body, expected.Body.Raw) {
+				return false
+			}
 		}
 	}
 
@@ -376,6 +387,173 @@ func (m *ReportPortalMockServer) matchesBody(actual, expected string) bool {
 	return normalizeAndCompareJSON(actual, expected)
 }
 
+// graphQLRequest is the JSON payload of a GraphQL request sent over HTTP
+type graphQLRequest struct {
+	Query     string          `json:"query"`
+	Variables json.RawMessage `json:"variables,omitempty"`
+}
+
+// matchesGraphQLBody checks if request body is a GraphQL request matching the expected
+// Postman graphql body. Queries are compared after whitespace normalization and variables
+// are compared as JSON.
+func (m *ReportPortalMockServer) matchesGraphQLBody(actual string, expected interface{}) bool {
+	expectedQuery, expectedVariables, ok := parsePostmanGraphQL(expected)
+	if !ok {
+		slog.Debug("Invalid expected GraphQL body", "expected", expected)
+		return false
+	}
+
+	var actualRequest graphQLRequest
+	if err := json.Unmarshal([]byte(actual), &actualRequest); err != nil {
+		slog.Debug("Request body is not a GraphQL request", "error", err)
+		return false
+	}
+
+	if normalizeGraphQLQuery(actualRequest.Query) != normalizeGraphQLQuery(expectedQuery) {
+		slog.Debug( //nolint:gosec // structured log with literal message; values are structured args only
+			"GraphQL query mismatch",
+			"expected",
+			expectedQuery,
+			"actual",
+			actualRequest.Query,
+		)
+		return false
+	}
+
+	actualVariables := normalizeGraphQLVariables(string(actualRequest.Variables))
+	if !normalizeAndCompareJSON(actualVariables, normalizeGraphQLVariables(expectedVariables)) {
+		slog.Debug( //nolint:gosec // structured log with literal message; values are structured args only
+			"GraphQL variables mismatch",
+			"expected",
+			expectedVariables,
+			"actual",
+			string(actualRequest.Variables),
+		)
+		return false
+	}
+
+	return true
+}
+
+// parsePostmanGraphQL extracts the query and the variables (as JSON text) from a Postman
+// graphql body. Postman stores variables as a JSON string, but an inline JSON object is
+// accepted as well.
+func parsePostmanGraphQL(graphQL interface{}) (query, variables string, ok bool) {
+	fields, ok := graphQL.(map[string]interface{})
+	if !ok {
+		return "", "", false
+	}
+	query, ok = fields["query"].(string)
+	if !ok {
+		return "", "", false
+	}
+
+	switch v := fields["variables"].(type) {
+	case nil:
+		return query, "", true
+	case string:
+		return query, v, true
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return "", "", false
+		}
+		return query, string(raw), true
+	}
+}
+
+// matchesURLEncodedBody checks if an application/x-www-form-urlencoded request body holds
+// exactly the expected (enabled) key/value pairs, ignoring order
+func (m *ReportPortalMockServer) matchesURLEncodedBody(
+	actual string,
+	expected []testdata.PostmanKeyValue,
+) bool {
+	values, err := neturl.ParseQuery(actual)
+	if err != nil {
+		slog.Debug("Request body is not URL-encoded", "error", err)
+		return false
+	}
+
+	var actualPairs []string
+	for key, vals := range values {
+		for _, value := range vals {
+			actualPairs = append(actualPairs, formPair(key, value))
+		}
+	}
+
+	return matchesFormPairs(actualPairs, expected)
+}
+
+// matchesFormDataBody checks if a multipart/form-data request body holds exactly the expected
+// (enabled) key/value pairs, ignoring order. File parts are matched by their file name.
+func (m *ReportPortalMockServer) matchesFormDataBody(
+	contentType, actual string,
+	expected []testdata.PostmanKeyValue,
+) bool {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		slog.Debug("Request body is not multipart form data", "contentType", contentType)
+		return false
+	}
+
+	var actualPairs []string
+	reader := multipart.NewReader(strings.NewReader(actual), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			slog.Debug("Failed to read multipart body", "error", err)
+			return false
+		}
+
+		value := part.FileName()
+		if value == "" {
+			content, err := io.ReadAll(part)
+			if err != nil {
+				slog.Debug("Failed to read multipart part", "error", err)
+				return false
+			}
+			value = string(content)
+		}
+		actualPairs = append(actualPairs, formPair(part.FormName(), value))
+	}
+
+	return matchesFormPairs(actualPairs, expected)
+}
+
+// matchesFormPairs compares the actual form pairs with the enabled expected ones, ignoring order
+func matchesFormPairs(actual []string, expected []testdata.PostmanKeyValue) bool {
+	expectedPairs := make([]string, 0, len(expected))
+	for _, kv := range expected {
+		if kv.Disabled {
+			continue
+		}
+		expectedPairs = append(expectedPairs, formPair(kv.Key, kv.Value))
+	}
+
+	slices.Sort(actual)
+	slices.Sort(expectedPairs)
+	if !slices.Equal(actual, expectedPairs) {
+		slog.Debug( //nolint:gosec // structured log with literal message; values are structured args only
+			"Form body mismatch",
+			"expected",
+			expectedPairs,
+			"actual",
+			actual,
+		)
+		return false
+	}
+
+	return true
+}
+
+// formPair encodes a form key/value pair for order-independent comparison
+func formPair(key, value string) string {
+	return key + "\x00" + value
+}
+
 // AddRequestPair adds a new request/response pair dynamically
 func (m *ReportPortalMockServer) AddRequestPair(pair testdata.RequestResponsePair) {
 	m.mutex.Lock()
@@ -0,0 +1,243 @@
+package integration
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/integration/testdata"
+)
+
+func TestReportPortalMockServer_GraphQLBody(t *testing.T) {
+	tc := loadMockFixture(t, "graphql_request_test.json")
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "formatting and variable order differ",
+			body:       `{"query":"query GetLaunch($projectKey: String!, $launchId: Long!) { launch(projectKey: $projectKey, id: $launchId) { id name status } }","variables":{"launchId":9340675,"projectKey":"test-project"},"operationName":"GetLaunch"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "different query",
+			body:       `{"query":"query GetLaunch($projectKey: String!, $launchId: Long!) { launch(projectKey: $projectKey, id: $launchId) { id } }","variables":{"launchId":9340675,"projectKey":"test-project"}}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "different variables",
+			body:       `{"query":"query GetLaunch($projectKey: String!, $launchId: Long!) { launch(projectKey: $projectKey, id: $launchId) { id name status } }","variables":{"launchId":1,"projectKey":"test-project"}}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "not a GraphQL request",
+			body:       `query { launch { id } }`,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpMock := NewReportPortalMockServer(tc.ReportPortalMock.RequestResponsePairs)
+			defer rpMock.Close()
+
+			status, body := postToMock(
+				t,
+				rpMock.URL()+"/api/graphql",
+				"application/json",
+				tt.body,
+			)
+
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantStatus == http.StatusOK {
+				assert.True(t, normalizeAndCompareJSON(
+					body,
+					tc.ReportPortalMock.RequestResponsePairs[0].Response.Body,
+				))
+				assert.Equal(t, 1, rpMock.GetMatchedCount())
+			} else {
+				assert.Zero(t, rpMock.GetMatchedCount())
+			}
+		})
+	}
+}
+
+func TestReportPortalMockServer_URLEncodedBody(t *testing.T) {
+	tc := loadMockFixture(t, "form_request_test.json")
+
+	tests := []struct {
+		name       string
+		form       url.Values
+		wantStatus int
+	}{
+		{
+			name: "pairs in a different order",
+			form: url.Values{
+				"scope":      {"write", "read"},
+				"grant_type": {"password"},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "missing pair",
+			form: url.Values{
+				"scope":      {"read"},
+				"grant_type": {"password"},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "unexpected pair",
+			form: url.Values{
+				"scope":      {"read", "write"},
+				"grant_type": {"password"},
+				"debug":      {"true"},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpMock := NewReportPortalMockServer(tc.ReportPortalMock.RequestResponsePairs)
+			defer rpMock.Close()
+
+			status, body := postToMock(
+				t,
+				rpMock.URL()+"/api/v1/test-project/form",
+				"application/x-www-form-urlencoded",
+				tt.form.Encode(),
+			)
+
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantStatus == http.StatusOK {
+				assert.JSONEq(t, `{"message":"form accepted"}`, body)
+			}
+		})
+	}
+}
+
+func TestReportPortalMockServer_FormDataBody(t *testing.T) {
+	tc := loadMockFixture(t, "form_request_test.json")
+
+	tests := []struct {
+		name        string
+		description string
+		fileName    string
+		wantStatus  int
+	}{
+		{
+			name:        "matching fields and file name",
+			description: "Smoke tests results",
+			fileName:    "results.xml",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "different file name",
+			description: "Smoke tests results",
+			fileName:    "other.xml",
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name:        "different text field",
+			description: "Regression results",
+			fileName:    "results.xml",
+			wantStatus:  http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpMock := NewReportPortalMockServer(tc.ReportPortalMock.RequestResponsePairs)
+			defer rpMock.Close()
+
+			// Write the file part first to check that order is ignored
+			var buf bytes.Buffer
+			writer := multipart.NewWriter(&buf)
+			fileWriter, err := writer.CreateFormFile("file", tt.fileName)
+			require.NoError(t, err)
+			_, err = fileWriter.Write([]byte("<testsuite/>"))
+			require.NoError(t, err)
+			require.NoError(t, writer.WriteField("description", tt.description))
+			require.NoError(t, writer.Close())
+
+			status, body := postToMock(
+				t,
+				rpMock.URL()+"/api/v1/plugin/test-project/junit/import",
+				writer.FormDataContentType(),
+				buf.String(),
+			)
+
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantStatus == http.StatusOK {
+				assert.JSONEq(t, `{"message":"upload accepted"}`, body)
+			}
+		})
+	}
+}
+
+func TestReportPortalMockServer_FormDataBodyRequiresMultipart(t *testing.T) {
+	tc := loadMockFixture(t, "form_request_test.json")
+
+	rpMock := NewReportPortalMockServer(tc.ReportPortalMock.RequestResponsePairs)
+	defer rpMock.Close()
+
+	status, _ := postToMock(
+		t,
+		rpMock.URL()+"/api/v1/plugin/test-project/junit/import",
+		"application/x-www-form-urlencoded",
+		"description=Smoke+tests+results&file=results.xml",
+	)
+
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+// loadMockFixture parses a ReportPortal mock fixture from testdata/mock
+func loadMockFixture(t *testing.T, name string) *testdata.TestCase {
+	t.Helper()
+
+	//nolint:gosec // path comes from controlled test directory
+	raw, err := os.ReadFile(filepath.Join(getTestDataDir(), "mock", name))
+	require.NoError(t, err, "read mock fixture")
+
+	tc, err := testdata.ParseTestCase(raw)
+	require.NoError(t, err, "parse mock fixture")
+
+	return tc
+}
+
+// postToMock sends an authorized POST request to the mock server and returns the response
+// status code and body
+func postToMock(t *testing.T, target, contentType, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(
+		t.Context(),
+		http.MethodPost,
+		target,
+		strings.NewReader(body),
+	)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test-token-1234567")
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(respBody)
+}
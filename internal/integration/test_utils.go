@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"strings"
+	"unicode"
 )
 
 // normalizeAndCompareJSON compares two strings as JSON, normalizing whitespace and field order.
@@ -41,3 +42,21 @@ func normalizeAndCompareJSON(actual, expected string) bool {
 	// Use bytes.Equal for efficient byte slice comparison
 	return bytes.Equal(actualBytes, expectedBytes)
 }
+
+// normalizeGraphQLQuery collapses insignificant whitespace and commas of a GraphQL query,
+// so that queries differing only in formatting compare equal.
+func normalizeGraphQLQuery(query string) string {
+	return strings.Join(strings.FieldsFunc(query, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	}), " ")
+}
+
+// normalizeGraphQLVariables maps absent GraphQL variables ("" or null) to an empty object,
+// so that omitted and empty variables compare equal.
+func normalizeGraphQLVariables(variables string) string {
+	trimmed := strings.TrimSpace(variables)
+	if trimmed == "" || trimmed == "null" {
+		return "{}"
+	}
+	return trimmed
+}
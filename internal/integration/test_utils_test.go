@@ -144,3 +144,60 @@ func TestNormalizeAndCompareJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeGraphQLQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "already normalized",
+			query: `query { launch { id } }`,
+			want:  `query { launch { id } }`,
+		},
+		{
+			name:  "newlines and indentation",
+			query: "query {\n  launch {\n    id\n  }\n}",
+			want:  `query { launch { id } }`,
+		},
+		{
+			name:  "commas are insignificant",
+			query: `query ($a: Int, $b: Int) { launch(a: $a, b: $b) { id, name } }`,
+			want:  `query ($a: Int $b: Int) { launch(a: $a b: $b) { id name } }`,
+		},
+		{
+			name:  "empty",
+			query: "  \n ",
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeGraphQLQuery(tt.query); got != tt.want {
+				t.Errorf("normalizeGraphQLQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeGraphQLVariables(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables string
+		want      string
+	}{
+		{name: "absent", variables: "", want: "{}"},
+		{name: "null", variables: " null ", want: "{}"},
+		{name: "object", variables: ` {"a":1} `, want: `{"a":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeGraphQLVariables(tt.variables); got != tt.want {
+				t.Errorf("normalizeGraphQLVariables() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Package mcpclient holds the client side of the MCP session handshake, shared by the testdata
+// verifier and the HTTP server tests so that both open sessions the same way.
+package mcpclient
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	// DefaultProtocolVersion is the MCP protocol version requested by Initialize
+	DefaultProtocolVersion = "2024-11-05"
+	// SessionIDHeader is the response header carrying the ID of a streamable HTTP session
+	SessionIDHeader = "Mcp-Session-Id"
+)
+
+// Options describes the client performing the handshake. Empty fields are left out of the
+// requests or replaced by defaults.
+type Options struct {
+	Token           string // Sent as "Authorization: Bearer <token>"
+	Project         string // Sent as the X-Project header
+	ClientName      string // clientInfo.name of the initialize request (default "mcp-client")
+	ClientVersion   string // clientInfo.version of the initialize request (default "1.0.0")
+	ProtocolVersion string // Requested protocol version (default DefaultProtocolVersion)
+}
+
+// Initialize performs the MCP handshake against a streamable HTTP endpoint: it sends the
+// initialize request followed by the notifications/initialized notification and returns the
+// session ID assigned by the server. It keeps no state, so every call opens a new session and a
+// failed call can simply be retried. A nil httpClient uses http.DefaultClient.
+func Initialize(
+	ctx context.Context,
+	httpClient *http.Client,
+	serverURL string,
+	opts Options,
+) (string, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	initReq := map[string]any{
+		"jsonrpc": "2.0",
+		"method":  "initialize",
+		"id":      0,
+		"params": map[string]any{
+			"protocolVersion": cmp.Or(opts.ProtocolVersion, DefaultProtocolVersion),
+			"capabilities":    map[string]any{},
+			"clientInfo": map[string]any{
+				"name":    cmp.Or(opts.ClientName, "mcp-client"),
+				"version": cmp.Or(opts.ClientVersion, "1.0.0"),
+			},
+		},
+	}
+	status, header, body, err := post(ctx, httpClient, serverURL, "", opts, initReq)
+	if err != nil {
+		return "", err
+	}
+	if status < 200 || status >= 300 {
+		return "", fmt.Errorf("initialize failed with status %d: %s", status, body)
+	}
+	sessionID := header.Get(SessionIDHeader)
+	if sessionID == "" {
+		return "", fmt.Errorf("no mcp-session-id in response headers, body: %s", body)
+	}
+
+	initialized := map[string]any{
+		"jsonrpc": "2.0",
+		"method":  "notifications/initialized",
+	}
+	status, _, body, err = post(ctx, httpClient, serverURL, sessionID, opts, initialized)
+	if err != nil {
+		return "", err
+	}
+	if status < 200 || status >= 300 {
+		return "", fmt.Errorf("initialized notification failed with status %d: %s", status, body)
+	}
+
+	return sessionID, nil
+}
+
+// post sends a JSON-RPC message and returns the status, headers and body of the response
+func post(
+	ctx context.Context,
+	httpClient *http.Client,
+	serverURL, sessionID string,
+	opts Options,
+	message any,
+) (int, http.Header, []byte, error) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, serverURL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.Token)
+	}
+	if opts.Project != "" {
+		req.Header.Set("X-Project", opts.Project)
+	}
+	if sessionID != "" {
+		req.Header.Set(SessionIDHeader, sessionID)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return resp.StatusCode, resp.Header, body, nil
+}
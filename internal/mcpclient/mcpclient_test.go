@@ -0,0 +1,110 @@
+package mcpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitialize(t *testing.T) {
+	var methods []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/mcp", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "application/json, text/event-stream", r.Header.Get("Accept"))
+		assert.Equal(t, "Bearer secret-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "my_project", r.Header.Get("X-Project"))
+
+		var message struct {
+			Method string `json:"method"`
+			Params struct {
+				ProtocolVersion string            `json:"protocolVersion"`
+				ClientInfo      map[string]string `json:"clientInfo"`
+			} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+		methods = append(methods, message.Method)
+
+		switch message.Method {
+		case "initialize":
+			assert.Empty(t, r.Header.Get(SessionIDHeader))
+			assert.Equal(t, DefaultProtocolVersion, message.Params.ProtocolVersion)
+			assert.Equal(
+				t,
+				map[string]string{"name": "verifier", "version": "1.0.0"},
+				message.Params.ClientInfo,
+			)
+			w.Header().Set(SessionIDHeader, "session-1")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":{}}`))
+		case "notifications/initialized":
+			assert.Equal(t, "session-1", r.Header.Get(SessionIDHeader))
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Errorf("unexpected method %q", message.Method)
+		}
+	}))
+	defer mockServer.Close()
+
+	sessionID, err := Initialize(
+		context.Background(),
+		mockServer.Client(),
+		mockServer.URL+"/mcp",
+		Options{Token: "secret-token", Project: "my_project", ClientName: "verifier"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "session-1", sessionID)
+	assert.Equal(t, []string{"initialize", "notifications/initialized"}, methods)
+}
+
+func TestInitialize_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr string
+	}{
+		{
+			name: "initialize rejected",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "bad request", http.StatusBadRequest)
+			},
+			wantErr: "initialize failed with status 400: bad request",
+		},
+		{
+			name: "no session ID",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":{}}`))
+			},
+			wantErr: "no mcp-session-id in response headers",
+		},
+		{
+			name: "initialized notification rejected",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get(SessionIDHeader) != "" {
+					http.Error(w, "session not found", http.StatusNotFound)
+					return
+				}
+				w.Header().Set(SessionIDHeader, "session-1")
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":{}}`))
+			},
+			wantErr: "initialized notification failed with status 404",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockServer := httptest.NewServer(tt.handler)
+			defer mockServer.Close()
+
+			sessionID, err := Initialize(context.Background(), nil, mockServer.URL, Options{})
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.Empty(t, sessionID)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package promptreader
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"text/template"
@@ -11,6 +12,45 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// DefaultMaxOutputBytes is the built-in cap of the messages rendered by one prompt request
+// (see WithMaxOutputBytes)
+const DefaultMaxOutputBytes = 1 << 20
+
+// readerOptions holds the settings of the prompts built by LoadPromptsFromYAML
+type readerOptions struct {
+	// maxOutputBytes caps the total size of the messages rendered by one prompt request so
+	// that oversized arguments cannot blow up memory or the client's token budget
+	maxOutputBytes int
+}
+
+// Option configures the prompts built by ReadPrompts and LoadPromptsFromYAML
+type Option func(*readerOptions)
+
+// WithMaxOutputBytes caps the total size of the messages rendered by one prompt request.
+// Loading fails for a cap below 1; without this option DefaultMaxOutputBytes applies.
+func WithMaxOutputBytes(n int) Option {
+	return func(o *readerOptions) {
+		o.maxOutputBytes = n
+	}
+}
+
+// errOutputTooLarge is returned by limitedBuffer once the output cap is exceeded
+var errOutputTooLarge = errors.New("rendered prompt output too large")
+
+// limitedBuffer is a bytes.Buffer that refuses writes beyond limit bytes, so that rendering
+// stops as soon as the output gets too large instead of after it has been fully built
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errOutputTooLarge
+	}
+	return b.Buffer.Write(p)
+}
+
 type PromptHandlerPair struct {
 	Prompt  *mcp.Prompt
 	Handler mcp.PromptHandler
@@ -18,19 +58,34 @@ type PromptHandlerPair struct {
 
 // ReadPrompts reads prompt definitions from a YAML file and converts them
 // to pairs of mcp.Prompt and mcp.PromptHandler. It delegates to LoadPromptsFromYAML.
-func ReadPrompts(data []byte) ([]PromptHandlerPair, error) {
-	return LoadPromptsFromYAML(data)
+func ReadPrompts(data []byte, opts ...Option) ([]PromptHandlerPair, error) {
+	return LoadPromptsFromYAML(data, opts...)
 }
 
 // LoadPromptsFromYAML reads prompt definitions from a YAML file and converts them
 // to pairs of mcp.Prompt and mcp.PromptHandler.
-func LoadPromptsFromYAML(data []byte) ([]PromptHandlerPair, error) {
+func LoadPromptsFromYAML(data []byte, opts ...Option) ([]PromptHandlerPair, error) {
+	options := readerOptions{maxOutputBytes: DefaultMaxOutputBytes}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.maxOutputBytes < 1 {
+		return nil, fmt.Errorf(
+			"invalid max prompt output bytes %d: must be at least 1",
+			options.maxOutputBytes,
+		)
+	}
+
 	// Parse YAML
 	var promptDefs struct {
 		Prompts []struct {
 			Name        string `yaml:"name"`
 			Description string `yaml:"description"`
-			Arguments   []struct {
+			// LeftDelim and RightDelim override the template action delimiters of the
+			// messages; empty values keep the default "{{" and "}}"
+			LeftDelim  string `yaml:"left_delim"`
+			RightDelim string `yaml:"right_delim"`
+			Arguments  []struct {
 				Name        string `yaml:"name"`
 				Description string `yaml:"description"`
 				Required    bool   `yaml:"required"`
@@ -58,7 +113,8 @@ func LoadPromptsFromYAML(data []byte) ([]PromptHandlerPair, error) {
 			return nil, fmt.Errorf("prompt %s has no messages", def.Name)
 		}
 
-		tmpls := template.New("").Option("missingkey=error")
+		// Templates created with tmpls.New inherit the delimiters
+		tmpls := template.New("").Delims(def.LeftDelim, def.RightDelim).Option("missingkey=error")
 		var err error
 		for idx, msgDef := range def.Messages {
 			if msgDef.Content.Type != "text" {
@@ -110,6 +166,8 @@ func LoadPromptsFromYAML(data []byte) ([]PromptHandlerPair, error) {
 				return nil, fmt.Errorf("prompt %s not found", req.Params.Name)
 			}
 			messages := make([]*mcp.PromptMessage, 0, len(defCopy.Messages))
+			limit := options.maxOutputBytes
+			remaining := limit
 
 			for msgIdx, msg := range defCopy.Messages {
 				tmpl := tmplsCopy.Lookup(strconv.Itoa(msgIdx))
@@ -120,10 +178,18 @@ func LoadPromptsFromYAML(data []byte) ([]PromptHandlerPair, error) {
 						defCopy.Name,
 					)
 				}
-				var buf bytes.Buffer
-				if err := tmpl.Execute(&buf, req.Params.Arguments); err != nil {
+				buf := &limitedBuffer{limit: remaining}
+				if err := tmpl.Execute(buf, req.Params.Arguments); err != nil {
+					if errors.Is(err, errOutputTooLarge) {
+						return nil, fmt.Errorf(
+							"prompt %s output exceeds the maximum of %d bytes",
+							defCopy.Name,
+							limit,
+						)
+					}
 					return nil, fmt.Errorf("error executing template: %w", err)
 				}
+				remaining -= buf.Len()
 
 				if msg.Role != "user" && msg.Role != "assistant" {
 					return nil, fmt.Errorf(
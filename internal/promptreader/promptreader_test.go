@@ -3,6 +3,7 @@ package promptreader_test
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -304,3 +305,88 @@ prompts:
 	assert.Equal(t, "assistant", string(promptResult.Messages[1].Role))
 	assert.Equal(t, "user", string(promptResult.Messages[2].Role))
 }
+
+func TestCustomTemplateDelimiters(t *testing.T) {
+	yamlContent := []byte(`
+prompts:
+  - name: custom_delims
+    description: "Prompt with literal braces"
+    left_delim: "[["
+    right_delim: "]]"
+    arguments:
+      - name: launch_id
+        description: "Launch ID"
+        required: true
+    messages:
+      - role: user
+        content:
+          type: text
+          text: "Analyze launch [[.launch_id]] and keep {{.placeholder}} and }} literal"
+`)
+
+	prompts, err := promptreader.LoadPromptsFromYAML(yamlContent)
+	require.NoError(t, err)
+	require.Len(t, prompts, 1)
+
+	promptResult, err := prompts[0].Handler(context.Background(), &mcp.GetPromptRequest{
+		Params: &mcp.GetPromptParams{
+			Name:      "custom_delims",
+			Arguments: map[string]string{"launch_id": "42"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, promptResult.Messages, 1)
+	textContent, ok := promptResult.Messages[0].Content.(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+	assert.Equal(t, "Analyze launch 42 and keep {{.placeholder}} and }} literal", textContent.Text)
+}
+
+func TestPromptOutputSizeLimit(t *testing.T) {
+	yamlContent := []byte(`
+prompts:
+  - name: echo
+    description: "Echoes its argument twice"
+    arguments:
+      - name: text
+        description: "Text to echo"
+        required: true
+    messages:
+      - role: user
+        content:
+          type: text
+          text: "First: {{.text}}"
+      - role: assistant
+        content:
+          type: text
+          text: "Second: {{.text}}"
+`)
+	prompts, err := promptreader.LoadPromptsFromYAML(
+		yamlContent,
+		promptreader.WithMaxOutputBytes(64),
+	)
+	require.NoError(t, err)
+	require.Len(t, prompts, 1)
+
+	getPrompt := func(text string) (*mcp.GetPromptResult, error) {
+		return prompts[0].Handler(context.Background(), &mcp.GetPromptRequest{
+			Params: &mcp.GetPromptParams{
+				Name:      "echo",
+				Arguments: map[string]string{"text": text},
+			},
+		})
+	}
+
+	result, err := getPrompt("short")
+	require.NoError(t, err)
+	assert.Len(t, result.Messages, 2)
+
+	// Each message fits on its own, but together they exceed the cap
+	_, err = getPrompt(strings.Repeat("x", 40))
+	require.ErrorContains(t, err, "exceeds the maximum of 64 bytes")
+
+	_, err = getPrompt(strings.Repeat("x", 1000))
+	require.ErrorContains(t, err, "exceeds the maximum of 64 bytes")
+
+	_, err = promptreader.LoadPromptsFromYAML(yamlContent, promptreader.WithMaxOutputBytes(0))
+	require.ErrorContains(t, err, "invalid max prompt output bytes 0")
+}
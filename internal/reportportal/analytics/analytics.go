@@ -3,16 +3,16 @@ package analytics
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
 	"crypto/tls"
 	"encoding/base64"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -22,7 +22,7 @@ import (
 )
 
 const (
-	// Google Analytics 4 Measurement Protocol endpoint
+	// Default Google Analytics 4 Measurement Protocol endpoint (see WithGA4Endpoint)
 	ga4EndpointURL = "https://www.google-analytics.com/mp/collect"
 
 	// Configuration
@@ -32,9 +32,12 @@ const (
 
 	HashAlgorithm = "SHA256-128bit"
 
-	// Batch send interval for analytics data
+	// Default batch send interval for analytics data (see WithFlushInterval)
 	BatchSendInterval = 10 * time.Second
 
+	// Shortest accepted batch send interval; shorter intervals are raised to it
+	MinBatchSendInterval = 1 * time.Second
+
 	maxPerRequest = 25
 
 	// Timeout for fetching instance ID from ReportPortal
@@ -46,15 +49,7 @@ const (
 
 // HashToken creates a secure hash of the token
 func HashToken(token string) string {
-	if token == "" {
-		return ""
-	}
-
-	// Create full SHA256 hash of the token
-	hash := sha256.Sum256([]byte(token))
-
-	// Return full hash
-	return hex.EncodeToString(hash[:])
+	return utils.HashToken(token)
 }
 
 // truncateForLog safely truncates a string for logging purposes
@@ -102,9 +97,10 @@ type GAPayload struct {
 
 // Analytics handles Google Analytics tracking with batched metrics
 type Analytics struct {
-	Config     *AnalyticsConfig
-	httpClient *http.Client // GA4 (Measurement Protocol); always uses default certificate verification
-	rpClient   *http.Client // ReportPortal /api/info only; uses tlsCfg when non-nil
+	Config      *AnalyticsConfig
+	httpClient  *http.Client // GA4 (Measurement Protocol); always uses default certificate verification
+	ga4Endpoint string       // GA4 Measurement Protocol endpoint (ga4EndpointURL unless overridden)
+	rpClient    *http.Client // ReportPortal /api/info only; uses tlsCfg when non-nil
 
 	// ReportPortal instance ID (fetched lazily on first use, retried until successful)
 	instanceID        string      // ReportPortal instance ID from /api/info endpoint
@@ -117,12 +113,18 @@ type Analytics struct {
 	metrics     map[string]map[string]*int64 // userID -> (tool name -> counter)
 	metricsLock sync.RWMutex                 // protects metrics map
 
+	// Local metrics file (optional): cumulative counters written on each flush
+	metricsFile     string
+	fileTotals      map[string]map[string]int64 // userID -> (tool name -> total count)
+	metricsFileLock sync.Mutex                  // protects fileTotals and file writes
+
 	// Background processing
-	ctx      context.Context    // cancelled on Stop() to interrupt in-flight HTTP requests
-	cancel   context.CancelFunc // cancels ctx
-	stopChan chan struct{}
-	wg       sync.WaitGroup
-	stopOnce sync.Once // ensures Stop() is only executed once
+	flushInterval time.Duration      // how often batched metrics are flushed
+	ctx           context.Context    // cancelled on Stop() to interrupt in-flight HTTP requests
+	cancel        context.CancelFunc // cancels ctx
+	stopChan      chan struct{}
+	wg            sync.WaitGroup
+	stopOnce      sync.Once // ensures Stop() is only executed once
 }
 
 // ensureInstanceID lazily fetches the instance ID if not already set.
@@ -243,33 +245,89 @@ func fetchInstanceID(ctx context.Context, hostURL string, httpClient *http.Clien
 	return instanceID
 }
 
+// Option configures optional Analytics behaviour
+type Option func(*options)
+
+type options struct {
+	metricsFile   string
+	ga4Endpoint   string
+	flushInterval time.Duration
+}
+
+// WithMetricsFile makes the metrics processor write cumulative per-tool counters to a local
+// JSON file on each flush. With a metrics file configured, analytics also runs without a
+// GA4 API secret, in which case nothing is sent to GA4. An empty path is ignored.
+func WithMetricsFile(path string) Option {
+	return func(o *options) {
+		o.metricsFile = path
+	}
+}
+
+// WithGA4Endpoint overrides the GA4 Measurement Protocol endpoint, e.g. to route events through
+// a proxy or a self-hosted collector. An empty endpoint keeps the default.
+func WithGA4Endpoint(endpoint string) Option {
+	return func(o *options) {
+		o.ga4Endpoint = endpoint
+	}
+}
+
+// WithFlushInterval sets how often batched metrics are sent to GA4 and written to the metrics
+// file. Zero keeps BatchSendInterval; intervals below MinBatchSendInterval are raised to it.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.flushInterval = interval
+	}
+}
+
 // NewAnalytics creates a new Analytics instance
 // Parameters:
 //   - userID: Custom user identifier (if empty, a generic ID will be generated)
-//   - apiSecret: Google Analytics 4 API secret for authentication (required)
+//   - apiSecret: Google Analytics 4 API secret for authentication (required unless a metrics file is set)
 //   - rpAPIToken: ReportPortal API token for secure hashing (optional, used when available)
 //   - rpHostURL: ReportPortal host URL for fetching instance ID (optional)
 //   - tlsCfg: Optional TLS configuration for ReportPortal /api/info only (nil = system defaults).
 //     GA4 requests always use default certificate verification and never use this config.
+//   - opts: Optional settings such as WithMetricsFile, WithGA4Endpoint and WithFlushInterval
 //
-// Returns error if apiSecret is empty
+// Returns error if apiSecret is empty and no metrics file is configured
 func NewAnalytics(
 	userID string,
 	apiSecret string,
 	rpAPIToken string,
 	rpHostURL string,
 	tlsCfg *tls.Config,
+	opts ...Option,
 ) (*Analytics, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.ga4Endpoint == "" {
+		o.ga4Endpoint = ga4EndpointURL
+	}
+	switch {
+	case o.flushInterval == 0:
+		o.flushInterval = BatchSendInterval
+	case o.flushInterval < MinBatchSendInterval:
+		slog.Warn("Analytics flush interval is too short, using the minimum",
+			"requested", o.flushInterval,
+			"minimum", MinBatchSendInterval,
+		)
+		o.flushInterval = MinBatchSendInterval
+	}
+
 	// Analytics enablement is now controlled by the caller (CLI flags)
 	slog.Debug("Initializing analytics",
 		"has_ga4_secret", apiSecret != "",
 		"user_id", userID,
 		"has_rp_token", rpAPIToken != "",
 		"measurement_id", measurementID,
+		"metrics_file", o.metricsFile,
+		"flush_interval", o.flushInterval,
 	)
 
-	// If GA4 API secret is empty, disable analytics
-	if apiSecret == "" {
+	// If GA4 API secret is empty and there is nowhere else to report to, disable analytics
+	if apiSecret == "" && o.metricsFile == "" {
 		return nil, fmt.Errorf("analytics disabled: missing GA4 API secret")
 	}
 
@@ -324,15 +382,19 @@ func NewAnalytics(
 	)
 
 	analytics := &Analytics{
-		Config:     config,
-		httpClient: httpClient,
-		rpClient:   rpClient,
-		rpHostURL:  rpHostURL,                          // Store for lazy fetching
-		instanceID: "",                                 // Will be fetched lazily on first use
-		metrics:    make(map[string]map[string]*int64), // userID -> toolName -> counter
-		ctx:        ctx,
-		cancel:     cancel,
-		stopChan:   make(chan struct{}),
+		Config:        config,
+		httpClient:    httpClient,
+		rpClient:      rpClient,
+		rpHostURL:     rpHostURL,                          // Store for lazy fetching
+		instanceID:    "",                                 // Will be fetched lazily on first use
+		metrics:       make(map[string]map[string]*int64), // userID -> toolName -> counter
+		ga4Endpoint:   o.ga4Endpoint,
+		metricsFile:   o.metricsFile,
+		fileTotals:    make(map[string]map[string]int64),
+		flushInterval: o.flushInterval,
+		ctx:           ctx,
+		cancel:        cancel,
+		stopChan:      make(chan struct{}),
 	}
 
 	analytics.startMetricsProcessor()
@@ -349,6 +411,12 @@ func (a *Analytics) TrackMCPEvent(ctx context.Context, toolName string) {
 		return
 	}
 
+	// The client opted out of analytics for this request (X-Analytics-Opt-Out header)
+	if utils.IsAnalyticsOptOut(ctx) {
+		slog.Debug("Analytics opted out by client", "tool", toolName)
+		return
+	}
+
 	// Extract token from context and determine user ID
 	userID := a.getUserIDFromContext(ctx)
 
@@ -423,8 +491,12 @@ func (a *Analytics) sendPayload(ctx context.Context, payload GAPayload) error {
 		slog.Debug("Batch request payload:", "json", string(jsonData))
 	}
 
+	endpoint := a.ga4Endpoint
+	if endpoint == "" {
+		endpoint = ga4EndpointURL
+	}
 	url := fmt.Sprintf("%s?measurement_id=%s&api_secret=%s",
-		ga4EndpointURL, a.Config.MeasurementID, a.Config.APISecret)
+		endpoint, a.Config.MeasurementID, a.Config.APISecret)
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
@@ -503,15 +575,24 @@ func GetAnalyticArg() string {
 	return finalResult
 }
 
+// FlushInterval returns how often batched metrics are flushed. Instances not created by
+// NewAnalytics report BatchSendInterval.
+func (a *Analytics) FlushInterval() time.Duration {
+	if a.flushInterval <= 0 {
+		return BatchSendInterval
+	}
+	return a.flushInterval
+}
+
 // startMetricsProcessor starts the background goroutine that sends analytics batches at regular intervals
 func (a *Analytics) startMetricsProcessor() {
 	a.wg.Add(1)
 	go func() {
 		defer a.wg.Done()
-		ticker := time.NewTicker(BatchSendInterval)
+		ticker := time.NewTicker(a.flushInterval)
 		defer ticker.Stop()
 
-		slog.Debug("Analytics metrics processor started", "interval", BatchSendInterval)
+		slog.Debug("Analytics metrics processor started", "interval", a.flushInterval)
 
 		for {
 			select {
@@ -599,7 +680,8 @@ func (a *Analytics) incrementMetric(userID, toolName string) {
 	atomic.AddInt64(counter, 1)
 }
 
-// processMetrics collects and sends all non-zero metrics to GA4
+// processMetrics collects all non-zero metrics, writes them to the local metrics file
+// (if configured) and sends them to GA4 (if an API secret is configured)
 func (a *Analytics) processMetrics() {
 	if a == nil {
 		return
@@ -646,11 +728,78 @@ func (a *Analytics) processMetrics() {
 		"total_events", totalEvents,
 	)
 
+	if a.metricsFile != "" {
+		a.writeMetricsFile(metricsToSend)
+	}
+
+	// Local-only mode: GA4 reporting is disabled
+	if a.Config.APISecret == "" {
+		return
+	}
+
 	// Send metrics as a batch to GA4 per user, using the analytics context so
 	// in-flight requests are cancelled promptly when Stop() is called.
 	a.sendBatchMetricsPerUser(a.ctx, metricsToSend)
 }
 
+// metricsFileSnapshot is the JSON document written to the local metrics file
+type metricsFileSnapshot struct {
+	UpdatedAt time.Time                   `json:"updated_at"`
+	Tools     map[string]int64            `json:"tools"` // tool name -> total count
+	Users     map[string]map[string]int64 `json:"users"` // hashed user ID -> tool name -> total count
+}
+
+// writeMetricsFile adds the flushed counters to the running totals and rewrites the metrics file.
+// The file is replaced atomically so readers never observe a partially written document.
+func (a *Analytics) writeMetricsFile(metricsPerUser map[string]map[string]int64) {
+	a.metricsFileLock.Lock()
+	defer a.metricsFileLock.Unlock()
+
+	snapshot := metricsFileSnapshot{
+		UpdatedAt: time.Now().UTC(),
+		Tools:     make(map[string]int64),
+		Users:     a.fileTotals,
+	}
+	for userID, metrics := range metricsPerUser {
+		if a.fileTotals[userID] == nil {
+			a.fileTotals[userID] = make(map[string]int64)
+		}
+		for toolName, count := range metrics {
+			a.fileTotals[userID][toolName] += count
+		}
+	}
+	for _, metrics := range a.fileTotals {
+		for toolName, count := range metrics {
+			snapshot.Tools[toolName] += count
+		}
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		slog.Warn("Failed to marshal local metrics", "error", err)
+		return
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(a.metricsFile), filepath.Base(a.metricsFile)+".*.tmp")
+	if err != nil {
+		slog.Warn("Failed to create local metrics file", "path", a.metricsFile, "error", err)
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		slog.Warn("Failed to write local metrics file", "path", a.metricsFile, "error", err)
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), a.metricsFile); err != nil {
+		slog.Warn("Failed to replace local metrics file", "path", a.metricsFile, "error", err)
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	slog.Debug("Local metrics file updated", "path", a.metricsFile)
+}
+
 // sendBatchMetricsPerUser sends multiple tool metrics per user as batch events to GA4
 func (a *Analytics) sendBatchMetricsPerUser(
 	ctx context.Context,
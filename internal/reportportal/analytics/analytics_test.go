@@ -8,6 +8,8 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -174,6 +176,99 @@ func TestNewAnalytics(t *testing.T) {
 	}
 }
 
+func TestMetricsFile_WritesCountsWithoutGA4(t *testing.T) {
+	metricsFile := filepath.Join(t.TempDir(), "metrics.json")
+
+	// No GA4 secret: the metrics file alone enables analytics
+	a, err := NewAnalytics("test-user", "", "", "", nil, WithMetricsFile(metricsFile))
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	defer a.Stop()
+
+	readSnapshot := func() metricsFileSnapshot {
+		t.Helper()
+		data, err := os.ReadFile(metricsFile)
+		require.NoError(t, err)
+		var snapshot metricsFileSnapshot
+		require.NoError(t, json.Unmarshal(data, &snapshot))
+		return snapshot
+	}
+
+	a.incrementMetric(a.Config.UserID, "get_launches")
+	a.incrementMetric(a.Config.UserID, "get_launches")
+	a.incrementMetric("other-user", "get_test_item_by_id")
+	a.processMetrics()
+
+	snapshot := readSnapshot()
+	assert.Equal(t, map[string]int64{"get_launches": 2, "get_test_item_by_id": 1}, snapshot.Tools)
+	assert.Equal(t, int64(2), snapshot.Users[a.Config.UserID]["get_launches"])
+	assert.Equal(t, int64(1), snapshot.Users["other-user"]["get_test_item_by_id"])
+	assert.False(t, snapshot.UpdatedAt.IsZero())
+
+	// Counters accumulate across flushes
+	a.incrementMetric(a.Config.UserID, "get_launches")
+	a.processMetrics()
+
+	snapshot = readSnapshot()
+	assert.Equal(t, map[string]int64{"get_launches": 3, "get_test_item_by_id": 1}, snapshot.Tools)
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(filepath.Dir(metricsFile))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestNewAnalytics_FlushInterval(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested time.Duration
+		expected  time.Duration
+	}{
+		{name: "default", requested: 0, expected: BatchSendInterval},
+		{name: "custom", requested: time.Minute, expected: time.Minute},
+		{name: "below minimum", requested: 10 * time.Millisecond, expected: MinBatchSendInterval},
+		{name: "negative", requested: -time.Second, expected: MinBatchSendInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAnalytics("test-user", "test-secret", "", "", nil,
+				WithFlushInterval(tt.requested))
+			require.NoError(t, err)
+			defer a.Stop()
+
+			assert.Equal(t, tt.expected, a.FlushInterval())
+		})
+	}
+}
+
+func TestMetricsFile_FlushedOnInterval(t *testing.T) {
+	metricsFile := filepath.Join(t.TempDir(), "metrics.json")
+
+	a, err := NewAnalytics("test-user", "", "", "", nil,
+		WithMetricsFile(metricsFile),
+		WithFlushInterval(MinBatchSendInterval),
+	)
+	require.NoError(t, err)
+	defer a.Stop()
+
+	start := time.Now()
+	a.incrementMetric(a.Config.UserID, "get_launches")
+
+	// The background processor flushes on its own, long before the default interval
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(metricsFile)
+		return err == nil
+	}, BatchSendInterval/2, 50*time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(start), MinBatchSendInterval/2)
+
+	data, err := os.ReadFile(metricsFile)
+	require.NoError(t, err)
+	var snapshot metricsFileSnapshot
+	require.NoError(t, json.Unmarshal(data, &snapshot))
+	assert.Equal(t, map[string]int64{"get_launches": 1}, snapshot.Tools)
+}
+
 func TestNewAnalytics_AppliesTLSConfig(t *testing.T) {
 	tlsCfg := &tls.Config{
 		InsecureSkipVerify: true, //nolint:gosec // intentional for test assertion only
@@ -1495,6 +1590,43 @@ func TestHTTPTokenMiddlewareIntegrationWithAnalytics(t *testing.T) {
 			"Should NOT track metrics for Bearer token when custom user ID is set",
 		)
 	})
+
+	// Test 3: X-Analytics-Opt-Out header - nothing is recorded for the request
+	t.Run("opt-out header skips tracking", func(t *testing.T) {
+		analytics, err := NewAnalytics("", "test-secret", "", "", nil)
+		require.NoError(t, err)
+		require.NotNil(t, analytics)
+		defer analytics.Stop()
+
+		testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			analytics.TrackMCPEvent(r.Context(), "test_tool")
+			w.WriteHeader(http.StatusOK)
+		})
+		httpMiddleware := middleware.HTTPTokenMiddleware(testHandler)
+
+		req := httptest.NewRequest("POST", "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+testToken1)
+		req.Header.Set(middleware.AnalyticsOptOutHeader, "true")
+
+		rr := httptest.NewRecorder()
+		httpMiddleware.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		analytics.metricsLock.RLock()
+		assert.Empty(t, analytics.metrics, "No metric should be recorded for opted-out requests")
+		analytics.metricsLock.RUnlock()
+
+		// A non-true header value does not opt out
+		req = httptest.NewRequest("POST", "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+testToken1)
+		req.Header.Set(middleware.AnalyticsOptOutHeader, "no")
+
+		httpMiddleware.ServeHTTP(httptest.NewRecorder(), req)
+
+		analytics.metricsLock.RLock()
+		assert.Contains(t, analytics.metrics[HashToken(testToken1)], "test_tool")
+		analytics.metricsLock.RUnlock()
+	})
 }
 
 func TestAnalyticsInstanceIDFetching(t *testing.T) {
@@ -1584,13 +1716,19 @@ func TestAnalyticsInstanceIDFetching(t *testing.T) {
 		)
 		defer gaServer.Close()
 
-		// Create analytics with mock RP server
-		analytics, err := NewAnalytics("test-user", "test-secret", "", mockServer.URL, nil)
+		// Create analytics with mock RP server, sending GA events to the mock GA server
+		analytics, err := NewAnalytics(
+			"test-user",
+			"test-secret",
+			"",
+			mockServer.URL,
+			nil,
+			WithGA4Endpoint(gaServer.URL),
+		)
 		require.NoError(t, err)
 		require.NotNil(t, analytics)
 		defer analytics.Stop()
 
-		// Override GA endpoint for testing (we need to modify the config)
 		// Track an event
 		ctx := context.Background()
 		analytics.TrackMCPEvent(ctx, "test_tool")
@@ -1601,11 +1739,14 @@ func TestAnalyticsInstanceIDFetching(t *testing.T) {
 		// Process metrics manually
 		analytics.processMetrics()
 
-		// Verify events were created with instanceID
-		// Note: In real scenario, events would be sent to GA server
-		// For this test, we verify the instanceID is stored in analytics
+		// Verify events were sent to the GA server with instanceID
 		assert.True(t, analytics.instanceIDFetched.Load(), "Should be fetched after processing")
 		assert.Equal(t, mockInstanceID, analytics.instanceID)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.NotEmpty(t, capturedEvents, "GA server should receive the batched events")
+		assert.Equal(t, mockInstanceID, capturedEvents[0].Params["instanceID"])
 	})
 
 	t.Run("instance ID retries on failure until successful", func(t *testing.T) {
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"runtime"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -29,24 +30,54 @@ import (
 // createHTTPClient creates a reusable HTTP client for the HTTP server path.
 // Unlike buildHTTPClient in server.go (which targets single-user stdio mode),
 // this function tunes the connection pool for concurrent multi-user traffic:
-// MaxIdleConns=100, MaxIdleConnsPerHost=10, IdleConnTimeout=90s, HTTP/2 forced.
+// MaxIdleConns and MaxIdleConnsPerHost from --max-idle-conns and --max-idle-conns-per-host
+// (100 and 10 by default), IdleConnTimeout=90s, HTTP/2 forced.
 // The timeout parameter is the per-request deadline and comes from --connection-timeout.
 // tlsCfg may be nil, in which case the Go default TLS behaviour is used.
-func createHTTPClient(timeout time.Duration, tlsCfg *tls.Config) *http.Client {
+func createHTTPClient(
+	timeout time.Duration,
+	tlsCfg *tls.Config,
+	userAgent string,
+	maxIdleConns, maxIdleConnsPerHost int,
+) *http.Client {
 	transport := utils.NewBaseTransport()
-	transport.MaxIdleConns = 100
-	transport.MaxIdleConnsPerHost = 10
+	transport.MaxIdleConns = maxIdleConns
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
 	transport.IdleConnTimeout = 90 * time.Second
 	transport.DisableCompression = false
 	transport.ForceAttemptHTTP2 = true // HTTP/2 always enabled for optimal performance
 	transport.TLSClientConfig = tlsCfg
 
 	return &http.Client{
-		Transport: transport,
+		Transport: utils.WithUserAgent(transport, userAgent),
 		Timeout:   timeout,
 	}
 }
 
+// defaultUserAgent returns the User-Agent used when HTTPServerConfig.UserAgent is not set
+func defaultUserAgent() string {
+	return utils.BuildUserAgent(config.Version, "")
+}
+
+// processStartTime is when the server process started; /health and /info report the uptime since then
+var processStartTime = time.Now()
+
+// defaultShutdownTimeout is the drain period used when HTTPServerConfig.ShutdownTimeout is not set
+const defaultShutdownTimeout = 5 * time.Second
+
+// Connection pool sizes of the outbound ReportPortal client used when HTTPServerConfig leaves
+// them unset
+const (
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 10
+)
+
+// MCP transports the HTTP server can serve (HTTPServerConfig.Transport)
+const (
+	TransportStreamable = "streamable" // Streamable HTTP on /mcp and /api/mcp (default)
+	TransportSSE        = "sse"        // Legacy HTTP+SSE transport (MCP 2024-11-05) on /sse
+)
+
 // HTTPServerConfig holds configuration for the HTTP-enabled MCP server
 type HTTPServerConfig struct {
 	Version         string
@@ -55,11 +86,39 @@ type HTTPServerConfig struct {
 	UserID          string
 	GA4Secret       string
 	AnalyticsOn     bool
+	MetricsFile     string        // Local JSON file for usage metrics (works with GA4 disabled)
+	GA4Endpoint     string        // GA4 Measurement Protocol endpoint override (empty = default)
+	FlushInterval   time.Duration // Time between analytics flushes (0 = analytics default)
+	ReadOnly        bool          // Hide tools that change data in ReportPortal
+	RequireConfirm  bool          // Destructive tools require an explicit confirm: true argument
+	PrettyJSON      bool          // Indent JSON tool results (costs more tokens)
+
+	// Prompt settings
+	MaxPromptOutputBytes int // Cap of one rendered prompt (0 = promptreader.DefaultMaxOutputBytes)
+
+	// Defaults of the launch and test item tools (zero value = built-in defaults)
+	ToolSettings mcphandlers.ToolSettings
+
+	// Tool result cache settings
+	CacheSize int           // Read tool result cache capacity (0 = caching disabled)
+	CacheTTL  time.Duration // Lifetime of a cached tool result
 
 	// HTTP settings
 	MaxConcurrentRequests int           // Chi Throttle limit
+	PerTokenConcurrency   int           // In-flight requests allowed per API token (0 = no limit)
 	ConnectionTimeout     time.Duration // Request timeout
+	MaxIdleConns          int           // Idle connections kept to ReportPortal in total
+	MaxIdleConnsPerHost   int           // Idle connections kept per ReportPortal host
+	ShutdownTimeout       time.Duration // Drain period for in-flight requests on shutdown
+	SessionIdleTimeout    time.Duration // Idle streamable sessions are closed after it (0 = never); not applied to SSE
+	MaxRequestBytes       int64         // Request body size limit; larger bodies get 413
+	ValidateToken         bool          // Validate bearer tokens against RP before dispatching
+	ValidateTokenTTL      time.Duration // Cache period for successfully validated tokens
+	AccessLogLevel        slog.Level    // Level of the per-request access log entries
 	TLSConfig             *tls.Config   // Optional TLS config (nil = system defaults)
+	UserAgent             string        // User-Agent for outbound RP requests (empty = default)
+	Transport             string        // MCP transport: TransportStreamable (default) or TransportSSE
+	TokenHeader           string        // Header carrying the RP API token (default Authorization)
 	// HTTP/2 is always enabled for optimal performance
 }
 
@@ -67,13 +126,19 @@ type HTTPServerConfig struct {
 type HTTPServer struct {
 	mcpServer         *mcp.Server
 	AnalyticsInstance *analytics.Analytics
+	analyticsErr      error // Why requested analytics failed to initialize (nil otherwise)
 	config            HTTPServerConfig
 	Router            chi.Router   // Made public for CreateHTTPServerWithMiddleware
-	mcpHTTPHandler    http.Handler // Official SDK HTTP handler
+	mcpHTTPHandler    http.Handler // Official SDK HTTP handler for the configured transport
 	httpClient        *http.Client // Direct HTTP client instead of ConnectionManager
 
+	toolCache *mcphandlers.ToolResultCache // Read tool result cache (nil = disabled)
+
 	// State management
-	running atomic.Bool
+	running  atomic.Bool
+	activeMu sync.Mutex
+	active   int           // In-flight non-SSE requests, drained on Stop
+	idle     chan struct{} // Closed when active drops to zero while a drain is waiting
 }
 
 // MCPRequestPayload represents the basic JSON-RPC structure of MCP requests
@@ -98,6 +163,37 @@ func NewHTTPServer(
 	if config.ConnectionTimeout <= 0 {
 		config.ConnectionTimeout = 30 * time.Second
 	}
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = defaultShutdownTimeout
+	}
+	if config.MaxIdleConns <= 0 {
+		config.MaxIdleConns = defaultMaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost <= 0 {
+		config.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	}
+	if config.MaxRequestBytes <= 0 {
+		config.MaxRequestBytes = app_middleware.DefaultMaxRequestBytes
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = defaultUserAgent()
+	}
+	config.TokenHeader = strings.TrimSpace(config.TokenHeader)
+	if config.TokenHeader == "" {
+		config.TokenHeader = app_middleware.DefaultTokenHeader
+	}
+	switch config.Transport {
+	case "":
+		config.Transport = TransportStreamable
+	case TransportStreamable, TransportSSE:
+	default:
+		return nil, fmt.Errorf(
+			"unknown MCP transport %q: must be %s or %s",
+			config.Transport,
+			TransportStreamable,
+			TransportSSE,
+		)
+	}
 
 	// Create base MCP server
 	mcpServer := mcp.NewServer(
@@ -111,26 +207,41 @@ func NewHTTPServer(
 	)
 
 	// Create HTTP client
-	httpClient := createHTTPClient(config.ConnectionTimeout, config.TLSConfig)
+	httpClient := createHTTPClient(
+		config.ConnectionTimeout,
+		config.TLSConfig,
+		config.UserAgent,
+		config.MaxIdleConns,
+		config.MaxIdleConnsPerHost,
+	)
 
 	// Initialize batch-based analytics
 	// Note: In HTTP mode, FallbackRPToken is always empty (tokens come from HTTP headers).
 	// Analytics uses UserID for identification in HTTP mode.
+	// A failed initialization leaves analytics off; the reason is reported by /info
 	var analyticsInstance *analytics.Analytics
-	if config.AnalyticsOn && config.GA4Secret != "" {
-		var err error
-		analyticsInstance, err = analytics.NewAnalytics(
+	var analyticsErr error
+	if config.AnalyticsOn || config.MetricsFile != "" {
+		ga4Secret := config.GA4Secret
+		if !config.AnalyticsOn {
+			ga4Secret = "" // Local metrics file only
+		}
+		analyticsInstance, analyticsErr = analytics.NewAnalytics(
 			config.UserID,
-			config.GA4Secret,
+			ga4Secret,
 			"",                      // FallbackRPToken is always empty in HTTP mode
 			config.HostURL.String(), // ReportPortal host URL for instance ID
 			config.TLSConfig,
+			analytics.WithMetricsFile(config.MetricsFile),
+			analytics.WithGA4Endpoint(config.GA4Endpoint),
+			analytics.WithFlushInterval(config.FlushInterval),
 		)
-		if err != nil {
-			slog.Warn("Failed to initialize analytics", "error", err)
+		if analyticsErr != nil {
+			slog.Warn("Failed to initialize analytics", "error", analyticsErr)
 		} else {
 			slog.Info("HTTP MCP server initialized with batch-based analytics",
-				"has_ga4_secret", config.GA4Secret != "",
+				"has_ga4_secret", ga4Secret != "",
+				"metrics_file", config.MetricsFile,
 				"uses_user_id", config.UserID != "")
 		}
 	}
@@ -138,8 +249,10 @@ func NewHTTPServer(
 	httpServer := &HTTPServer{
 		mcpServer:         mcpServer,
 		AnalyticsInstance: analyticsInstance,
+		analyticsErr:      analyticsErr,
 		config:            config,
 		httpClient:        httpClient,
+		toolCache:         mcphandlers.NewToolResultCache(config.CacheSize, config.CacheTTL),
 	}
 
 	// Initialize tools and resources
@@ -167,7 +280,15 @@ func (hs *HTTPServer) initializeTools() error {
 	rpClient.APIClient.GetConfig().Middleware = app_middleware.QueryParamsMiddleware
 
 	// Register all launch-related tools and resources
-	mcphandlers.RegisterLaunchTools(hs.mcpServer, rpClient, "", hs.AnalyticsInstance, hs.httpClient)
+	mcphandlers.RegisterLaunchTools(
+		hs.mcpServer,
+		rpClient,
+		"",
+		hs.AnalyticsInstance,
+		hs.httpClient,
+		hs.config.RequireConfirm,
+		hs.config.ToolSettings,
+	)
 
 	// Register all test item-related tools and resources
 	mcphandlers.RegisterTestItemTools(
@@ -175,13 +296,63 @@ func (hs *HTTPServer) initializeTools() error {
 		rpClient,
 		"",
 		hs.AnalyticsInstance,
+		hs.config.ToolSettings,
 	)
 
 	// Register all TMS-related tools
 	mcphandlers.RegisterTMSTools(hs.mcpServer, rpClient, "", hs.AnalyticsInstance)
 
+	// Register all dashboard-related tools
+	mcphandlers.RegisterDashboardTools(hs.mcpServer, rpClient, "", hs.AnalyticsInstance)
+
+	// Register all notification-related tools
+	mcphandlers.RegisterNotificationTools(hs.mcpServer, rpClient, "", hs.AnalyticsInstance)
+
+	// Report the effective non-secret configuration
+	mcphandlers.RegisterServerConfigTool(hs.mcpServer, mcphandlers.ServerConfigInfo{
+		Mode:                "http",
+		Version:             hs.config.Version,
+		RPHost:              mcphandlers.RedactHostURL(hs.config.HostURL),
+		AnalyticsEnabled:    hs.config.AnalyticsOn && hs.config.GA4Secret != "",
+		MetricsFile:         hs.config.MetricsFile,
+		ReadOnly:            hs.config.ReadOnly,
+		RequireConfirm:      hs.config.RequireConfirm,
+		InsecureTLS:         hs.config.TLSConfig != nil && hs.config.TLSConfig.InsecureSkipVerify,
+		CacheSize:           hs.config.CacheSize,
+		CacheTTL:            hs.config.CacheTTL.String(),
+		DefaultPageSize:     hs.config.ToolSettings.Pagination.PageSize(),
+		MaxWorkers:          hs.config.MaxConcurrentRequests,
+		PerTokenConcurrency: hs.config.PerTokenConcurrency,
+		ConnectionTimeout:   hs.config.ConnectionTimeout.String(),
+		ShutdownTimeout:     hs.config.ShutdownTimeout.String(),
+		SessionIdleTimeout:  hs.sessionIdleTimeout(),
+		MaxRequestBytes:     hs.config.MaxRequestBytes,
+		ValidateToken:       hs.config.ValidateToken,
+	}, hs.AnalyticsInstance)
+
+	// In read-only mode hide every tool that changes data in ReportPortal
+	if hs.config.ReadOnly {
+		mcphandlers.RemoveMutatingTools(hs.mcpServer)
+	}
+
+	// Suggest the closest tool names when a client calls a tool that does not exist
+	mcphandlers.AddUnknownToolSuggestions(hs.mcpServer)
+
+	// Indent JSON tool results for human-readable transcripts; added before the cache so that
+	// cached results are stored indented
+	if hs.config.PrettyJSON {
+		mcphandlers.AddPrettyJSON(hs.mcpServer)
+	}
+
+	// Serve repeated identical read tool calls from the cache (nil when caching is disabled)
+	mcphandlers.AddToolResultCache(hs.mcpServer, hs.toolCache, hs.AnalyticsInstance)
+
 	// Add prompts
-	prompts, err := mcphandlers.ReadPrompts(mcphandlers.PromptFiles, "prompts")
+	prompts, err := mcphandlers.ReadPrompts(
+		mcphandlers.PromptFiles,
+		"prompts",
+		hs.config.MaxPromptOutputBytes,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to load prompts: %w", err)
 	}
@@ -211,14 +382,30 @@ func (hs *HTTPServer) Start() error {
 	return nil
 }
 
-// Stop gracefully shuts down the HTTP server
+// Stop gracefully shuts down the HTTP server, draining in-flight requests for up to
+// ShutdownTimeout. See Shutdown.
 func (hs *HTTPServer) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), hs.config.ShutdownTimeout)
+	defer cancel()
+	return hs.Shutdown(ctx)
+}
+
+// Shutdown gracefully shuts down the HTTP server.
+// It waits until ctx is done for in-flight tool requests to finish before stopping analytics,
+// so that their analytics events are still recorded. Pass the context used for
+// http.Server.Shutdown so that both share a single deadline.
+func (hs *HTTPServer) Shutdown(ctx context.Context) error {
 	if !hs.running.CompareAndSwap(true, false) {
 		return nil
 	}
 
 	slog.Info("Stopping HTTP server")
 
+	if !hs.waitForActiveRequests(ctx) {
+		slog.Warn("Shutdown timeout reached with requests still in flight",
+			"shutdown_timeout", hs.config.ShutdownTimeout)
+	}
+
 	// Stop analytics
 	if hs.AnalyticsInstance != nil {
 		hs.AnalyticsInstance.Stop()
@@ -228,6 +415,61 @@ func (hs *HTTPServer) Stop() error {
 	return nil
 }
 
+// beginActiveRequest registers an in-flight request
+func (hs *HTTPServer) beginActiveRequest() {
+	hs.activeMu.Lock()
+	hs.active++
+	hs.activeMu.Unlock()
+}
+
+// endActiveRequest unregisters an in-flight request and wakes a waiting drain on the last one
+func (hs *HTTPServer) endActiveRequest() {
+	hs.activeMu.Lock()
+	defer hs.activeMu.Unlock()
+	hs.active--
+	if hs.active == 0 && hs.idle != nil {
+		close(hs.idle)
+		hs.idle = nil
+	}
+}
+
+// waitForActiveRequests blocks until all tracked requests complete or ctx is done.
+// Returns true if all requests completed in time.
+func (hs *HTTPServer) waitForActiveRequests(ctx context.Context) bool {
+	hs.activeMu.Lock()
+	if hs.active == 0 {
+		hs.activeMu.Unlock()
+		return true
+	}
+	if hs.idle == nil {
+		hs.idle = make(chan struct{})
+	}
+	idle := hs.idle
+	hs.activeMu.Unlock()
+
+	select {
+	case <-idle:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// trackActiveRequestsMiddleware counts each request as active while it is served
+// so that Shutdown can drain them. SSE streams are excluded because they stay open until
+// the client disconnects and would otherwise always exhaust the drain period.
+func (hs *HTTPServer) trackActiveRequestsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hs.isSSEStreamRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		hs.beginActiveRequest()
+		defer hs.endActiveRequest()
+		next.ServeHTTP(w, r)
+	})
+}
+
 // CreateHTTPServerWithMiddleware creates a complete HTTP server setup with middleware
 func CreateHTTPServerWithMiddleware(
 	config HTTPServerConfig,
@@ -262,33 +504,65 @@ type HTTPServerInfo struct {
 	MaxConcurrentRequests int           `json:"max_concurrent_requests"`
 	ConnectionTimeout     string        `json:"connection_timeout"`
 	ConcurrencyModel      string        `json:"concurrency_model"`
+	SessionIdleTimeout    string        `json:"session_idle_timeout,omitempty"`
+	ActiveSessions        int           `json:"active_sessions"`
 	ServerRunning         bool          `json:"server_running"`
 	AnalyticsEnabled      bool          `json:"analytics_enabled"`
+	AnalyticsError        string        `json:"analytics_error,omitempty"`
 	Timestamp             time.Time     `json:"timestamp"`
 	Type                  string        `json:"type"`
+	Transport             string        `json:"transport"`
+	MCPEndpoints          []string      `json:"mcp_endpoints"`
 	Analytics             AnalyticsInfo `json:"analytics"`
+	Build                 BuildInfo     `json:"build"`
+	StartedAt             time.Time     `json:"started_at"`
+	Uptime                string        `json:"uptime"`
+}
+
+// BuildInfo identifies the deployed server build
+type BuildInfo struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+}
+
+// currentBuildInfo returns the build information injected at link time
+func currentBuildInfo() BuildInfo {
+	return BuildInfo{Version: config.Version, Commit: config.Commit, Date: config.Date}
+}
+
+// processUptime returns how long the server process has been running, rounded to seconds
+func processUptime() time.Duration {
+	return time.Since(processStartTime).Round(time.Second)
 }
 
 // corsMiddleware handles CORS headers for SSE streams and API requests
-// Exposes mcp-session-id header so clients can access it
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Set CORS headers
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
-		w.Header().
-			Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Accept, mcp-session-id")
-		w.Header().Set("Access-Control-Expose-Headers", "mcp-session-id")
-		w.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
-
-		// Handle preflight OPTIONS requests
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusNoContent)
-			return
-		}
+// Exposes mcp-session-id and X-Request-ID headers so clients can access them.
+// A custom token header is allowed in addition to Authorization.
+func corsMiddleware(tokenHeader string) func(http.Handler) http.Handler {
+	allowedHeaders := "Content-Type, Authorization, Accept, mcp-session-id, X-Request-ID, " +
+		"X-Analytics-Opt-Out"
+	if http.CanonicalHeaderKey(tokenHeader) != app_middleware.DefaultTokenHeader {
+		allowedHeaders += ", " + tokenHeader
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Set CORS headers
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+			w.Header().Set("Access-Control-Expose-Headers", "mcp-session-id, X-Request-ID")
+			w.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
+
+			// Handle preflight OPTIONS requests
+			if r.Method == "OPTIONS" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
 
-		next.ServeHTTP(w, r)
-	})
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
 // conditionalTimeoutMiddleware applies timeout only to non-SSE requests
@@ -305,32 +579,72 @@ func (hs *HTTPServer) conditionalTimeoutMiddleware(next http.Handler) http.Handl
 	})
 }
 
+// conditionalTokenConcurrencyMiddleware applies the per-token concurrency limit only to non-SSE
+// requests: SSE streams stay open for the whole session and would hold a slot forever
+func (hs *HTTPServer) conditionalTokenConcurrencyMiddleware(
+	limiter *app_middleware.TokenConcurrencyLimiter,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		limited := limiter.Middleware(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if hs.isSSEStreamRequest(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			limited.ServeHTTP(w, r)
+		})
+	}
+}
+
+// sessionIdleTimeout returns the reported session idle timeout. It is empty for the SSE transport,
+// whose sessions end only when their event stream disconnects.
+func (hs *HTTPServer) sessionIdleTimeout() string {
+	if hs.config.Transport == TransportSSE {
+		return ""
+	}
+	return hs.config.SessionIdleTimeout.String()
+}
+
 // setupChiRouter creates and configures the Chi router with all routes and middleware
 func (hs *HTTPServer) setupChiRouter() {
 	r := chi.NewRouter()
 
 	// Add CORS middleware first to ensure it applies to all routes
-	r.Use(corsMiddleware)
+	r.Use(corsMiddleware(hs.config.TokenHeader))
 
 	// Add Chi middleware
-	r.Use(middleware.RequestID)
+	r.Use(app_middleware.RequestIDMiddleware)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
+	// Reject oversized request bodies before they are parsed
+	r.Use(app_middleware.MaxRequestBodyMiddleware(hs.config.MaxRequestBytes))
+	// Access log with redacted credentials; reads the already size-limited body for the tool name
+	r.Use(app_middleware.AccessLogMiddleware(hs.config.AccessLogLevel, hs.config.TokenHeader))
+	// Track in-flight requests so shutdown can drain them
+	r.Use(hs.trackActiveRequestsMiddleware)
 	// Use conditional timeout that skips SSE streams
 	r.Use(hs.conditionalTimeoutMiddleware)
 
 	// Add HTTP concurrency control
 	r.Use(middleware.Throttle(hs.config.MaxConcurrentRequests))
 
-	// Create MCP HTTP handler using official SDK's StreamableHTTPHandler
-	// This properly dispatches to all registered tools, prompts, and resources
-	hs.mcpHTTPHandler = mcp.NewStreamableHTTPHandler(
-		func(r *http.Request) *mcp.Server {
-			return hs.mcpServer
-		},
-		nil, // Use default options
-	)
+	// Create MCP HTTP handler using official SDK's StreamableHTTPHandler, or its SSEHandler for
+	// clients that only speak the legacy HTTP+SSE transport.
+	// Both properly dispatch to all registered tools, prompts, and resources
+	getServer := func(r *http.Request) *mcp.Server {
+		return hs.mcpServer
+	}
+	if hs.config.Transport == TransportSSE {
+		hs.mcpHTTPHandler = mcp.NewSSEHandler(getServer, nil)
+	} else {
+		hs.mcpHTTPHandler = mcp.NewStreamableHTTPHandler(
+			getServer,
+			&mcp.StreamableHTTPOptions{
+				// Close sessions that clients abandoned without a DELETE so they do not accumulate
+				SessionTimeout: hs.config.SessionIdleTimeout,
+			},
+		)
+	}
 
 	hs.Router = r
 
@@ -353,8 +667,8 @@ func (hs *HTTPServer) setupRoutes() {
 	// Server info endpoint
 	hs.Router.Get("/info", hs.serverInfoHandler)
 
-	// Metrics endpoint (if analytics enabled)
-	if hs.AnalyticsInstance != nil {
+	// Metrics endpoint (if analytics or the tool result cache is enabled)
+	if hs.AnalyticsInstance != nil || hs.toolCache != nil {
 		hs.Router.Get("/metrics", hs.metricsHandler)
 	}
 
@@ -366,29 +680,106 @@ func (hs *HTTPServer) setupRoutes() {
 
 	// MCP endpoints using chi.Group pattern
 	hs.Router.Group(func(mcpRouter chi.Router) {
+		// Explain the required headers and handshake when a request is rejected as malformed
+		mcpRouter.Use(app_middleware.MCPRequestGuidanceMiddleware)
 		// Add MCP-specific middleware for token extraction and validation
-		mcpRouter.Use(app_middleware.HTTPTokenMiddleware)
+		mcpRouter.Use(app_middleware.TokenHeaderMiddleware(hs.config.TokenHeader))
+		if hs.config.ValidateToken {
+			validator := app_middleware.NewTokenValidator(
+				hs.config.HostURL,
+				hs.httpClient,
+				hs.config.ValidateTokenTTL,
+			)
+			mcpRouter.Use(validator.Middleware)
+		}
+		// Keep a single token from taking all the slots of the global Throttle limit
+		mcpRouter.Use(hs.conditionalTokenConcurrencyMiddleware(
+			app_middleware.NewTokenConcurrencyLimiter(hs.config.PerTokenConcurrency),
+		))
 		mcpRouter.Use(hs.mcpMiddleware)
 
+		if hs.config.Transport == TransportSSE {
+			// GET opens the event stream; POSTs to its ?sessionid= endpoint deliver messages
+			mcpRouter.Handle(sseEndpointPath, hs.mcpHTTPHandler)
+			return
+		}
+
 		// Handle all MCP endpoints
 		mcpRouter.Handle("/mcp", hs.mcpHTTPHandler)
 		mcpRouter.Handle("/api/mcp", hs.mcpHTTPHandler)
 		mcpRouter.Handle("/mcp/*", hs.mcpHTTPHandler)
 		mcpRouter.Handle("/api/mcp/*", hs.mcpHTTPHandler)
+		// Clients configured with the bare server URL POST JSON-RPC to the root
+		mcpRouter.Post("/", hs.mcpHTTPHandler.ServeHTTP)
+	})
+
+	// Point clients that guessed a wrong MCP path to the right one instead of a bare 404
+	hs.Router.NotFound(hs.wrongMCPPathHandler)
+}
+
+// mcpEndpointPaths are the paths served by the streamable MCP handler (POST "/" is accepted too)
+var mcpEndpointPaths = []string{"/mcp", "/api/mcp"}
+
+// sseEndpointPath is the path served by the legacy SSE handler
+const sseEndpointPath = "/sse"
+
+// mcpEndpoints returns the MCP endpoint paths of the configured transport
+func (hs *HTTPServer) mcpEndpoints() []string {
+	if hs.config.Transport == TransportSSE {
+		return []string{sseEndpointPath}
+	}
+	return mcpEndpointPaths
+}
+
+// wrongMCPPathHandler replies 400 with the correct MCP endpoint for paths that look like a
+// misconfigured MCP URL (e.g. /api, /sse, /v1/mcp) and 404 for everything else
+func (hs *HTTPServer) wrongMCPPathHandler(w http.ResponseWriter, r *http.Request) {
+	if !isWrongMCPPath(r.URL.Path) {
+		http.NotFound(w, r)
+		return
+	}
+
+	endpoints := hs.mcpEndpoints()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": fmt.Sprintf(
+			"%q is not an MCP endpoint; configure your client with %s",
+			r.URL.Path,
+			endpoints[0],
+		),
+		"mcp_endpoints": endpoints,
 	})
 }
 
-// GetHTTPServerInfo returns information about the HTTP server configuration
-func GetHTTPServerInfo(analyticsInstance *analytics.Analytics) HTTPServerInfo {
+// isWrongMCPPath reports whether an unrouted path was most likely meant to be the MCP endpoint
+func isWrongMCPPath(path string) bool {
+	trimmed := strings.ToLower(strings.Trim(path, "/"))
+	switch trimmed {
+	case "api", "sse", "message", "messages", "rpc", "jsonrpc":
+		return true
+	}
+	return strings.Contains(trimmed, "mcp")
+}
+
+// GetHTTPServerInfo returns information about the HTTP server configuration.
+// analyticsErr is the reason analytics was requested but failed to initialize, if any.
+func GetHTTPServerInfo(analyticsInstance *analytics.Analytics, analyticsErr error) HTTPServerInfo {
 	info := HTTPServerInfo{
-		Type: "http_mcp_server",
+		Type:      "http_mcp_server",
+		Build:     currentBuildInfo(),
+		StartedAt: processStartTime.UTC(),
+		Uptime:    processUptime().String(),
+	}
+	if analyticsErr != nil {
+		info.AnalyticsError = analyticsErr.Error()
 	}
 
 	if analyticsInstance != nil {
 		info.Analytics = AnalyticsInfo{
 			Enabled:  true,
 			Type:     "batch",
-			Interval: analytics.BatchSendInterval.String(),
+			Interval: analyticsInstance.FlushInterval().String(),
 		}
 	} else {
 		info.Analytics = AnalyticsInfo{
@@ -399,12 +790,24 @@ func GetHTTPServerInfo(analyticsInstance *analytics.Analytics) HTTPServerInfo {
 	return info
 }
 
+// activeSessions returns the number of MCP sessions currently connected to the server
+func (hs *HTTPServer) activeSessions() int {
+	count := 0
+	for range hs.mcpServer.Sessions() {
+		count++
+	}
+	return count
+}
+
 // healthHandler returns server health status
 func (hs *HTTPServer) healthHandler(w http.ResponseWriter, r *http.Request) {
 	health := map[string]interface{}{
-		"status":    "healthy",
-		"timestamp": time.Now().UTC(),
-		"version":   hs.config.Version,
+		"status":     "healthy",
+		"timestamp":  time.Now().UTC(),
+		"version":    hs.config.Version,
+		"build":      currentBuildInfo(),
+		"started_at": processStartTime.UTC(),
+		"uptime":     processUptime().String(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -422,13 +825,17 @@ func (hs *HTTPServer) healthHandler(w http.ResponseWriter, r *http.Request) {
 // serverInfoHandler returns comprehensive server information (merged /info and /status)
 func (hs *HTTPServer) serverInfoHandler(w http.ResponseWriter, r *http.Request) {
 	// Merge info and status data into comprehensive response
-	info := GetHTTPServerInfo(hs.AnalyticsInstance)
+	info := GetHTTPServerInfo(hs.AnalyticsInstance, hs.analyticsErr)
 
 	// Server configuration
 	info.Version = hs.config.Version
 	info.MaxConcurrentRequests = hs.config.MaxConcurrentRequests
 	info.ConnectionTimeout = hs.config.ConnectionTimeout.String()
 	info.ConcurrencyModel = "chi_throttle"
+	info.SessionIdleTimeout = hs.sessionIdleTimeout()
+	info.ActiveSessions = hs.activeSessions()
+	info.Transport = hs.config.Transport
+	info.MCPEndpoints = hs.mcpEndpoints()
 
 	// Runtime status
 	info.ServerRunning = hs.running.Load()
@@ -439,25 +846,33 @@ func (hs *HTTPServer) serverInfoHandler(w http.ResponseWriter, r *http.Request)
 	_ = json.NewEncoder(w).Encode(info)
 }
 
-// metricsHandler returns analytics metrics (if available)
+// metricsHandler returns analytics and tool result cache metrics (if available)
 func (hs *HTTPServer) metricsHandler(w http.ResponseWriter, r *http.Request) {
-	if hs.AnalyticsInstance == nil {
+	if hs.AnalyticsInstance == nil && hs.toolCache == nil {
 		http.Error(w, "Analytics not enabled", http.StatusNotFound)
 		return
 	}
 
 	// Return basic analytics information
-	metrics := AnalyticsInfo{
-		Enabled:  true,
-		Type:     "batch",
-		Interval: analytics.BatchSendInterval.String(),
+	metrics := AnalyticsInfo{Enabled: false}
+	if hs.AnalyticsInstance != nil {
+		metrics = AnalyticsInfo{
+			Enabled:  true,
+			Type:     "batch",
+			Interval: hs.AnalyticsInstance.FlushInterval().String(),
+		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+	response := map[string]interface{}{
 		"analytics": metrics,
 		"timestamp": time.Now().UTC(),
-	})
+	}
+	if hs.toolCache != nil {
+		response["cache"] = hs.toolCache.Stats()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
 }
 
 // rootHandler serves the root endpoint
@@ -471,7 +886,7 @@ func (hs *HTTPServer) rootHandler(w http.ResponseWriter, r *http.Request) {
 			"info":    "/info",
 			"metrics": "/metrics",
 			"api":     "/api/*",
-			"mcp":     "/api/mcp",
+			"mcp":     hs.mcpEndpoints()[0],
 		},
 	}
 
@@ -486,7 +901,12 @@ func (hs *HTTPServer) mcpMiddleware(next http.Handler) http.Handler {
 		// regular MCP JSON-RPC requests (POST with application/json),
 		// and MCP DELETE session-termination requests
 		if !hs.isMCPRequest(r) && !hs.isSSEStreamRequest(r) {
-			http.Error(w, "Invalid MCP request", http.StatusBadRequest)
+			http.Error(
+				w,
+				"Invalid MCP request: POST requires Content-Type: application/json, "+
+					"GET requires Accept: text/event-stream",
+				http.StatusBadRequest,
+			)
 			return
 		}
 		next.ServeHTTP(w, r)
@@ -583,14 +1003,19 @@ func RunStreamingServer(ctx context.Context, cmd *cli.Command) error {
 	// Wait for a shutdown signal or an error from the server
 	select {
 	case <-ctx.Done(): // Context canceled (e.g., SIGTERM received)
-		slog.Info("shutting down server...")
-		sCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		slog.Info("shutting down server...",
+			"shutdown_timeout", serverHandler.MCP.config.ShutdownTimeout)
+		sCtx, cancel := context.WithTimeout(
+			context.Background(),
+			serverHandler.MCP.config.ShutdownTimeout,
+		)
 		defer cancel()
 		if err := httpServer.Shutdown(sCtx); err != nil {
 			slog.Error("error during server shutdown", "error", err)
 		}
-		// Stop() handles analytics shutdown internally
-		if err := serverHandler.MCP.Stop(); err != nil {
+		// Shutdown waits for in-flight tool requests within the same deadline and handles
+		// analytics shutdown internally
+		if err := serverHandler.MCP.Shutdown(sCtx); err != nil {
 			slog.Error("error stopping HTTP server", "error", err)
 		}
 	case err := <-errC: // Error occurred while running the server
@@ -610,10 +1035,35 @@ func buildHTTPServerConfig(cmd *cli.Command) (HTTPServerConfig, error) {
 	userID := cmd.String("user-id")
 	analyticsAPISecret := analytics.GetAnalyticArg()
 	analyticsOff := cmd.Bool("analytics-off")
+	readOnly := cmd.Bool("read-only")
+	requireConfirm := cmd.Bool("require-confirm")
+	prettyJSON := cmd.Bool("pretty")
+	maxPromptOutputBytes := cmd.Int("max-prompt-output-bytes")
+	metricsFile := cmd.String("metrics-file")
+	ga4Endpoint := cmd.String("ga4-endpoint")
+	flushIntervalSec := cmd.Int("analytics-flush-interval")
 
 	// Performance tuning parameters with defaults
 	maxWorkers := cmd.Int("max-workers")
 	connectionTimeoutSec := cmd.Int("connection-timeout")
+	shutdownTimeoutSec := cmd.Int("shutdown-timeout")
+	sessionIdleTimeoutSec := cmd.Int("session-idle-timeout")
+	maxRequestBytes := cmd.Int("max-request-bytes")
+	perTokenConcurrency := cmd.Int("per-token-concurrency")
+	maxIdleConns := cmd.Int("max-idle-conns")
+	maxIdleConnsPerHost := cmd.Int("max-idle-conns-per-host")
+	validateToken := cmd.Bool("validate-token")
+	validateTokenTTLSec := cmd.Int("validate-token-ttl")
+	cacheSize := cmd.Int("cache-size")
+	cacheTTLSec := cmd.Int("cache-ttl")
+	userAgentSuffix := cmd.String("user-agent-suffix")
+	transport := cmd.String("transport")
+	tokenHeader := cmd.String("token-header")
+
+	var accessLogLevel slog.Level
+	if err := accessLogLevel.UnmarshalText([]byte(cmd.String("access-log-level"))); err != nil {
+		return HTTPServerConfig{}, fmt.Errorf("invalid access log level: %w", err)
+	}
 
 	// TLS settings
 	insecureTLS := cmd.Bool("insecure")
@@ -623,6 +1073,18 @@ func buildHTTPServerConfig(cmd *cli.Command) (HTTPServerConfig, error) {
 	if maxWorkers <= 0 {
 		maxWorkers = runtime.NumCPU() * 2
 	}
+	if maxIdleConns < 1 {
+		return HTTPServerConfig{}, fmt.Errorf(
+			"invalid max idle connections %d: must be positive",
+			maxIdleConns,
+		)
+	}
+	if maxIdleConnsPerHost < 1 {
+		return HTTPServerConfig{}, fmt.Errorf(
+			"invalid max idle connections per host %d: must be positive",
+			maxIdleConnsPerHost,
+		)
+	}
 
 	hostUrl, err := url.Parse(host)
 	if err != nil {
@@ -639,6 +1101,11 @@ func buildHTTPServerConfig(cmd *cli.Command) (HTTPServerConfig, error) {
 		return HTTPServerConfig{}, fmt.Errorf("build TLS config: %w", err)
 	}
 
+	toolSettings, err := mcphandlers.ToolSettingsFromFlags(cmd)
+	if err != nil {
+		return HTTPServerConfig{}, err
+	}
+
 	return HTTPServerConfig{
 		Version: fmt.Sprintf(
 			"%s (%s) %s",
@@ -651,8 +1118,30 @@ func buildHTTPServerConfig(cmd *cli.Command) (HTTPServerConfig, error) {
 		UserID:                userID,
 		GA4Secret:             analyticsAPISecret,
 		AnalyticsOn:           !analyticsOff,
+		MetricsFile:           metricsFile,
+		GA4Endpoint:           ga4Endpoint,
+		FlushInterval:         time.Duration(flushIntervalSec) * time.Second,
+		ReadOnly:              readOnly,
+		RequireConfirm:        requireConfirm,
+		PrettyJSON:            prettyJSON,
+		MaxPromptOutputBytes:  maxPromptOutputBytes,
+		ToolSettings:          toolSettings,
+		CacheSize:             cacheSize,
+		CacheTTL:              time.Duration(cacheTTLSec) * time.Second,
 		MaxConcurrentRequests: maxWorkers,
+		PerTokenConcurrency:   perTokenConcurrency,
 		ConnectionTimeout:     time.Duration(connectionTimeoutSec) * time.Second,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		ShutdownTimeout:       time.Duration(shutdownTimeoutSec) * time.Second,
+		SessionIdleTimeout:    time.Duration(sessionIdleTimeoutSec) * time.Second,
+		MaxRequestBytes:       int64(maxRequestBytes),
+		ValidateToken:         validateToken,
+		ValidateTokenTTL:      time.Duration(validateTokenTTLSec) * time.Second,
+		AccessLogLevel:        accessLogLevel,
 		TLSConfig:             tlsCfg,
+		UserAgent:             utils.BuildUserAgent(config.Version, userAgentSuffix),
+		Transport:             transport,
+		TokenHeader:           tokenHeader,
 	}, nil
 }
@@ -1,14 +1,30 @@
 package mcpreportportal
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/reportportal/reportportal-mcp-server/internal/config"
+	"github.com/reportportal/reportportal-mcp-server/internal/mcpclient"
 	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/analytics"
+	mcphandlers "github.com/reportportal/reportportal-mcp-server/internal/reportportal/mcp_handlers"
+	app_middleware "github.com/reportportal/reportportal-mcp-server/internal/reportportal/middleware"
 )
 
 func TestNewHTTPServer_WithoutRPAPIToken(t *testing.T) {
@@ -250,6 +266,50 @@ func TestCreateHTTPServerWithMiddleware_WithoutRPAPIToken(t *testing.T) {
 	}
 }
 
+// TestHTTPServer_RegistersMutatingTools verifies that HTTP mode registers every curated
+// mutating tool, just like stdio mode, and hides them all in read-only mode
+func TestHTTPServer_RegistersMutatingTools(t *testing.T) {
+	listTools := func(readOnly bool) []string {
+		httpServer, err := NewHTTPServer(HTTPServerConfig{
+			Version:  "1.0.0",
+			HostURL:  mustParseURL("https://reportportal.example.com"),
+			ReadOnly: readOnly,
+		})
+		require.NoError(t, err)
+
+		st, ct := mcp.NewInMemoryTransports()
+		_, err = httpServer.mcpServer.Connect(context.Background(), st, nil)
+		require.NoError(t, err)
+		cs, err := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "0"}, nil).
+			Connect(context.Background(), ct, nil)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, cs.Close()) }()
+
+		var names []string
+		for tool, err := range cs.Tools(context.Background(), nil) {
+			require.NoError(t, err)
+			names = append(names, tool.Name)
+		}
+		return names
+	}
+
+	tools := listTools(false)
+	for _, name := range mcphandlers.MutatingToolNames() {
+		assert.Contains(t, tools, name, "mutating tool %q is not registered in HTTP mode", name)
+	}
+
+	readOnlyTools := listTools(true)
+	assert.Contains(t, readOnlyTools, "get_launches")
+	for _, name := range readOnlyTools {
+		assert.False(
+			t,
+			mcphandlers.IsMutatingTool(name),
+			"mutating tool %q exposed in read-only mode",
+			name,
+		)
+	}
+}
+
 func TestHTTPServerConfig_Defaults(t *testing.T) {
 	config := HTTPServerConfig{
 		Version:         "1.0.0",
@@ -326,13 +386,360 @@ func TestHTTPServer_StopIdempotent(t *testing.T) {
 	}
 }
 
+func TestHTTPServer_StopDrainsActiveRequests(t *testing.T) {
+	config := HTTPServerConfig{
+		Version:         "1.0.0",
+		HostURL:         mustParseURL("https://reportportal.example.com"),
+		ShutdownTimeout: 2 * time.Second,
+	}
+
+	httpServer, err := NewHTTPServer(config)
+	require.NoError(t, err)
+	require.NoError(t, httpServer.Start())
+
+	started := make(chan struct{})
+	var completed atomic.Bool
+	httpServer.Router.Get("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		completed.Store(true)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rr := httptest.NewRecorder()
+	requestDone := make(chan struct{})
+	go func() {
+		defer close(requestDone)
+		httpServer.Router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	}()
+
+	<-started
+	require.NoError(t, httpServer.Stop())
+
+	// Stop must not return before the in-flight request has completed
+	assert.True(t, completed.Load(), "in-flight request should complete during shutdown")
+	<-requestDone
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+// TestHTTPServer_ShutdownHonoursContextDeadline verifies that draining in-flight requests stops
+// at the deadline of the context passed to Shutdown rather than waiting for ShutdownTimeout.
+func TestHTTPServer_ShutdownHonoursContextDeadline(t *testing.T) {
+	httpServer, err := NewHTTPServer(HTTPServerConfig{
+		Version:         "1.0.0",
+		HostURL:         mustParseURL("https://reportportal.example.com"),
+		ShutdownTimeout: time.Minute,
+	})
+	require.NoError(t, err)
+	require.NoError(t, httpServer.Start())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	httpServer.Router.Get("/hang", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+
+	requestDone := make(chan struct{})
+	go func() {
+		defer close(requestDone)
+		httpServer.Router.ServeHTTP(
+			httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodGet, "/hang", nil),
+		)
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	begin := time.Now()
+	require.NoError(t, httpServer.Shutdown(ctx))
+	assert.Less(t, time.Since(begin), 5*time.Second, "drain must stop at the context deadline")
+
+	close(release)
+	<-requestDone
+}
+
+func TestHTTPServerConfig_ShutdownTimeoutDefault(t *testing.T) {
+	httpServer, err := NewHTTPServer(HTTPServerConfig{
+		Version: "1.0.0",
+		HostURL: mustParseURL("https://reportportal.example.com"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, defaultShutdownTimeout, httpServer.config.ShutdownTimeout)
+}
+
+func TestHTTPServer_RejectsOversizedRequestBody(t *testing.T) {
+	httpServer, err := NewHTTPServer(HTTPServerConfig{
+		Version:         "1.0.0",
+		HostURL:         mustParseURL("https://reportportal.example.com"),
+		MaxRequestBytes: 64,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/mcp",
+		strings.NewReader(`{"jsonrpc":"2.0","method":"`+strings.Repeat("a", 64)+`"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	httpServer.Router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+}
+
+func TestHTTPServer_MCPEndpointAliases(t *testing.T) {
+	httpServer, err := NewHTTPServer(HTTPServerConfig{
+		Version: "1.0.0",
+		HostURL: mustParseURL("https://reportportal.example.com"),
+	})
+	require.NoError(t, err)
+
+	const initialize = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{` +
+		`"protocolVersion":"2025-06-18","capabilities":{},` +
+		`"clientInfo":{"name":"test","version":"0"}}}`
+
+	for _, path := range []string{"/mcp", "/mcp/", "/api/mcp", "/api/mcp/", "/"} {
+		t.Run(path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(initialize))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept", "application/json, text/event-stream")
+			rr := httptest.NewRecorder()
+			httpServer.Router.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+			assert.Contains(t, rr.Body.String(), "reportportal-mcp-server")
+		})
+	}
+}
+
+func TestHTTPServer_WrongMCPPath(t *testing.T) {
+	httpServer, err := NewHTTPServer(HTTPServerConfig{
+		Version: "1.0.0",
+		HostURL: mustParseURL("https://reportportal.example.com"),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path         string
+		expectedCode int
+	}{
+		{path: "/api", expectedCode: http.StatusBadRequest},
+		{path: "/sse", expectedCode: http.StatusBadRequest},
+		{path: "/v1/mcp", expectedCode: http.StatusBadRequest},
+		{path: "/MCP", expectedCode: http.StatusBadRequest},
+		{path: "/favicon.ico", expectedCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(`{}`))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+			httpServer.Router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+			if tt.expectedCode == http.StatusBadRequest {
+				var body map[string]any
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Contains(t, body["error"], "configure your client with /mcp")
+				assert.Equal(t, []any{"/mcp", "/api/mcp"}, body["mcp_endpoints"])
+			}
+		})
+	}
+}
+
+func TestHTTPServer_IdleSessionsAreClosed(t *testing.T) {
+	httpServer, err := NewHTTPServer(HTTPServerConfig{
+		Version:            "1.0.0",
+		HostURL:            mustParseURL("https://reportportal.example.com"),
+		SessionIdleTimeout: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	activeSessions := func() int {
+		rr := httptest.NewRecorder()
+		httpServer.Router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/info", nil))
+		var info HTTPServerInfo
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &info))
+		return info.ActiveSessions
+	}
+	mcpRequest := func(sessionID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json, text/event-stream")
+		if sessionID != "" {
+			req.Header.Set("Mcp-Session-Id", sessionID)
+		}
+		rr := httptest.NewRecorder()
+		httpServer.Router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	server := httptest.NewServer(httpServer.Router)
+	defer server.Close()
+	sessionID, err := mcpclient.Initialize(
+		context.Background(),
+		server.Client(),
+		server.URL+"/mcp",
+		mcpclient.Options{ClientName: "test"},
+	)
+	require.NoError(t, err)
+	require.NotEmpty(t, sessionID)
+	assert.Equal(t, 1, activeSessions())
+
+	// The session receives no further requests and is closed once the idle timeout expires
+	assert.Eventually(t, func() bool {
+		return activeSessions() == 0
+	}, 5*time.Second, 20*time.Millisecond)
+
+	rr := mcpRequest(sessionID, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+	assert.Equal(t, http.StatusNotFound, rr.Code, "an expired session must not be reused")
+}
+
+func TestHTTPServer_SSETransport(t *testing.T) {
+	httpServer, err := NewHTTPServer(HTTPServerConfig{
+		Version:   "1.0.0",
+		HostURL:   mustParseURL("https://reportportal.example.com"),
+		Transport: TransportSSE,
+	})
+	require.NoError(t, err)
+	server := httptest.NewServer(httpServer.Router)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/sse", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	stream, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = stream.Body.Close() }()
+	require.Equal(t, http.StatusOK, stream.StatusCode)
+
+	// readEvent returns the name and data of the next server-sent event
+	reader := bufio.NewReader(stream.Body)
+	readEvent := func() (string, string) {
+		var event, data string
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case line == "" && event != "":
+				return event, data
+			case strings.HasPrefix(line, "event:"):
+				event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			case strings.HasPrefix(line, "data:"):
+				data += strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			}
+		}
+	}
+
+	event, endpoint := readEvent()
+	require.Equal(t, "endpoint", event)
+	require.True(t, strings.HasPrefix(endpoint, "/sse?sessionid="), endpoint)
+
+	const initialize = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{` +
+		`"protocolVersion":"2024-11-05","capabilities":{},` +
+		`"clientInfo":{"name":"test","version":"0"}}}`
+	resp, err := http.Post(server.URL+endpoint, "application/json", strings.NewReader(initialize))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	event, data := readEvent()
+	require.Equal(t, "message", event)
+	var message struct {
+		ID     int `json:"id"`
+		Result struct {
+			ServerInfo struct {
+				Name string `json:"name"`
+			} `json:"serverInfo"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(data), &message), data)
+	assert.Equal(t, 1, message.ID)
+	assert.Equal(t, "reportportal-mcp-server", message.Result.ServerInfo.Name)
+
+	// /info documents the SSE endpoint, and the streamable paths now point to it
+	rr := httptest.NewRecorder()
+	httpServer.Router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/info", nil))
+	var info HTTPServerInfo
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &info))
+	assert.Equal(t, TransportSSE, info.Transport)
+	assert.Equal(t, []string{"/sse"}, info.MCPEndpoints)
+	assert.Empty(t, info.SessionIdleTimeout, "the idle timeout does not apply to SSE sessions")
+
+	mcpReq := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(initialize))
+	mcpReq.Header.Set("Content-Type", "application/json")
+	rr = httptest.NewRecorder()
+	httpServer.Router.ServeHTTP(rr, mcpReq)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "configure your client with /sse")
+}
+
+func TestNewHTTPServer_UnknownTransport(t *testing.T) {
+	_, err := NewHTTPServer(HTTPServerConfig{
+		Version:   "1.0.0",
+		HostURL:   mustParseURL("https://reportportal.example.com"),
+		Transport: "websocket",
+	})
+	assert.ErrorContains(t, err, `unknown MCP transport "websocket"`)
+}
+
+func TestHTTPServer_MalformedMCPRequestExplainsRequiredHeaders(t *testing.T) {
+	httpServer, err := NewHTTPServer(HTTPServerConfig{
+		Version: "1.0.0",
+		HostURL: mustParseURL("https://reportportal.example.com"),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{name: "wrong content type", contentType: "text/plain", body: `{}`},
+		{name: "empty JSON-RPC payload", contentType: "application/json", body: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("Accept", "application/json, text/event-stream")
+			rr := httptest.NewRecorder()
+			httpServer.Router.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+			assert.Contains(t, rr.Body.String(), "application/json")
+			assert.Contains(t, rr.Body.String(), "Bearer")
+			assert.Contains(t, rr.Body.String(), "X-Project")
+			assert.Contains(t, rr.Body.String(), "initialize")
+		})
+	}
+}
+
+func TestHTTPServerConfig_MaxRequestBytesDefault(t *testing.T) {
+	httpServer, err := NewHTTPServer(HTTPServerConfig{
+		Version: "1.0.0",
+		HostURL: mustParseURL("https://reportportal.example.com"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, app_middleware.DefaultMaxRequestBytes, httpServer.config.MaxRequestBytes)
+}
+
 func TestGetHTTPServerInfo(t *testing.T) {
 	tests := []struct {
 		name             string
 		analytics        *analytics.Analytics
+		analyticsErr     error
 		expectAnalytics  bool
 		expectedType     string
 		expectedInterval string
+		expectedError    string
 	}{
 		{
 			name:            "server info without analytics",
@@ -350,13 +757,24 @@ func TestGetHTTPServerInfo(t *testing.T) {
 			expectedType:     "batch",
 			expectedInterval: analytics.BatchSendInterval.String(),
 		},
+		{
+			name:            "server info with failed analytics",
+			analyticsErr:    errors.New("analytics disabled: missing GA4 API secret"),
+			expectAnalytics: false,
+			expectedError:   "analytics disabled: missing GA4 API secret",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			info := GetHTTPServerInfo(tt.analytics)
+			info := GetHTTPServerInfo(tt.analytics, tt.analyticsErr)
 
 			assert.Equal(t, "http_mcp_server", info.Type)
+			assert.Equal(t, tt.expectedError, info.AnalyticsError)
+			assert.Equal(t, config.Version, info.Build.Version)
+			assert.Equal(t, config.Commit, info.Build.Commit)
+			assert.Equal(t, config.Date, info.Build.Date)
+			assert.NotEmpty(t, info.Uptime)
 
 			if tt.expectAnalytics {
 				assert.True(t, info.Analytics.Enabled)
@@ -369,6 +787,105 @@ func TestGetHTTPServerInfo(t *testing.T) {
 	}
 }
 
+func TestHTTPServer_InfoReportsAnalyticsError(t *testing.T) {
+	serverInfo := func(config HTTPServerConfig) map[string]any {
+		t.Helper()
+		httpServer, err := NewHTTPServer(config)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		httpServer.Router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/info", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		var info map[string]any
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &info))
+		return info
+	}
+
+	// Analytics requested without a GA4 secret fails to initialize; the server still starts
+	info := serverInfo(HTTPServerConfig{
+		Version:     "1.0.0",
+		HostURL:     mustParseURL("https://reportportal.example.com"),
+		AnalyticsOn: true,
+	})
+	assert.Contains(t, info["analytics_error"], "missing GA4 API secret")
+	assert.Equal(t, false, info["analytics"].(map[string]any)["enabled"])
+
+	// Analytics turned off is not an error
+	info = serverInfo(HTTPServerConfig{
+		Version: "1.0.0",
+		HostURL: mustParseURL("https://reportportal.example.com"),
+	})
+	assert.NotContains(t, info, "analytics_error")
+}
+
+func TestHTTPServer_HealthReportsBuildAndUptime(t *testing.T) {
+	httpServer, err := NewHTTPServer(HTTPServerConfig{
+		Version: "1.0.0",
+		HostURL: mustParseURL("https://reportportal.example.com"),
+	})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	httpServer.Router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+	var health map[string]any
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &health))
+
+	assert.Equal(t, "1.0.0", health["version"])
+	build, ok := health["build"].(map[string]any)
+	require.True(t, ok, "expected build object")
+	assert.Equal(t, config.Version, build["version"])
+	assert.Equal(t, config.Commit, build["commit"])
+	assert.Equal(t, config.Date, build["date"])
+	uptime, err := time.ParseDuration(health["uptime"].(string))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, uptime, time.Duration(0))
+	assert.NotEmpty(t, health["started_at"])
+}
+
+func TestCreateHTTPClient_TrustsCustomCA(t *testing.T) {
+	rp := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer rp.Close()
+
+	// Without the custom CA the self-signed certificate is rejected
+	resp, err := createHTTPClient(5*time.Second, nil, "test", 100, 10).Get(rp.URL)
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+	require.Error(t, err)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rp.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0o600))
+
+	tlsCfg, err := config.BuildTLSConfig(false, caFile)
+	require.NoError(t, err)
+	require.NotNil(t, tlsCfg)
+	assert.False(t, tlsCfg.InsecureSkipVerify)
+
+	resp, err = createHTTPClient(5*time.Second, tlsCfg, "test", 100, 10).Get(rp.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestCreateHTTPClient_ConnectionPool(t *testing.T) {
+	client := createHTTPClient(5*time.Second, nil, "", 250, 40)
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok, "expected *http.Transport, got %T", client.Transport)
+	assert.Equal(t, 250, transport.MaxIdleConns)
+	assert.Equal(t, 40, transport.MaxIdleConnsPerHost)
+
+	// Unset pool sizes keep the defaults
+	httpServer, err := NewHTTPServer(HTTPServerConfig{
+		Version: "1.0.0",
+		HostURL: mustParseURL("https://reportportal.example.com"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, defaultMaxIdleConns, httpServer.config.MaxIdleConns)
+	assert.Equal(t, defaultMaxIdleConnsPerHost, httpServer.config.MaxIdleConnsPerHost)
+}
+
 // mustParseURL is a helper function to parse URLs for tests
 func mustParseURL(rawURL string) *url.URL {
 	u, err := url.Parse(rawURL)
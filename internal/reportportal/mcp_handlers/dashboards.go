@@ -0,0 +1,319 @@
+package mcphandlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/reportportal/goRP/v5/pkg/gorp"
+	"github.com/reportportal/goRP/v5/pkg/openapi"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/analytics"
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
+)
+
+// DashboardResources encapsulates the ReportPortal client for dashboard-related tools.
+type DashboardResources struct {
+	client            *gorp.Client
+	defaultProjectKey string
+	analytics         *analytics.Analytics
+}
+
+// NewDashboardResources creates a new DashboardResources instance.
+func NewDashboardResources(
+	client *gorp.Client,
+	analyticsClient *analytics.Analytics,
+	projectKey string,
+) *DashboardResources {
+	return &DashboardResources{
+		client:            client,
+		defaultProjectKey: projectKey,
+		analytics:         analyticsClient,
+	}
+}
+
+// RegisterDashboardTools registers all dashboard-related tools with the MCP server.
+func RegisterDashboardTools(
+	s *mcp.Server,
+	rpClient *gorp.Client,
+	defaultProjectKey string,
+	analyticsClient *analytics.Analytics,
+) {
+	dashboards := NewDashboardResources(rpClient, analyticsClient, defaultProjectKey)
+
+	registerTool(s, dashboards.toolCreateDashboard)
+	registerTool(s, dashboards.toolAddWidgetToDashboard)
+}
+
+// CreateDashboardArgs holds params for create_dashboard.
+type CreateDashboardArgs struct {
+	ProjectKey  string `json:"projectKey"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// toolCreateDashboard creates a tool that creates an empty dashboard in a project.
+// ReportPortal 5 has no per-dashboard sharing: dashboards are visible to all project members.
+func (dr *DashboardResources) toolCreateDashboard() (*mcp.Tool, ToolHandler[CreateDashboardArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(dr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name: "create_dashboard",
+			Description: "Create an empty dashboard in a project and return its ID. " +
+				"The dashboard is visible to all project members. This tool mutates data.",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"name": {
+						Type:        "string",
+						Description: "Dashboard name, unique within the project",
+					},
+					"description": {
+						Type:        "string",
+						Description: "Optional dashboard description",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		utils.WithAnalytics(
+			dr.analytics,
+			"create_dashboard",
+			func(ctx context.Context, req *mcp.CallToolRequest, args CreateDashboardArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				name := strings.TrimSpace(args.Name)
+				if name == "" {
+					return nil, nil, fmt.Errorf("name must not be empty or whitespace")
+				}
+
+				rq := openapi.NewComEpamReportportalBaseModelDashboardCreateDashboardRQ(name)
+				if description := strings.TrimSpace(args.Description); description != "" {
+					rq.SetDescription(description)
+				}
+
+				created, response, err := dr.client.DashboardAPI.CreateDashboard(ctx, project).
+					ComEpamReportportalBaseModelDashboardCreateDashboardRQ(*rq).
+					Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				r, err := json.Marshal(map[string]any{
+					"id":   created.GetId(),
+					"name": name,
+				})
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// widgetTypes are the widget types accepted by the ReportPortal widget API
+var widgetTypes = []string{
+	"oldLineChart",
+	"investigatedTrend",
+	"launchStatistics",
+	"statisticTrend",
+	"casesTrend",
+	"notPassed",
+	"overallStatistics",
+	"uniqueBugTable",
+	"bugTrend",
+	"activityStream",
+	"launchesComparisonChart",
+	"launchesDurationChart",
+	"launchesTable",
+	"topTestCases",
+	"flakyTestCases",
+	"passingRateSummary",
+	"passingRatePerLaunch",
+	"productStatus",
+	"mostTimeConsuming",
+	"cumulative",
+	"topPatternTemplates",
+	"componentHealthCheck",
+	"componentHealthCheckTable",
+}
+
+// AddWidgetToDashboardArgs holds params for add_widget_to_dashboard.
+type AddWidgetToDashboardArgs struct {
+	ProjectKey        string                                                       `json:"projectKey"`
+	DashboardID       int64                                                        `json:"dashboard_id"`
+	WidgetType        string                                                       `json:"widget_type"`
+	Name              string                                                       `json:"name"`
+	Description       string                                                       `json:"description"`
+	ContentParameters *openapi.ComEpamReportportalBaseModelWidgetContentParameters `json:"content_parameters"`
+	FilterIDs         []int64                                                      `json:"filter_ids"`
+}
+
+// toolAddWidgetToDashboard creates a tool that creates a widget and places it on a dashboard.
+// ReportPortal has no single call for this, so the widget is created first and then added.
+func (dr *DashboardResources) toolAddWidgetToDashboard() (*mcp.Tool, ToolHandler[AddWidgetToDashboardArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(dr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	widgetTypeEnum := make([]any, len(widgetTypes))
+	for i, widgetType := range widgetTypes {
+		widgetTypeEnum[i] = widgetType
+	}
+	return &mcp.Tool{
+			Name: "add_widget_to_dashboard",
+			Description: "Create a widget and add it to an existing dashboard; returns the new widget ID. " +
+				"Most widget types also need the IDs of the saved filters that select their launches. " +
+				"This tool mutates data.",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"dashboard_id": {
+						Type:        "integer",
+						Description: "ID of the dashboard to add the widget to",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+					"widget_type": {
+						Type:        "string",
+						Description: "Widget type",
+						Enum:        widgetTypeEnum,
+					},
+					"name": {
+						Type:        "string",
+						Description: "Widget name, unique within the project",
+					},
+					"description": {
+						Type:        "string",
+						Description: "Optional widget description",
+					},
+					"content_parameters": {
+						Type:        "object",
+						Description: "Widget content parameters",
+						Properties: map[string]*jsonschema.Schema{
+							"contentFields": {
+								Type: "array",
+								Description: "Statistics fields shown by the widget, " +
+									"e.g. statistics$executions$total or statistics$defects$product_bug$pb001",
+								Items: &jsonschema.Schema{Type: "string"},
+							},
+							"itemsCount": {
+								Type:        "integer",
+								Description: "Number of launches the widget is built from",
+								Minimum:     openapi.PtrFloat64(1),
+							},
+							"widgetOptions": {
+								Type:        "object",
+								Description: "Widget type specific options, e.g. {\"viewMode\": \"bar\"}",
+							},
+						},
+					},
+					"filter_ids": {
+						Type:        "array",
+						Description: "IDs of the saved filters the widget is built from",
+						Items:       &jsonschema.Schema{Type: "integer"},
+					},
+				},
+				Required: []string{"dashboard_id", "widget_type", "name"},
+			},
+		},
+		utils.WithAnalytics(
+			dr.analytics,
+			"add_widget_to_dashboard",
+			func(ctx context.Context, req *mcp.CallToolRequest, args AddWidgetToDashboardArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.DashboardID < 1 {
+					return nil, nil, fmt.Errorf("dashboard_id is required")
+				}
+				if !slices.Contains(widgetTypes, args.WidgetType) {
+					return nil, nil, fmt.Errorf(
+						"widget_type %q is not valid; must be one of: %s",
+						args.WidgetType,
+						strings.Join(widgetTypes, ", "),
+					)
+				}
+				name := strings.TrimSpace(args.Name)
+				if name == "" {
+					return nil, nil, fmt.Errorf("name must not be empty or whitespace")
+				}
+
+				rq := openapi.NewComEpamReportportalBaseModelWidgetWidgetRQ(name, args.WidgetType)
+				if description := strings.TrimSpace(args.Description); description != "" {
+					rq.SetDescription(description)
+				}
+				if args.ContentParameters != nil {
+					rq.SetContentParameters(*args.ContentParameters)
+				}
+				if len(args.FilterIDs) > 0 {
+					rq.SetFilterIds(args.FilterIDs)
+				}
+
+				created, response, err := dr.client.WidgetAPI.CreateWidget(ctx, project).
+					ComEpamReportportalBaseModelWidgetWidgetRQ(*rq).
+					Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+				widgetID := created.GetId()
+
+				widget := openapi.NewComEpamReportportalBaseModelDashboardDashboardResourceWidgetObjectModel(
+					widgetID,
+				)
+				widget.SetWidgetName(name)
+				widget.SetWidgetType(args.WidgetType)
+				_, response, err = dr.client.DashboardAPI.AddWidget(ctx, args.DashboardID, project).
+					ComEpamReportportalBaseModelDashboardAddWidgetRq(
+						*openapi.NewComEpamReportportalBaseModelDashboardAddWidgetRq(*widget),
+					).
+					Execute()
+				if err != nil {
+					// The widget exists at this point; report its ID so it can be reused or removed
+					return nil, nil, fmt.Errorf(
+						"widget %d was created but could not be added to dashboard %d: %s: %w",
+						widgetID,
+						args.DashboardID,
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				r, err := json.Marshal(map[string]any{
+					"widget_id":    widgetID,
+					"dashboard_id": args.DashboardID,
+				})
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
@@ -0,0 +1,142 @@
+package mcphandlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/reportportal/goRP/v5/pkg/gorp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDashboardTool(t *testing.T) {
+	ctx := context.Background()
+
+	var requests int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/test-project/dashboard", r.URL.Path)
+
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]any{
+			"name":        "Nightly",
+			"description": "Nightly regression overview",
+		}, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":17}`))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewDashboardResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolCreateDashboard()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, CreateDashboardArgs{
+		ProjectKey:  "test-project",
+		Name:        " Nightly ",
+		Description: "Nightly regression overview",
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.JSONEq(
+		t,
+		`{"id":17,"name":"Nightly"}`,
+		result.Content[0].(*mcp.TextContent).Text,
+	)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, CreateDashboardArgs{
+		ProjectKey: "test-project",
+		Name:       "  ",
+	})
+	assert.ErrorContains(t, err, "name must not be empty")
+	assert.Equal(t, 1, requests, "an invalid name must not reach ReportPortal")
+}
+
+func TestAddWidgetToDashboardTool(t *testing.T) {
+	ctx := context.Background()
+
+	var paths []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/test-project/widget":
+			assert.Equal(t, map[string]any{
+				"name":       "Pass rate",
+				"widgetType": "passingRatePerLaunch",
+				"contentParameters": map[string]any{
+					"itemsCount":    float64(30),
+					"widgetOptions": map[string]any{"launchNameFilter": "nightly"},
+				},
+				"filterIds": []any{float64(5)},
+			}, body)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":33}`))
+		case "/api/v1/test-project/dashboard/17/add":
+			assert.Equal(t, map[string]any{
+				"addWidget": map[string]any{
+					"widgetId":   float64(33),
+					"widgetName": "Pass rate",
+					"widgetType": "passingRatePerLaunch",
+				},
+			}, body)
+			_, _ = w.Write([]byte(`{"message":"Widget added"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewDashboardResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolAddWidgetToDashboard()
+
+	var args AddWidgetToDashboardArgs
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"projectKey": "test-project",
+		"dashboard_id": 17,
+		"widget_type": "passingRatePerLaunch",
+		"name": "Pass rate",
+		"content_parameters": {"itemsCount": 30, "widgetOptions": {"launchNameFilter": "nightly"}},
+		"filter_ids": [5]
+	}`), &args))
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, args)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.JSONEq(
+		t,
+		`{"widget_id":33,"dashboard_id":17}`,
+		result.Content[0].(*mcp.TextContent).Text,
+	)
+	assert.Equal(t, []string{
+		"POST /api/v1/test-project/widget",
+		"PUT /api/v1/test-project/dashboard/17/add",
+	}, paths)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, AddWidgetToDashboardArgs{
+		ProjectKey:  "test-project",
+		DashboardID: 17,
+		WidgetType:  "pieChart",
+		Name:        "Pass rate",
+	})
+	assert.ErrorContains(t, err, `widget_type "pieChart" is not valid`)
+	assert.Len(t, paths, 2, "an invalid widget type must not reach ReportPortal")
+}
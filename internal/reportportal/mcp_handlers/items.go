@@ -1,14 +1,21 @@
 package mcphandlers
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net/http"
 	"net/url"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -25,17 +32,35 @@ func RegisterTestItemTools(
 	rpClient *gorp.Client,
 	defaultProjectKey string,
 	analyticsClient *analytics.Analytics,
+	settings ToolSettings,
 ) {
 	testItems := NewTestItemResources(rpClient, analyticsClient, defaultProjectKey)
+	testItems.settings = settings
 
 	registerTool(s, testItems.toolGetTestItemById)
+	registerTool(s, testItems.toolGetTestItemParameters)
+	registerTool(s, testItems.toolGetTestItemIssue)
+	registerTool(s, testItems.toolGetItemsByCodeRef)
 	registerTool(s, testItems.toolGetTestItemsByFilter)
 	registerTool(s, testItems.toolGetTestItemLogsByFilter)
+	registerTool(s, testItems.toolGetItemLogsText)
 	registerTool(s, testItems.toolGetTestItemAttachment)
+	registerTool(s, testItems.toolListTestItemAttachments)
 	registerTool(s, testItems.toolGetTestSuitesByFilter)
+	registerTool(s, testItems.toolGetNestedSteps)
 	registerTool(s, testItems.toolGetProjectDefectTypes)
+	registerTool(s, testItems.toolGetBTSIntegrations)
 	registerTool(s, testItems.toolUpdateDefectTypeForTestItems)
+	registerTool(s, testItems.toolFinishStuckItems)
+	registerTool(s, testItems.toolBulkAddAttributeToItems)
+	registerTool(s, testItems.toolSetIgnoreAnalyzer)
 	registerTool(s, testItems.toolGetTestItemsHistory)
+	registerTool(s, testItems.toolGetTestCaseHistoryByHash)
+	registerTool(s, testItems.toolGetFailureContextLogs)
+	registerTool(s, testItems.toolGetLaunchFailureSummary)
+	registerTool(s, testItems.toolGetLogsGroupedByItem)
+	registerTool(s, testItems.toolGetUniqueFailureMessages)
+	registerTool(s, testItems.toolGetFlakyItems)
 
 	registerResourceTemplate(s, testItems.resourceTestItem)
 }
@@ -45,6 +70,7 @@ type TestItemResources struct {
 	client            *gorp.Client // Client to interact with the ReportPortal API
 	defaultProjectKey string       // Default project key
 	analytics         *analytics.Analytics
+	settings          ToolSettings // Server-wide tool defaults
 }
 
 func NewTestItemResources(
@@ -103,7 +129,8 @@ func (lr *TestItemResources) resolveFilterIDForProvider(
 		return "", fmt.Errorf("filter-id is empty")
 	}
 	if isAllDecimalDigits(trimmed) {
-		slog.Debug(
+		slog.DebugContext(
+			ctx,
 			"filter-id is numeric; using as saved filter ID",
 			"filterId",
 			trimmed,
@@ -116,7 +143,8 @@ func (lr *TestItemResources) resolveFilterIDForProvider(
 	if err != nil {
 		return "", err
 	}
-	slog.Debug(
+	slog.DebugContext(
+		ctx,
 		"resolved filter-id from saved filter name",
 		"filterName",
 		trimmed,
@@ -130,34 +158,58 @@ func (lr *TestItemResources) resolveFilterIDForProvider(
 
 // GetTestItemsByFilterArgs holds filter and pagination params for get_test_items_by_filter.
 type GetTestItemsByFilterArgs struct {
-	ProjectKey                  string `json:"projectKey"`
-	LaunchID                    int32  `json:"launch-id"`
-	Page                        uint   `json:"page"`
-	PageSize                    uint   `json:"page-size"`
-	PageSort                    string `json:"page-sort"`
-	FilterCntName               string `json:"filter-cnt-name"`
-	FilterHasCompositeAttribute string `json:"filter-has-compositeAttribute"`
-	FilterHasAttributeKey       string `json:"filter-has-attributeKey"`
-	FilterCntDescription        string `json:"filter-cnt-description"`
-	FilterInStatus              string `json:"filter-in-status"`
-	FilterEqHasRetries          string `json:"filter-eq-hasRetries"`
-	FilterEqParentId            string `json:"filter-eq-parentId"`
-	FilterBtwStartTimeFrom      string `json:"filter-btw-startTime-from"`
-	FilterBtwStartTimeTo        string `json:"filter-btw-startTime-to"`
-	FilterCntIssueComment       string `json:"filter-cnt-issueComment"`
-	FilterInIgnoreAnalyzer      *bool  `json:"filter-in-ignoreAnalyzer"`
-	FilterHasTicketId           string `json:"filter-has-ticketId"`
-	FilterAnyPatternName        string `json:"filter-any-patternName"`
-	FilterEqAutoAnalyzed        *bool  `json:"filter-eq-autoAnalyzed"`
-	IncludeBeforeAfterHooks     *bool  `json:"include-before-after-hooks"`
-	FilterAnyCompositeAttribute string `json:"filter-any-compositeAttribute"`
-	FilterName                  string `json:"filter-name"`
-	LaunchesLimit               uint32 `json:"launches-limit"`
+	ProjectKey                  string  `json:"projectKey"`
+	LaunchID                    int32   `json:"launch-id"`
+	LaunchIDs                   []int32 `json:"launch-ids"`
+	Page                        uint    `json:"page"`
+	PageSize                    uint    `json:"page-size"`
+	PageSort                    string  `json:"page-sort"`
+	FilterCntName               string  `json:"filter-cnt-name"`
+	FilterHasCompositeAttribute string  `json:"filter-has-compositeAttribute"`
+	FilterHasAttributeKey       string  `json:"filter-has-attributeKey"`
+	FilterCntDescription        string  `json:"filter-cnt-description"`
+	FilterInStatus              string  `json:"filter-in-status"`
+	FilterNeStatus              string  `json:"filter-ne-status"`
+	FilterNeName                string  `json:"filter-ne-name"`
+	FilterEqHasRetries          string  `json:"filter-eq-hasRetries"`
+	FilterEqParentId            string  `json:"filter-eq-parentId"`
+	FilterBtwStartTimeFrom      string  `json:"filter-btw-startTime-from"`
+	FilterBtwStartTimeTo        string  `json:"filter-btw-startTime-to"`
+	FilterCntIssueComment       string  `json:"filter-cnt-issueComment"`
+	FilterInIgnoreAnalyzer      *bool   `json:"filter-in-ignoreAnalyzer"`
+	FilterHasTicketId           string  `json:"filter-has-ticketId"`
+	FilterAnyPatternName        string  `json:"filter-any-patternName"`
+	FilterEqAutoAnalyzed        *bool   `json:"filter-eq-autoAnalyzed"`
+	IncludeBeforeAfterHooks     *bool   `json:"include-before-after-hooks"`
+	FilterAnyCompositeAttribute string  `json:"filter-any-compositeAttribute"`
+	FilterName                  string  `json:"filter-name"`
+	LaunchesLimit               uint32  `json:"launches-limit"`
 	// FilterEqDefectType maps to filter.eq.issueType (defect/issue type locator). Valid values
 	// come from get_project_defect_types (same locators as defect_type_id on update_defect_type_for_test_items).
 	FilterEqDefectType string `json:"filter-eq-defect-type"`
+	LastHours          uint   `json:"last_hours"`
+	LastDays           uint   `json:"last_days"`
+	ExpandRetries      bool   `json:"expand_retries"`
+	IncludeLinks       bool   `json:"include_links"`
+	FlattenAttributes  bool   `json:"flatten_attributes"`
+	CountOnly          bool   `json:"count_only"`
+	FetchAll           bool   `json:"fetch_all"`
 }
 
+const (
+	// expandRetriesMaxItems caps the items whose retries are inlined by expand_retries
+	expandRetriesMaxItems = 20
+	// expandRetriesConcurrency bounds the parallel retry lookups of expand_retries
+	expandRetriesConcurrency = 5
+	// multiLaunchItemsMaxLaunches caps the launches queried at once through launch-ids
+	multiLaunchItemsMaxLaunches = 20
+	// multiLaunchItemsConcurrency bounds the parallel per-launch queries of launch-ids
+	multiLaunchItemsConcurrency = 5
+)
+
+// testItemFilterStatuses are the execution statuses accepted by test item status filters
+var testItemFilterStatuses = []string{"PASSED", "FAILED", "SKIPPED", "INTERRUPTED", "IN_PROGRESS"}
+
 // toolGetTestItemsByFilter creates a tool to list test items for a specific launch.
 func (lr *TestItemResources) toolGetTestItemsByFilter() (*mcp.Tool, ToolHandler[GetTestItemsByFilterArgs, any]) {
 	properties := make(map[string]*jsonschema.Schema)
@@ -173,20 +225,34 @@ func (lr *TestItemResources) toolGetTestItemsByFilter() (*mcp.Tool, ToolHandler[
 	properties["launch-id"] = &jsonschema.Schema{
 		Type: "integer",
 		Description: "Maps to filter.eq.launchId. When set, providerType is launch. " +
-			"Conditionally required if filter-name is not provided. " +
+			"Conditionally required if neither launch-ids nor filter-name is provided. " +
 			"Must be non-negative; when querying by launch, use a positive ReportPortal launch ID (omit or 0 when using filter-name only).",
 		Minimum: openapi.PtrFloat64(0),
 	}
+	properties["launch-ids"] = &jsonschema.Schema{
+		Type: "array",
+		Description: fmt.Sprintf(
+			"Query several launches at once (up to %d IDs) and merge their items, e.g. to compare runs. "+
+				"page and page-size apply to each launch; the per-launch page metadata is returned under 'launches'. "+
+				"Cannot be combined with launch-id or filter-name",
+			multiLaunchItemsMaxLaunches,
+		),
+		Items:    &jsonschema.Schema{Type: "integer", Minimum: openapi.PtrFloat64(1)},
+		MaxItems: openapi.PtrInt(multiLaunchItemsMaxLaunches),
+	}
 	properties["filter-name"] = &jsonschema.Schema{
 		Type: "string",
 		Description: "Accepts either a saved filter name (string) or a numeric filterId (e.g. 197496); " +
 			"the handler resolves a saved filter name to a numeric filterId automatically. " +
 			"When set, providerType is filter. " +
-			"Conditionally required if launch-id is not provided.",
+			"Conditionally required if neither launch-id nor launch-ids is provided.",
 	}
 
 	// Add pagination parameters
-	paginationProps := utils.SetPaginationProperties(utils.DefaultSortingForItems)
+	paginationProps := utils.SetPaginationProperties(
+		lr.settings.Pagination,
+		utils.DefaultSortingForItems,
+	)
 	for k, v := range paginationProps {
 		properties[k] = v
 	}
@@ -198,7 +264,7 @@ func (lr *TestItemResources) toolGetTestItemsByFilter() (*mcp.Tool, ToolHandler[
 	}
 	properties["filter-has-compositeAttribute"] = &jsonschema.Schema{
 		Type:        "string",
-		Description: "Items have this combination of the attribute values, format: attribute1,attribute2:attribute3,... etc. string without spaces",
+		Description: "Items have this combination of the attribute values, format: comma-separated value, key:value or key: (any value of the key) segments without spaces, e.g. demo,platform:ios,build:",
 	}
 	properties["filter-has-attributeKey"] = &jsonschema.Schema{
 		Type:        "string",
@@ -212,16 +278,43 @@ func (lr *TestItemResources) toolGetTestItemsByFilter() (*mcp.Tool, ToolHandler[
 		Type:        "string",
 		Description: "Items with status",
 	}
+	neStatusEnum := make([]any, 0, len(testItemFilterStatuses))
+	for _, status := range testItemFilterStatuses {
+		neStatusEnum = append(neStatusEnum, status)
+	}
+	properties["filter-ne-status"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Maps to filter.ne.status. Exclude items with this status, e.g. PASSED to get everything that did not pass",
+		Enum:        neStatusEnum,
+	}
+	properties["filter-ne-name"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Maps to filter.ne.name. Exclude items whose name is exactly this value",
+	}
 	properties["filter-eq-hasRetries"] = &jsonschema.Schema{
 		Type:        "string",
 		Description: "Items have retries or not, can be a list of values: TRUE, FALSE, -- (default, filter is not applied)",
 		Enum:        []any{"TRUE", "FALSE", "--"},
 		Default:     mustMarshalJSON("--"),
 	}
+	properties["expand_retries"] = &jsonschema.Schema{
+		Type: "boolean",
+		Description: "Inline the retry attempts of items that have retries under each item's 'retries' key " +
+			fmt.Sprintf("(first %d such items only). ", expandRetriesMaxItems) +
+			"Combine with filter-eq-hasRetries=TRUE to list only retried items",
+		Default: mustMarshalJSON(false),
+	}
 	properties["filter-eq-parentId"] = &jsonschema.Schema{
 		Type:        "string",
 		Description: "Items parent ID equals",
 	}
+	properties[utils.IncludeLinksField] = utils.IncludeLinksSchema()
+	properties["flatten_attributes"] = &jsonschema.Schema{
+		Type: "boolean",
+		Description: "Add a 'flatAttributes' list of 'key:value' strings (just 'value' for attributes " +
+			"without a key) to each item, next to the original 'attributes' array",
+		Default: mustMarshalJSON(false),
+	}
 	properties["filter-btw-startTime-from"] = &jsonschema.Schema{
 		Type:        "string",
 		Description: "Test items with start time from timestamp (GMT timezone(UTC+00:00), RFC3339 format or Unix epoch)",
@@ -257,7 +350,7 @@ func (lr *TestItemResources) toolGetTestItemsByFilter() (*mcp.Tool, ToolHandler[
 	}
 	properties["filter-any-compositeAttribute"] = &jsonschema.Schema{
 		Type:        "string",
-		Description: "Maps to filter.any.compositeAttribute. Format: attribute1Key:attribute1Value,attribute2Key:attribute2Value, attribute3Value, e.g. demo,platform:ios,build:1.2.3.",
+		Description: "Maps to filter.any.compositeAttribute. Format: attribute1Key:attribute1Value,attribute2Key:attribute2Value,attribute3Value without spaces, e.g. demo,platform:ios,build:1.2.3",
 	}
 	properties["launches-limit"] = &jsonschema.Schema{
 		Type:        "integer",
@@ -269,34 +362,91 @@ func (lr *TestItemResources) toolGetTestItemsByFilter() (*mcp.Tool, ToolHandler[
 		Description: "Filters results to test items with this defect/issue type locator (maps to filter.eq.issueType). " +
 			"Use get_project_defect_types to retrieve the valid locator values for your project",
 	}
+	utils.SetTimeWindowProperties(properties)
+	properties[countOnlyField] = countOnlySchema("test items")
+	properties["fetch_all"] = &jsonschema.Schema{
+		Type: "boolean",
+		Description: "Read every page of the query and return all items concatenated under 'content', " +
+			"e.g. to export a full launch. page is ignored and page-size defaults to " +
+			fmt.Sprintf("%d. ", utils.MaxDefaultPageSize) +
+			"The number of pages and items is capped by the server; 'truncated' tells whether " +
+			"items were left out. Cannot be combined with launch-ids or count_only",
+		Default: mustMarshalJSON(false),
+	}
 
 	return &mcp.Tool{
 			Name:        "get_test_items_by_filter",
-			Description: "Get list of test items with optional filters. Accepts top-level query parameters launchId and filterId (not filter.eq.launchId / filter.eq.name). Either launchId (via launch-id) or filterId (via filter-name) is required; filter-name may be supplied as a saved filter name and the handler will resolve it to a numeric filterId. Optional filter-eq-defect-type narrows items by defect/issue type.",
+			Description: "Get list of test items with optional filters. Accepts top-level query parameters launchId and filterId (not filter.eq.launchId / filter.eq.name). Either launchId (via launch-id, or several via launch-ids) or filterId (via filter-name) is required; filter-name may be supplied as a saved filter name and the handler will resolve it to a numeric filterId. Optional filter-eq-defect-type narrows items by defect/issue type.",
 			InputSchema: &jsonschema.Schema{
 				Type:       "object",
 				Properties: properties,
 				Required:   nil,
 			},
 		}, utils.WithAnalytics(lr.analytics, "get_test_items_by_filter", func(ctx context.Context, request *mcp.CallToolRequest, args GetTestItemsByFilterArgs) (*mcp.CallToolResult, any, error) {
-			slog.Debug("START PROCESSING")
+			slog.DebugContext(ctx, "START PROCESSING")
 			project, err := utils.ExtractProject(ctx, args.ProjectKey)
 			if err != nil {
 				return nil, nil, err
 			}
 
-			if args.LaunchID == 0 && strings.TrimSpace(args.FilterName) == "" {
+			if err := utils.ValidateCompositeAttribute(
+				"filter-has-compositeAttribute",
+				args.FilterHasCompositeAttribute,
+			); err != nil {
+				return nil, nil, err
+			}
+			if err := utils.ValidateCompositeAttribute(
+				"filter-any-compositeAttribute",
+				args.FilterAnyCompositeAttribute,
+			); err != nil {
+				return nil, nil, err
+			}
+
+			hasFilterName := strings.TrimSpace(args.FilterName) != ""
+			if len(args.LaunchIDs) > 0 {
+				if args.LaunchID != 0 || hasFilterName {
+					return nil, nil, fmt.Errorf(
+						"launch-ids cannot be combined with launch-id or filter-name",
+					)
+				}
+				if len(args.LaunchIDs) > multiLaunchItemsMaxLaunches {
+					return nil, nil, fmt.Errorf(
+						"launch-ids accepts at most %d launch IDs, got %d",
+						multiLaunchItemsMaxLaunches,
+						len(args.LaunchIDs),
+					)
+				}
+				for _, launchID := range args.LaunchIDs {
+					if launchID <= 0 {
+						return nil, nil, fmt.Errorf(
+							"launch-ids must contain positive launch IDs, got %d",
+							launchID,
+						)
+					}
+				}
+			} else if args.LaunchID == 0 && !hasFilterName {
 				return nil, nil, fmt.Errorf(
-					"either launch-id or filter-name is required",
+					"either launch-id, launch-ids or filter-name is required",
 				)
-			} else if args.LaunchID != 0 && strings.TrimSpace(args.FilterName) != "" {
+			} else if args.LaunchID != 0 && hasFilterName {
 				return nil, nil, fmt.Errorf(
 					"provide either launch-id or filter-name, not both",
 				)
 			}
+			if args.FetchAll && (len(args.LaunchIDs) > 0 || args.CountOnly) {
+				return nil, nil, fmt.Errorf(
+					"fetch_all cannot be combined with launch-ids or count_only",
+				)
+			}
 			if args.LaunchID < 0 {
 				return nil, nil, fmt.Errorf("launch-id must be non-negative, got %d", args.LaunchID)
 			}
+			defectType := strings.TrimSpace(args.FilterEqDefectType)
+			if args.FilterEqDefectType != "" && defectType == "" {
+				return nil, nil, fmt.Errorf(
+					"filter-eq-defect-type must be a non-empty defect type locator (see get_project_defect_types)",
+				)
+			}
 
 			filterInType := utils.DefaultFilterInType
 			if args.IncludeBeforeAfterHooks != nil && *args.IncludeBeforeAfterHooks {
@@ -314,7 +464,7 @@ func (lr *TestItemResources) toolGetTestItemsByFilter() (*mcp.Tool, ToolHandler[
 
 			providerType := utils.DefaultProviderType
 			var resolvedFilterID string
-			if strings.TrimSpace(args.FilterName) != "" {
+			if hasFilterName {
 				providerType = utils.FilterProviderType
 				resolvedFilterID, err = lr.resolveFilterIDForProvider(ctx, project, args.FilterName)
 				if err != nil {
@@ -326,9 +476,6 @@ func (lr *TestItemResources) toolGetTestItemsByFilter() (*mcp.Tool, ToolHandler[
 					launchesLimit = utils.DefaultLaunchesLimitForFilterProvider
 				}
 				urlValues.Add("launchesLimit", strconv.FormatUint(uint64(launchesLimit), 10))
-			} else if args.LaunchID != 0 {
-				// Launch provider expects top-level query param launchId (same as get_test_suites_by_filter); Params() only adds params[launchId].
-				urlValues.Add("launchId", strconv.FormatInt(int64(args.LaunchID), 10))
 			}
 
 			urlValues.Add("providerType", providerType)
@@ -343,6 +490,24 @@ func (lr *TestItemResources) toolGetTestItemsByFilter() (*mcp.Tool, ToolHandler[
 			if args.FilterInStatus != "" {
 				urlValues.Add("filter.in.status", args.FilterInStatus)
 			}
+			if args.FilterNeStatus != "" {
+				neStatus := strings.ToUpper(strings.TrimSpace(args.FilterNeStatus))
+				if !slices.Contains(testItemFilterStatuses, neStatus) {
+					return nil, nil, fmt.Errorf(
+						"invalid filter-ne-status %q: must be one of %s",
+						args.FilterNeStatus,
+						strings.Join(testItemFilterStatuses, ", "),
+					)
+				}
+				urlValues.Add("filter.ne.status", neStatus)
+			}
+			if args.FilterNeName != "" {
+				neName := strings.TrimSpace(args.FilterNeName)
+				if neName == "" {
+					return nil, nil, fmt.Errorf("filter-ne-name must not be blank")
+				}
+				urlValues.Add("filter.ne.name", neName)
+			}
 			if args.FilterEqParentId != "" {
 				_, err := strconv.ParseUint(args.FilterEqParentId, 10, 64)
 				if err != nil {
@@ -363,9 +528,11 @@ func (lr *TestItemResources) toolGetTestItemsByFilter() (*mcp.Tool, ToolHandler[
 				urlValues.Add("filter.any.patternName", args.FilterAnyPatternName)
 			}
 
-			filterStartTime, err := utils.ProcessStartTimeFilter(
+			filterStartTime, err := utils.ProcessStartTimeWindow(
 				args.FilterBtwStartTimeFrom,
 				args.FilterBtwStartTimeTo,
+				args.LastHours,
+				args.LastDays,
 			)
 			if err != nil {
 				return nil, nil, err
@@ -380,62 +547,461 @@ func (lr *TestItemResources) toolGetTestItemsByFilter() (*mcp.Tool, ToolHandler[
 				)
 			}
 
-			ctxWithParams := utils.WithQueryParams(ctx, urlValues)
-			// Prepare "requiredUrlParams" for the API request because the ReportPortal API v2 expects them in a specific format
-			requiredUrlParams := map[string]string{}
-			if strings.TrimSpace(args.FilterName) == "" {
-				requiredUrlParams["launchId"] = strconv.FormatInt(int64(args.LaunchID), 10)
-			}
-			// Build the API request with filters
-			apiRequest := lr.client.TestItemAPI.GetTestItemsV2(ctxWithParams, project).
-				Params(requiredUrlParams)
-
-			// Apply pagination parameters
-			apiRequest = utils.ApplyPaginationOptions(
-				apiRequest,
-				args.Page,
-				args.PageSize,
-				args.PageSort,
-				utils.DefaultSortingForItems,
-			)
-
 			// Process attribute keys and combine with composite attributes
 			filterAttributes := utils.ProcessAttributeKeys(
 				args.FilterHasCompositeAttribute,
 				args.FilterHasAttributeKey,
 			)
-			if filterAttributes != "" {
-				apiRequest = apiRequest.FilterHasCompositeAttribute(filterAttributes)
+
+			// Only the page metadata is needed to count the items
+			page, pageSize := args.Page, args.PageSize
+			if args.CountOnly {
+				page, pageSize = utils.FirstPage, 1
+			} else if args.FetchAll && pageSize == 0 {
+				pageSize = utils.MaxDefaultPageSize
 			}
-			if args.FilterEqHasRetries != "--" {
-				apiRequest = apiRequest.FilterEqHasRetries(args.FilterEqHasRetries == "TRUE")
+
+			// queryItemsPage runs the filtered query for the given page, for one launch when
+			// launchID is set
+			queryItemsPage := func(launchID int32, page uint) (*http.Response, error) {
+				queryValues := maps.Clone(urlValues)
+				// Prepare "requiredUrlParams" for the API request because the ReportPortal API v2 expects them in a specific format
+				requiredUrlParams := map[string]string{}
+				if launchID != 0 {
+					// Launch provider expects top-level query param launchId (same as get_test_suites_by_filter); Params() only adds params[launchId].
+					queryValues.Add("launchId", strconv.FormatInt(int64(launchID), 10))
+					requiredUrlParams["launchId"] = strconv.FormatInt(int64(launchID), 10)
+				}
+				// Build the API request with filters
+				apiRequest := lr.client.TestItemAPI.GetTestItemsV2(
+					utils.WithQueryParams(ctx, queryValues),
+					project,
+				).Params(requiredUrlParams)
+
+				// Apply pagination parameters
+				apiRequest, err := utils.ApplyPaginationOptions(
+					apiRequest,
+					lr.settings.Pagination,
+					page,
+					pageSize,
+					args.PageSort,
+					utils.DefaultSortingForItems,
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				if filterAttributes != "" {
+					apiRequest = apiRequest.FilterHasCompositeAttribute(filterAttributes)
+				}
+				if args.FilterEqHasRetries != "--" {
+					apiRequest = apiRequest.FilterEqHasRetries(args.FilterEqHasRetries == "TRUE")
+				}
+				if args.FilterEqAutoAnalyzed != nil {
+					apiRequest = apiRequest.FilterEqAutoAnalyzed(*args.FilterEqAutoAnalyzed)
+				}
+				if defectType != "" {
+					apiRequest = apiRequest.FilterEqIssueType(defectType)
+				}
+
+				// Execute the request
+				_, response, err := apiRequest.Execute()
+				if err != nil {
+					return nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+				return response, nil
 			}
-			if args.FilterEqAutoAnalyzed != nil {
-				apiRequest = apiRequest.FilterEqAutoAnalyzed(*args.FilterEqAutoAnalyzed)
+			queryItems := func(launchID int32) (*http.Response, error) {
+				return queryItemsPage(launchID, page)
 			}
-			if defectType := strings.TrimSpace(args.FilterEqDefectType); defectType != "" {
-				apiRequest = apiRequest.FilterEqIssueType(defectType)
+
+			if args.CountOnly {
+				launchIDs := args.LaunchIDs
+				if len(launchIDs) == 0 {
+					launchIDs = []int32{args.LaunchID}
+				}
+				total, err := countLaunchItems(ctx, launchIDs, queryItems)
+				if err != nil {
+					return nil, nil, err
+				}
+				return countOnlyResult(total), nil, nil
 			}
 
-			// Execute the request
-			_, response, err := apiRequest.Execute()
-			if err != nil {
-				return nil, nil, fmt.Errorf(
-					"%s: %w",
-					utils.ExtractResponseError(err, response),
-					err,
+			var rawBody []byte
+			if len(args.LaunchIDs) > 0 {
+				rawBody, err = mergeLaunchItemPages(ctx, args.LaunchIDs, queryItems)
+				if err != nil {
+					return nil, nil, err
+				}
+			} else if args.FetchAll {
+				rawBody, err = fetchAllItemPages(
+					ctx,
+					lr.settings.FetchAll.MaxPages(),
+					lr.settings.FetchAll.MaxTotalResults(),
+					func(page uint) (*http.Response, error) {
+						return queryItemsPage(args.LaunchID, page)
+					},
 				)
+				if err != nil {
+					return nil, nil, err
+				}
+			} else {
+				response, err := queryItems(args.LaunchID)
+				if err != nil {
+					return nil, nil, err
+				}
+				if !args.ExpandRetries && !args.IncludeLinks && !args.FlattenAttributes {
+					// Return the serialized launches as a text result
+					return utils.ReadResponseBody(response)
+				}
+				rawBody, err = utils.ReadResponseBodyRaw(response)
+				if err != nil {
+					return nil, nil, err
+				}
 			}
 
-			// Return the serialized launches as a text result
-			return utils.ReadResponseBody(response)
+			if args.ExpandRetries {
+				rawBody, err = lr.expandItemRetries(
+					ctx,
+					project,
+					rawBody,
+					args.FilterEqHasRetries == "TRUE",
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			if args.FlattenAttributes {
+				rawBody, err = flattenItemAttributes(rawBody)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			rawBody, err = lr.withItemLinks(rawBody, project, args.IncludeLinks)
+			if err != nil {
+				return nil, nil, err
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(rawBody)}},
+			}, nil, nil
 		})
 }
 
+// fetchAllItemPages reads consecutive test item pages with queryPage, starting with the first,
+// and concatenates their items under "content". It stops after the last page, after maxPages
+// pages or once maxResults items are collected, flagging the result as "truncated" when items
+// are left out. Cancelling ctx aborts the loop between pages.
+func fetchAllItemPages(
+	ctx context.Context,
+	maxPages, maxResults int,
+	queryPage func(page uint) (*http.Response, error),
+) ([]byte, error) {
+	content := []any{}
+	var totalElements int64
+	pagesFetched := 0
+	truncated := false
+	for page := uint(utils.FirstPage); ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf(
+				"fetching test items stopped after %d pages: %w",
+				pagesFetched,
+				err,
+			)
+		}
+		if pagesFetched >= maxPages {
+			truncated = true
+			break
+		}
+		response, err := queryPage(page)
+		if err != nil {
+			return nil, err
+		}
+		rawPage, err := utils.ReadResponseBodyRaw(response)
+		if err != nil {
+			return nil, err
+		}
+		// Decode generically so that fields unknown to the client models are passed through as-is
+		decoder := json.NewDecoder(bytes.NewReader(rawPage))
+		decoder.UseNumber()
+		var itemsPage struct {
+			Content []any `json:"content"`
+			Page    *struct {
+				TotalElements int64 `json:"totalElements"`
+				TotalPages    int64 `json:"totalPages"`
+			} `json:"page"`
+		}
+		if err := decoder.Decode(&itemsPage); err != nil {
+			return nil, fmt.Errorf("failed to parse test items: %w", err)
+		}
+		pagesFetched++
+		if itemsPage.Page != nil {
+			totalElements = itemsPage.Page.TotalElements
+		}
+
+		if remaining := maxResults - len(content); len(itemsPage.Content) > remaining {
+			content = append(content, itemsPage.Content[:remaining]...)
+			truncated = true
+			break
+		}
+		content = append(content, itemsPage.Content...)
+		if itemsPage.Page == nil || len(itemsPage.Content) == 0 ||
+			int64(page) >= itemsPage.Page.TotalPages {
+			break
+		}
+	}
+	if int64(len(content)) > totalElements {
+		totalElements = int64(len(content))
+	}
+
+	return json.Marshal(map[string]any{
+		"content": content,
+		"page": map[string]any{
+			"totalElements": totalElements,
+			"pagesFetched":  pagesFetched,
+		},
+		"truncated": truncated,
+	})
+}
+
+// countLaunchItems sums the totalElements of the item pages queried for each launch ID; launch ID
+// 0 stands for the query without a launch (saved filter provider). Unlike mergeLaunchItemPages any
+// failed query fails the count, since a partial total would be misleading.
+func countLaunchItems(
+	ctx context.Context,
+	launchIDs []int32,
+	queryItems func(launchID int32) (*http.Response, error),
+) (int64, error) {
+	totals := make([]int64, len(launchIDs))
+	errs := forEachBounded(ctx, len(launchIDs), multiLaunchItemsConcurrency, func(i int) error {
+		response, err := queryItems(launchIDs[i])
+		if err != nil {
+			return err
+		}
+		rawPage, err := utils.ReadResponseBodyRaw(response)
+		if err != nil {
+			return err
+		}
+		var itemsPage struct {
+			Page *struct {
+				TotalElements int64 `json:"totalElements"`
+			} `json:"page"`
+		}
+		if err := json.Unmarshal(rawPage, &itemsPage); err != nil {
+			return fmt.Errorf("failed to parse test items: %w", err)
+		}
+		if itemsPage.Page == nil {
+			return fmt.Errorf("test items response has no page metadata")
+		}
+		totals[i] = itemsPage.Page.TotalElements
+		return nil
+	})
+	if err := errors.Join(errs...); err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, n := range totals {
+		total += n
+	}
+	return total, nil
+}
+
+// flattenItemAttributes adds a flatAttributes list of "key:value" strings (just "value" for
+// attributes without a key) to every item of a test item page, keeping the attributes array as-is
+func flattenItemAttributes(rawPage []byte) ([]byte, error) {
+	// Decode generically so that fields unknown to the client models are passed through as-is
+	decoder := json.NewDecoder(bytes.NewReader(rawPage))
+	decoder.UseNumber()
+	var page map[string]any
+	if err := decoder.Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to parse test items: %w", err)
+	}
+
+	content, _ := page["content"].([]any)
+	for _, entry := range content {
+		item, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		attributes, _ := item["attributes"].([]any)
+		flat := make([]string, 0, len(attributes))
+		for _, attr := range attributes {
+			attribute, ok := attr.(map[string]any)
+			if !ok {
+				continue
+			}
+			key, _ := attribute["key"].(string)
+			value, _ := attribute["value"].(string)
+			if key == "" {
+				flat = append(flat, value)
+			} else {
+				flat = append(flat, key+":"+value)
+			}
+		}
+		item["flatAttributes"] = flat
+	}
+
+	return json.Marshal(page)
+}
+
+// withItemLinks adds a webUrl field to the test item, or every item of the page, in rawBody
+// when includeLinks is set
+func (lr *TestItemResources) withItemLinks(
+	rawBody []byte,
+	project string,
+	includeLinks bool,
+) ([]byte, error) {
+	if !includeLinks {
+		return rawBody, nil
+	}
+	links := utils.NewWebLinks(lr.client.APIClient.GetConfig(), project, lr.settings.UIPaths)
+	return links.AddItemURLs(rawBody)
+}
+
+// mergeLaunchItemPages runs queryItems for each launch with bounded parallelism and merges the
+// returned pages: items are concatenated in launch order under "content", and each launch's
+// page metadata (or the error of its query) is listed under "launches". It fails only when
+// every launch query fails.
+func mergeLaunchItemPages(
+	ctx context.Context,
+	launchIDs []int32,
+	queryItems func(launchID int32) (*http.Response, error),
+) ([]byte, error) {
+	pages := make([]map[string]any, len(launchIDs))
+	errs := forEachBounded(ctx, len(launchIDs), multiLaunchItemsConcurrency, func(i int) error {
+		response, err := queryItems(launchIDs[i])
+		if err != nil {
+			return err
+		}
+		rawPage, err := utils.ReadResponseBodyRaw(response)
+		if err != nil {
+			return err
+		}
+		// Decode generically so that fields unknown to the client models are passed through as-is
+		decoder := json.NewDecoder(bytes.NewReader(rawPage))
+		decoder.UseNumber()
+		if err := decoder.Decode(&pages[i]); err != nil {
+			return fmt.Errorf("failed to parse test items: %w", err)
+		}
+		return nil
+	})
+
+	content := []any{}
+	launches := make([]map[string]any, 0, len(launchIDs))
+	failed := 0
+	for i, launchID := range launchIDs {
+		launch := map[string]any{"launch_id": launchID}
+		if errs[i] != nil {
+			failed++
+			launch["error"] = errs[i].Error()
+		} else {
+			items, _ := pages[i]["content"].([]any)
+			content = append(content, items...)
+			launch["page"] = pages[i]["page"]
+		}
+		launches = append(launches, launch)
+	}
+	if failed == len(launchIDs) {
+		return nil, fmt.Errorf("failed to get test items for all launches: %w", errors.Join(errs...))
+	}
+
+	return json.Marshal(map[string]any{
+		"content":  content,
+		"launches": launches,
+	})
+}
+
+// expandItemRetries inlines the retry attempts of the items in a test item page under their
+// "retries" key. Items are expanded when flagged with hasRetries, or all of them when the page
+// was already filtered by hasRetries=TRUE, up to expandRetriesMaxItems items.
+func (lr *TestItemResources) expandItemRetries(
+	ctx context.Context,
+	project string,
+	rawPage []byte,
+	allHaveRetries bool,
+) ([]byte, error) {
+	// Decode generically so that fields unknown to the client models are passed through as-is
+	decoder := json.NewDecoder(bytes.NewReader(rawPage))
+	decoder.UseNumber()
+	var page map[string]any
+	if err := decoder.Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to parse test items: %w", err)
+	}
+
+	content, _ := page["content"].([]any)
+	var toExpand []map[string]any
+	flagged := 0
+	for _, entry := range content {
+		item, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasRetries, _ := item["hasRetries"].(bool); !hasRetries && !allHaveRetries {
+			continue
+		}
+		if uuid, _ := item["uuid"].(string); uuid == "" {
+			continue
+		}
+		flagged++
+		if len(toExpand) < expandRetriesMaxItems {
+			toExpand = append(toExpand, item)
+		}
+	}
+
+	errs := forEachBounded(ctx, len(toExpand), expandRetriesConcurrency, func(i int) error {
+		uuid, _ := toExpand[i]["uuid"].(string)
+		retries, err := lr.fetchItemRetries(ctx, project, uuid)
+		if err != nil {
+			return err
+		}
+		toExpand[i]["retries"] = retries
+		return nil
+	})
+	for i, err := range errs {
+		if err != nil {
+			toExpand[i]["retries_error"] = err.Error()
+		}
+	}
+	if flagged > len(toExpand) {
+		page["retries_message"] = fmt.Sprintf(
+			"retries expanded for the first %d of %d items with retries",
+			len(toExpand),
+			flagged,
+		)
+	}
+
+	return json.Marshal(page)
+}
+
+// fetchItemRetries returns the retry attempts of the test item with the given UUID, never nil
+func (lr *TestItemResources) fetchItemRetries(
+	ctx context.Context,
+	project, uuid string,
+) ([]openapi.ComEpamReportportalBaseReportingTestItemResource, error) {
+	withRetries, response, err := lr.client.TestItemAPI.GetTestItemByUuidTimestamp(
+		ctx,
+		uuid,
+		project,
+	).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+	}
+	retries := withRetries.GetRetries()
+	if retries == nil {
+		retries = []openapi.ComEpamReportportalBaseReportingTestItemResource{}
+	}
+	return retries, nil
+}
+
 // GetTestItemByIdArgs holds params for get_test_item_by_id.
 type GetTestItemByIdArgs struct {
-	ProjectKey string `json:"projectKey"`
-	TestItemID string `json:"test_item_id"`
+	ProjectKey   string `json:"projectKey"`
+	TestItemID   string `json:"test_item_id"`
+	IncludeLinks bool   `json:"include_links"`
 }
 
 // toolGetTestItemById creates a tool to retrieve a test item by its ID.
@@ -450,6 +1016,7 @@ func (lr *TestItemResources) toolGetTestItemById() (*mcp.Tool, ToolHandler[GetTe
 		Type:        "string",
 		Description: "Test Item ID",
 	}
+	properties[utils.IncludeLinksField] = utils.IncludeLinksSchema()
 
 	return &mcp.Tool{
 			Name:        "get_test_item_by_id",
@@ -480,37 +1047,337 @@ func (lr *TestItemResources) toolGetTestItemById() (*mcp.Tool, ToolHandler[GetTe
 				)
 			}
 
-			// Return the serialized testItem as a text result
-			return utils.ReadResponseBody(response)
+			if !args.IncludeLinks {
+				// Return the serialized testItem as a text result
+				return utils.ReadResponseBody(response)
+			}
+			rawBody, err := utils.ReadResponseBodyRaw(response)
+			if err != nil {
+				return nil, nil, err
+			}
+			rawBody, err = lr.withItemLinks(rawBody, project, true)
+			if err != nil {
+				return nil, nil, err
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(rawBody)}},
+			}, nil, nil
 		})
 }
 
-// resourceTestItem creates a resource template for accessing test items by URI.
-func (lr *TestItemResources) resourceTestItem() (*mcp.ResourceTemplate, mcp.ResourceHandler) {
-	return &mcp.ResourceTemplate{
-			Name:        "reportportal-test-item-by-id",
-			Description: "Access ReportPortal test items by URI (reportportal://{projectKey}/testitem/{testItemId})",
-			MIMEType:    "application/json",
-			URITemplate: "reportportal://{projectKey}/testitem/{testItemId}",
-		}, func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
-			// Parse the URI to extract parameters
-			uri := request.Params.URI
-			project, testItemId, err := parseTestItemURI(uri)
-			if err != nil {
-				return nil, err
-			}
+// GetTestItemParametersArgs holds params for get_test_item_parameters.
+type GetTestItemParametersArgs struct {
+	ProjectKey string `json:"projectKey"`
+	TestItemID int64  `json:"test_item_id"`
+}
 
-			// Fetch the test item from ReportPortal
-			testItem, _, err := lr.client.TestItemAPI.GetTestItem(ctx, testItemId, project).
-				Execute()
+// toolGetTestItemParameters creates a tool that returns only the parameters of a test item.
+func (lr *TestItemResources) toolGetTestItemParameters() (*mcp.Tool, ToolHandler[GetTestItemParametersArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_test_item_parameters",
+			Description: "Get only the parameters (key/value pairs) of a data-driven test item, " +
+				"as an array. Returns an empty array when the item has no parameters. " +
+				"Use get_test_item_by_id for the full item",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"test_item_id": {
+						Type:        "integer",
+						Description: "Test item ID",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+				},
+				Required: []string{"test_item_id"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "get_test_item_parameters", func(ctx context.Context, request *mcp.CallToolRequest, args GetTestItemParametersArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get test item: %w", err)
+				return nil, nil, err
 			}
 
-			// Marshal the test item to JSON
-			testItemPayload, err := json.Marshal(testItem)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			if args.TestItemID <= 0 {
+				return nil, nil, fmt.Errorf("test_item_id is required")
+			}
+
+			item, response, err := lr.client.TestItemAPI.GetTestItem(
+				ctx,
+				strconv.FormatInt(args.TestItemID, 10),
+				project,
+			).Execute()
+			if err != nil {
+				return nil, nil, fmt.Errorf(
+					"%s: %w",
+					utils.ExtractResponseError(err, response),
+					err,
+				)
+			}
+
+			parameters := item.GetParameters()
+			if parameters == nil {
+				parameters = []openapi.ComEpamReportportalBaseReportingParameterResource{}
+			}
+
+			r, err := json.Marshal(parameters)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
+
+// GetTestItemIssueArgs holds params for get_test_item_issue.
+type GetTestItemIssueArgs struct {
+	ProjectKey string `json:"projectKey"`
+	TestItemID int64  `json:"test_item_id"`
+}
+
+// toolGetTestItemIssue creates a tool that returns only the issue (defect) of a test item.
+func (lr *TestItemResources) toolGetTestItemIssue() (*mcp.Tool, ToolHandler[GetTestItemIssueArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_test_item_issue",
+			Description: "Get only the issue (defect) of a test item: issue type, comment, autoAnalyzed, " +
+				"ignoreAnalyzer and linked external system issues. Fails when the item has no issue. " +
+				"Use get_test_item_by_id for the full item",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"test_item_id": {
+						Type:        "integer",
+						Description: "Test item ID",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+				},
+				Required: []string{"test_item_id"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "get_test_item_issue", func(ctx context.Context, request *mcp.CallToolRequest, args GetTestItemIssueArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if args.TestItemID <= 0 {
+				return nil, nil, fmt.Errorf("test_item_id is required")
+			}
+
+			item, response, err := lr.client.TestItemAPI.GetTestItem(
+				ctx,
+				strconv.FormatInt(args.TestItemID, 10),
+				project,
+			).Execute()
+			if err != nil {
+				return nil, nil, fmt.Errorf(
+					"%s: %w",
+					utils.ExtractResponseError(err, response),
+					err,
+				)
+			}
+
+			if item.Issue == nil {
+				return nil, nil, fmt.Errorf("test item %d has no issue", args.TestItemID)
+			}
+
+			r, err := json.Marshal(item.Issue)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
+
+const (
+	// codeRefScanLaunches is the number of latest launches searched when no launch_id is given
+	codeRefScanLaunches = 10
+	// codeRefItemsPageSize caps the test items returned per launch by get_items_by_code_ref
+	codeRefItemsPageSize = 50
+)
+
+// GetItemsByCodeRefArgs holds params for get_items_by_code_ref.
+type GetItemsByCodeRefArgs struct {
+	ProjectKey string `json:"projectKey"`
+	CodeRef    string `json:"code_ref"`
+	LaunchID   int32  `json:"launch_id"`
+	Exact      bool   `json:"exact"`
+}
+
+// toolGetItemsByCodeRef creates a tool that finds test items by their code reference,
+// e.g. to link a test source location to its results.
+func (lr *TestItemResources) toolGetItemsByCodeRef() (*mcp.Tool, ToolHandler[GetItemsByCodeRefArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_items_by_code_ref",
+			Description: "Find test items by their code reference (codeRef, e.g. the test class and method " +
+				"or source path reported by the agent). Matches items whose codeRef contains code_ref, " +
+				"or equals it with exact. Searches the given launch, or the latest " +
+				fmt.Sprintf(
+					"%d launches without launch_id, returning up to %d items per launch under content ",
+					codeRefScanLaunches,
+					codeRefItemsPageSize,
+				) +
+				"and each launch's page metadata under launches",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"code_ref": {
+						Type:        "string",
+						Description: "Code reference of the test (or a part of it), e.g. com.example.LoginTest.testLogin",
+					},
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch to search (default: the latest launches of the project)",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+					"exact": {
+						Type:        "boolean",
+						Description: "Match the code reference exactly instead of as a substring",
+						Default:     mustMarshalJSON(false),
+					},
+				},
+				Required: []string{"code_ref"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "get_items_by_code_ref", func(ctx context.Context, request *mcp.CallToolRequest, args GetItemsByCodeRefArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			codeRef := strings.TrimSpace(args.CodeRef)
+			if codeRef == "" {
+				return nil, nil, fmt.Errorf("code_ref is required")
+			}
+			if args.LaunchID < 0 {
+				return nil, nil, fmt.Errorf("launch_id must be positive, got %d", args.LaunchID)
+			}
+			codeRefFilter := "filter.cnt.codeRef"
+			if args.Exact {
+				codeRefFilter = "filter.eq.codeRef"
+			}
+
+			// Launches to search, newest first
+			var launchIDs []int32
+			if args.LaunchID > 0 {
+				launchIDs = append(launchIDs, args.LaunchID)
+			} else {
+				apiRequest, err := utils.ApplyPaginationOptions(
+					lr.client.LaunchAPI.GetProjectLaunches(ctx, project),
+					lr.settings.Pagination,
+					utils.FirstPage,
+					codeRefScanLaunches,
+					utils.DefaultSortingForLaunches,
+					utils.DefaultSortingForLaunches,
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+				latest, response, err := apiRequest.Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+				for _, launch := range latest.Content {
+					launchIDs = append(launchIDs, int32(launch.Id)) //nolint:gosec // launch IDs fit into int32 on the RP side
+				}
+			}
+
+			var rawBody []byte
+			if len(launchIDs) == 0 {
+				rawBody, err = json.Marshal(map[string]any{
+					"content":  []any{},
+					"launches": []any{},
+					"message":  "no launches found in the project",
+				})
+			} else {
+				rawBody, err = mergeLaunchItemPages(ctx, launchIDs, func(launchID int32) (*http.Response, error) {
+					launchIDStr := strconv.FormatInt(int64(launchID), 10)
+					ctxWithParams := utils.WithQueryParams(ctx, url.Values{
+						"launchId":     {launchIDStr},
+						"providerType": {utils.DefaultProviderType},
+						codeRefFilter:  {codeRef},
+					})
+					apiRequest, err := utils.ApplyPaginationOptions(
+						lr.client.TestItemAPI.GetTestItemsV2(ctxWithParams, project).
+							Params(map[string]string{"launchId": launchIDStr}),
+						lr.settings.Pagination,
+						utils.FirstPage,
+						codeRefItemsPageSize,
+						utils.DefaultSortingForItems,
+						utils.DefaultSortingForItems,
+					)
+					if err != nil {
+						return nil, err
+					}
+					_, response, err := apiRequest.Execute()
+					if err != nil {
+						return nil, fmt.Errorf(
+							"%s: %w",
+							utils.ExtractResponseError(err, response),
+							err,
+						)
+					}
+					return response, nil
+				})
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(rawBody)}},
+			}, nil, nil
+		})
+}
+
+// resourceTestItem creates a resource template for accessing test items by URI.
+func (lr *TestItemResources) resourceTestItem() (*mcp.ResourceTemplate, mcp.ResourceHandler) {
+	return &mcp.ResourceTemplate{
+			Name:        "reportportal-test-item-by-id",
+			Description: "Access ReportPortal test items by URI (reportportal://{projectKey}/testitem/{testItemId})",
+			MIMEType:    "application/json",
+			URITemplate: "reportportal://{projectKey}/testitem/{testItemId}",
+		}, func(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			// Parse the URI to extract parameters
+			uri := request.Params.URI
+			project, testItemId, err := parseTestItemURI(uri)
+			if err != nil {
+				return nil, err
+			}
+
+			// Fetch the test item from ReportPortal
+			testItem, _, err := lr.client.TestItemAPI.GetTestItem(ctx, testItemId, project).
+				Execute()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get test item: %w", err)
+			}
+
+			// Marshal the test item to JSON
+			testItemPayload, err := json.Marshal(testItem)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
 			// Return the resource contents
@@ -626,6 +1493,212 @@ func (lr *TestItemResources) toolGetTestItemAttachment() (*mcp.Tool, ToolHandler
 		})
 }
 
+const (
+	// itemAttachmentsPageSize is the page size used to scan the logs of a test item for attachments
+	itemAttachmentsPageSize = 100
+	// itemAttachmentsMaxPages caps the log pages scanned by list_test_item_attachments
+	itemAttachmentsMaxPages = 20
+	// itemAttachmentsMaxAttachments caps the attachments listed, and so the size lookups issued,
+	// by list_test_item_attachments
+	itemAttachmentsMaxAttachments = 100
+	// itemAttachmentsSizeConcurrency bounds the parallel attachment size lookups
+	itemAttachmentsSizeConcurrency = 5
+)
+
+// ListTestItemAttachmentsArgs holds params for list_test_item_attachments.
+type ListTestItemAttachmentsArgs struct {
+	ProjectKey string `json:"projectKey"`
+	TestItemID int64  `json:"test_item_id"`
+}
+
+// itemAttachment is one attachment of a test item as listed by list_test_item_attachments
+type itemAttachment struct {
+	AttachmentID string     `json:"attachment_id"`
+	ContentType  string     `json:"content_type"`
+	FileName     string     `json:"file_name,omitempty"`
+	Size         *int64     `json:"size,omitempty"`
+	LogID        int64      `json:"log_id"`
+	LogTime      *time.Time `json:"log_time,omitempty"`
+	LogLevel     string     `json:"log_level,omitempty"`
+	LogMessage   string     `json:"log_message,omitempty"`
+	Error        string     `json:"error,omitempty"` // set when the size of the attachment could not be read
+}
+
+// toolListTestItemAttachments creates a tool that lists the attachments of a test item's logs,
+// so that each of them can be fetched with get_test_item_attachment_by_id
+func (lr *TestItemResources) toolListTestItemAttachments() (*mcp.Tool, ToolHandler[ListTestItemAttachmentsArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "list_test_item_attachments",
+			Description: "List the attachments of a test item: scans its logs for binary content and returns " +
+				"the attachment ID, content type and size of each one. Use get_test_item_attachment_by_id " +
+				"with an attachment_id to fetch the attachment itself. At most " +
+				strconv.Itoa(itemAttachmentsMaxAttachments) + " attachments are listed",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"test_item_id": {
+						Type:        "integer",
+						Description: "Test item ID",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+				},
+				Required: []string{"test_item_id"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "list_test_item_attachments", func(ctx context.Context, request *mcp.CallToolRequest, args ListTestItemAttachmentsArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if args.TestItemID <= 0 {
+				return nil, nil, fmt.Errorf("test_item_id is required")
+			}
+
+			ctxWithParams := utils.WithQueryParams(ctx, url.Values{
+				"filter.ex.binaryContent": {"true"},
+			})
+			attachments := make([]itemAttachment, 0)
+			truncatedMessage := ""
+			for page := uint(utils.FirstPage); truncatedMessage == ""; page++ {
+				if page > itemAttachmentsMaxPages {
+					truncatedMessage = fmt.Sprintf(
+						"only the first %d logs of the test item were scanned for attachments",
+						itemAttachmentsMaxPages*itemAttachmentsPageSize,
+					)
+					break
+				}
+				apiRequest, err := utils.ApplyPaginationOptions(
+					lr.client.LogAPI.GetLogs(ctxWithParams, project).
+						FilterEqItem(int32(args.TestItemID)), //nolint:gosec // item IDs fit into int32 on the RP side
+					lr.settings.Pagination,
+					page,
+					itemAttachmentsPageSize,
+					utils.DefaultSortingForLogs,
+					utils.DefaultSortingForLogs,
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+				logs, response, err := apiRequest.Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				for _, logEntry := range logs.Content {
+					// The binaryContent filter is applied server side; logs without it are skipped anyway
+					if logEntry.BinaryContent == nil || logEntry.BinaryContent.Id == "" {
+						continue
+					}
+					if len(attachments) == itemAttachmentsMaxAttachments {
+						truncatedMessage = fmt.Sprintf(
+							"only the first %d attachments of the test item are listed",
+							itemAttachmentsMaxAttachments,
+						)
+						break
+					}
+					attachments = append(attachments, itemAttachment{
+						AttachmentID: logEntry.BinaryContent.Id,
+						ContentType:  logEntry.BinaryContent.ContentType,
+						FileName:     logEntry.BinaryContent.GetFileName(),
+						LogID:        logEntry.Id,
+						LogTime:      logEntry.Time,
+						LogLevel:     logEntry.GetLevel(),
+						LogMessage:   logEntry.GetMessage(),
+					})
+				}
+				if len(logs.Content) == 0 || logs.Page == nil || !logs.Page.GetHasNext() {
+					break
+				}
+			}
+
+			// Log resources carry no size, so it is read from the headers of the attachment itself
+			errs := forEachBounded(ctx, len(attachments), itemAttachmentsSizeConcurrency, func(i int) error {
+				size, err := lr.attachmentSize(ctx, project, attachments[i].AttachmentID)
+				if err != nil {
+					return err
+				}
+				if size >= 0 {
+					attachments[i].Size = &size
+				}
+				return nil
+			})
+			for i, err := range errs {
+				if err != nil {
+					attachments[i].Error = err.Error()
+				}
+			}
+
+			result := map[string]any{
+				"test_item_id": args.TestItemID,
+				"attachments":  attachments,
+				"total":        len(attachments),
+			}
+			if truncatedMessage != "" {
+				result["truncated"] = true
+				result["message"] = truncatedMessage
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
+
+// attachmentSize returns the size in bytes of an attachment as reported by a HEAD request for it,
+// or -1 if the server does not report it. The attachment content itself is not downloaded.
+func (lr *TestItemResources) attachmentSize(
+	ctx context.Context,
+	project, attachmentID string,
+) (int64, error) {
+	cfg := lr.client.GetConfig()
+	attachmentURL := fmt.Sprintf(
+		"%s://%s/api/v1/data/%s/%s",
+		cfg.Scheme, cfg.Host, url.PathEscape(project), url.PathEscape(attachmentID),
+	)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodHead, attachmentURL, nil)
+	if err != nil {
+		return -1, fmt.Errorf("failed to build attachment request: %w", err)
+	}
+	for k, v := range cfg.DefaultHeader {
+		httpReq.Header.Set(k, v)
+	}
+	// Apply middleware to inject the auth token
+	if cfg.Middleware != nil {
+		cfg.Middleware(httpReq)
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: importHTTPClientTimeout}
+	}
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return -1, fmt.Errorf("attachment request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode >= 300 {
+		return -1, fmt.Errorf("attachment request failed (HTTP %d)", resp.StatusCode)
+	}
+	return resp.ContentLength, nil
+}
+
 // GetTestItemLogsByFilterArgs holds filter and pagination params for get_test_item_logs_by_filter.
 type GetTestItemLogsByFilterArgs struct {
 	ProjectKey            string `json:"projectKey"`
@@ -639,6 +1712,25 @@ type GetTestItemLogsByFilterArgs struct {
 	FilterInStatus        string `json:"filter-in-status"`
 }
 
+// logFilterValues builds the query parameters of the optional log filters. binaryContent is one of
+// TRUE, FALSE or "--" (filter not applied).
+func logFilterValues(level, message, binaryContent, status string) url.Values {
+	urlValues := url.Values{}
+	if level != "" {
+		urlValues.Add("filter.gte.level", level)
+	}
+	if message != "" {
+		urlValues.Add("filter.cnt.message", message)
+	}
+	if binaryContent != "--" {
+		urlValues.Add("filter.ex.binaryContent", strconv.FormatBool(binaryContent == "TRUE"))
+	}
+	if status != "" {
+		urlValues.Add("filter.in.status", status)
+	}
+	return urlValues
+}
+
 // toolGetTestItemLogsByFilter creates a tool to get test items logs for a specific launch.
 func (lr *TestItemResources) toolGetTestItemLogsByFilter() (*mcp.Tool, ToolHandler[GetTestItemLogsByFilterArgs, any]) {
 	properties := make(map[string]*jsonschema.Schema)
@@ -659,12 +1751,12 @@ func (lr *TestItemResources) toolGetTestItemLogsByFilter() (*mcp.Tool, ToolHandl
 	properties["page-size"] = &jsonschema.Schema{
 		Type:        "integer",
 		Description: "Page size",
-		Default:     mustMarshalJSON(utils.DefaultPageSize),
+		Default:     mustMarshalJSON(lr.settings.Pagination.PageSize()),
 	}
 	properties["page-sort"] = &jsonschema.Schema{
 		Type:        "string",
 		Description: "Sorting fields and direction",
-		Default:     mustMarshalJSON(utils.DefaultSortingForLogs),
+		Default:     mustMarshalJSON(lr.settings.Pagination.Sort(utils.DefaultSortingForLogs)),
 	}
 	properties["filter-gte-level"] = &jsonschema.Schema{
 		Type:        "string",
@@ -695,7 +1787,7 @@ func (lr *TestItemResources) toolGetTestItemLogsByFilter() (*mcp.Tool, ToolHandl
 				Required:   []string{"parent-item-id"},
 			},
 		}, utils.WithAnalytics(lr.analytics, "get_test_item_logs_by_filter", func(ctx context.Context, request *mcp.CallToolRequest, args GetTestItemLogsByFilterArgs) (*mcp.CallToolResult, any, error) {
-			slog.Debug("START PROCESSING")
+			slog.DebugContext(ctx, "START PROCESSING")
 			project, err := utils.ExtractProject(ctx, args.ProjectKey)
 			if err != nil {
 				return nil, nil, err
@@ -705,24 +1797,12 @@ func (lr *TestItemResources) toolGetTestItemLogsByFilter() (*mcp.Tool, ToolHandl
 				return nil, nil, fmt.Errorf("parent-item-id is required")
 			}
 
-			// Process optional log level filter
-			urlValues := url.Values{}
-			// Add optional filters to urlValues if they have values
-			if args.FilterGteLevel != "" {
-				urlValues.Add("filter.gte.level", args.FilterGteLevel)
-			}
-			if args.FilterCntMessage != "" {
-				urlValues.Add("filter.cnt.message", args.FilterCntMessage)
-			}
-			if args.FilterExBinaryContent != "--" {
-				urlValues.Add(
-					"filter.ex.binaryContent",
-					strconv.FormatBool(args.FilterExBinaryContent == "TRUE"),
-				)
-			}
-			if args.FilterInStatus != "" {
-				urlValues.Add("filter.in.status", args.FilterInStatus)
-			}
+			urlValues := logFilterValues(
+				args.FilterGteLevel,
+				args.FilterCntMessage,
+				args.FilterExBinaryContent,
+				args.FilterInStatus,
+			)
 			// Validate ParentItemID and convert it to int64
 			parentIdValue, err := strconv.ParseInt(args.ParentItemID, 10, 64)
 			if err != nil || parentIdValue < 0 {
@@ -739,13 +1819,17 @@ func (lr *TestItemResources) toolGetTestItemLogsByFilter() (*mcp.Tool, ToolHandl
 				Params(requiredUrlParams)
 
 			// Apply pagination parameters
-			apiRequest = utils.ApplyPaginationOptions(
+			apiRequest, err = utils.ApplyPaginationOptions(
 				apiRequest,
+				lr.settings.Pagination,
 				args.Page,
 				args.PageSize,
 				args.PageSort,
 				utils.DefaultSortingForLogs,
 			)
+			if err != nil {
+				return nil, nil, err
+			}
 
 			// Execute the request
 			_, response, err := apiRequest.Execute()
@@ -761,38 +1845,189 @@ func (lr *TestItemResources) toolGetTestItemLogsByFilter() (*mcp.Tool, ToolHandl
 		})
 }
 
-// GetTestSuitesByFilterArgs holds filter and pagination params for get_test_suites_by_filter.
-type GetTestSuitesByFilterArgs struct {
-	ProjectKey                  string `json:"projectKey"`
-	LaunchID                    uint32 `json:"launch-id"`
-	Page                        uint   `json:"page"`
-	PageSize                    uint   `json:"page-size"`
-	PageSort                    string `json:"page-sort"`
-	FilterCntName               string `json:"filter-cnt-name"`
-	FilterHasCompositeAttribute string `json:"filter-has-compositeAttribute"`
-	FilterHasAttributeKey       string `json:"filter-has-attributeKey"`
-	FilterCntDescription        string `json:"filter-cnt-description"`
-	FilterEqParentId            string `json:"filter-eq-parentId"`
-	FilterBtwStartTimeFrom      string `json:"filter-btw-startTime-from"`
-	FilterBtwStartTimeTo        string `json:"filter-btw-startTime-to"`
+const (
+	// itemLogsTextDefaultMaxLines is the default number of logs concatenated by get_item_logs_text
+	itemLogsTextDefaultMaxLines = 1000
+	// itemLogsTextMaxLines caps max_lines to keep the text within a reasonable size
+	itemLogsTextMaxLines = 10000
+	// itemLogsTextPageSize is the number of logs requested per page
+	itemLogsTextPageSize = 300
+)
+
+// GetItemLogsTextArgs holds params for get_item_logs_text.
+type GetItemLogsTextArgs struct {
+	ProjectKey       string `json:"projectKey"`
+	TestItemID       int64  `json:"test_item_id"`
+	FilterGteLevel   string `json:"filter-gte-level"`
+	FilterCntMessage string `json:"filter-cnt-message"`
+	IncludeLevel     bool   `json:"include_level"`
+	MaxLines         *int   `json:"max_lines"`
 }
 
-// toolGetTestSuitesByFilter creates a tool to get test suites for a specific launch.
-func (lr *TestItemResources) toolGetTestSuitesByFilter() (*mcp.Tool, ToolHandler[GetTestSuitesByFilterArgs, any]) {
-	properties := make(map[string]*jsonschema.Schema)
+// toolGetItemLogsText creates a tool that returns the log messages of a test item as plain text
+func (lr *TestItemResources) toolGetItemLogsText() (*mcp.Tool, ToolHandler[GetItemLogsTextArgs, any]) {
 	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
 	if err != nil {
 		slog.Error("failed to build project key schema", "error", err)
 	}
-	properties[utils.ProjectKeyField] = pkSchema
-	properties["launch-id"] = &jsonschema.Schema{
-		Type:        "integer",
-		Description: "Suites with specific Launch ID, this is a required parameter",
-	}
 
-	// Add pagination parameters
-	paginationProps := utils.SetPaginationProperties(utils.DefaultSortingForSuites)
-	for k, v := range paginationProps {
+	return &mcp.Tool{
+			Name: "get_item_logs_text",
+			Description: "Get the logs of a test item as plain text: the log messages concatenated in logTime " +
+				"order, one log per line, optionally prefixed with the log level. Cheaper to read than " +
+				"the JSON log objects of get_test_item_logs_by_filter",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"test_item_id": {
+						Type:        "integer",
+						Description: "Test item ID",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+					"filter-gte-level": {
+						Type:        "string",
+						Description: "Get logs only with this log level or higher",
+						Default:     mustMarshalJSON(utils.DefaultItemLogLevel),
+					},
+					"filter-cnt-message": {
+						Type:        "string",
+						Description: "Log should contains this substring",
+					},
+					"include_level": {
+						Type:        "boolean",
+						Description: "Prefix each log message with its level, e.g. [ERROR]",
+						Default:     mustMarshalJSON(false),
+					},
+					"max_lines": {
+						Type:        "integer",
+						Description: "Maximum number of logs to return",
+						Default:     mustMarshalJSON(itemLogsTextDefaultMaxLines),
+						Minimum:     openapi.PtrFloat64(1),
+						Maximum:     openapi.PtrFloat64(itemLogsTextMaxLines),
+					},
+				},
+				Required: []string{"test_item_id"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "get_item_logs_text", func(ctx context.Context, request *mcp.CallToolRequest, args GetItemLogsTextArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if args.TestItemID <= 0 {
+				return nil, nil, fmt.Errorf("test_item_id is required")
+			}
+			maxLines := itemLogsTextDefaultMaxLines
+			if args.MaxLines != nil {
+				maxLines = *args.MaxLines
+			}
+			if maxLines < 1 || maxLines > itemLogsTextMaxLines {
+				return nil, nil, fmt.Errorf(
+					"max_lines must be between 1 and %d, got %d",
+					itemLogsTextMaxLines,
+					maxLines,
+				)
+			}
+
+			ctxWithParams := utils.WithQueryParams(
+				ctx,
+				logFilterValues(args.FilterGteLevel, args.FilterCntMessage, "--", ""),
+			)
+			var text strings.Builder
+			lines, truncated := 0, false
+			for page := uint(utils.FirstPage); ; page++ {
+				apiRequest, err := utils.ApplyPaginationOptions(
+					lr.client.LogAPI.GetLogs(ctxWithParams, project).
+						FilterEqItem(int32(args.TestItemID)), //nolint:gosec // item IDs fit into int32 on the RP side
+					lr.settings.Pagination,
+					page,
+					itemLogsTextPageSize,
+					utils.DefaultSortingForLogs,
+					utils.DefaultSortingForLogs,
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+				logs, response, err := apiRequest.Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				for _, logEntry := range logs.Content {
+					if lines == maxLines {
+						truncated = true
+						break
+					}
+					if args.IncludeLevel {
+						fmt.Fprintf(&text, "[%s] ", logEntry.GetLevel())
+					}
+					text.WriteString(logEntry.GetMessage())
+					text.WriteByte('\n')
+					lines++
+				}
+				hasNext := len(logs.Content) > 0 && logs.Page != nil && logs.Page.GetHasNext()
+				if truncated || !hasNext {
+					break
+				}
+				if lines == maxLines {
+					truncated = true
+					break
+				}
+			}
+
+			if lines == 0 {
+				text.WriteString("no logs found for the test item\n")
+			}
+			if truncated {
+				fmt.Fprintf(&text, "[truncated: only the first %d logs are included]\n", maxLines)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: text.String()}},
+			}, nil, nil
+		})
+}
+
+// GetTestSuitesByFilterArgs holds filter and pagination params for get_test_suites_by_filter.
+type GetTestSuitesByFilterArgs struct {
+	ProjectKey                  string `json:"projectKey"`
+	LaunchID                    uint32 `json:"launch-id"`
+	Page                        uint   `json:"page"`
+	PageSize                    uint   `json:"page-size"`
+	PageSort                    string `json:"page-sort"`
+	FilterCntName               string `json:"filter-cnt-name"`
+	FilterHasCompositeAttribute string `json:"filter-has-compositeAttribute"`
+	FilterHasAttributeKey       string `json:"filter-has-attributeKey"`
+	FilterCntDescription        string `json:"filter-cnt-description"`
+	FilterEqParentId            string `json:"filter-eq-parentId"`
+	FilterBtwStartTimeFrom      string `json:"filter-btw-startTime-from"`
+	FilterBtwStartTimeTo        string `json:"filter-btw-startTime-to"`
+}
+
+// toolGetTestSuitesByFilter creates a tool to get test suites for a specific launch.
+func (lr *TestItemResources) toolGetTestSuitesByFilter() (*mcp.Tool, ToolHandler[GetTestSuitesByFilterArgs, any]) {
+	properties := make(map[string]*jsonschema.Schema)
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	properties[utils.ProjectKeyField] = pkSchema
+	properties["launch-id"] = &jsonschema.Schema{
+		Type:        "integer",
+		Description: "Suites with specific Launch ID, this is a required parameter",
+	}
+
+	// Add pagination parameters
+	paginationProps := utils.SetPaginationProperties(
+		lr.settings.Pagination,
+		utils.DefaultSortingForSuites,
+	)
+	for k, v := range paginationProps {
 		properties[k] = v
 	}
 
@@ -803,7 +2038,7 @@ func (lr *TestItemResources) toolGetTestSuitesByFilter() (*mcp.Tool, ToolHandler
 	}
 	properties["filter-has-compositeAttribute"] = &jsonschema.Schema{
 		Type:        "string",
-		Description: "Suites have this combination of the attribute values, format: attribute1,attribute2:attribute3,... etc. string without spaces",
+		Description: "Suites have this combination of the attribute values, format: comma-separated value, key:value or key: (any value of the key) segments without spaces, e.g. demo,platform:ios,build:",
 	}
 	properties["filter-has-attributeKey"] = &jsonschema.Schema{
 		Type:        "string",
@@ -835,12 +2070,19 @@ func (lr *TestItemResources) toolGetTestSuitesByFilter() (*mcp.Tool, ToolHandler
 				Required:   []string{"launch-id"},
 			},
 		}, utils.WithAnalytics(lr.analytics, "get_test_suites_by_filter", func(ctx context.Context, request *mcp.CallToolRequest, args GetTestSuitesByFilterArgs) (*mcp.CallToolResult, any, error) {
-			slog.Debug("START PROCESSING")
+			slog.DebugContext(ctx, "START PROCESSING")
 			project, err := utils.ExtractProject(ctx, args.ProjectKey)
 			if err != nil {
 				return nil, nil, err
 			}
 
+			if err := utils.ValidateCompositeAttribute(
+				"filter-has-compositeAttribute",
+				args.FilterHasCompositeAttribute,
+			); err != nil {
+				return nil, nil, err
+			}
+
 			if args.LaunchID == 0 {
 				return nil, nil, fmt.Errorf("launch-id is required")
 			}
@@ -890,13 +2132,17 @@ func (lr *TestItemResources) toolGetTestSuitesByFilter() (*mcp.Tool, ToolHandler
 				Params(requiredUrlParams)
 
 			// Apply pagination parameters
-			apiRequest = utils.ApplyPaginationOptions(
+			apiRequest, err = utils.ApplyPaginationOptions(
 				apiRequest,
+				lr.settings.Pagination,
 				args.Page,
 				args.PageSize,
 				args.PageSort,
 				utils.DefaultSortingForSuites,
 			)
+			if err != nil {
+				return nil, nil, err
+			}
 
 			// Process attribute keys and combine with composite attributes
 			filterAttributes := utils.ProcessAttributeKeys(
@@ -1011,12 +2257,92 @@ func (lr *TestItemResources) toolGetProjectDefectTypes() (*mcp.Tool, ToolHandler
 		})
 }
 
+// btsIntegrationGroupType is the integration group type of bug tracking systems (Jira, Azure DevOps, etc.)
+const btsIntegrationGroupType = "BTS"
+
+// btsIntegration is the subset of a bug tracking integration returned by get_bts_integrations.
+// Integration parameters other than the base URL and external project are omitted to avoid leaking credentials.
+type btsIntegration struct {
+	ID              int64  `json:"id"`
+	Name            string `json:"name,omitempty"`
+	Type            string `json:"type"`
+	Enabled         bool   `json:"enabled"`
+	URL             string `json:"url,omitempty"`
+	ExternalProject string `json:"external_project,omitempty"`
+}
+
+// toolGetBTSIntegrations creates a tool to list the bug tracking integrations available to a project.
+func (lr *TestItemResources) toolGetBTSIntegrations() (*mcp.Tool, ToolHandler[ProjectKeyArgs, any]) {
+	properties := make(map[string]*jsonschema.Schema)
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	properties[utils.ProjectKeyField] = pkSchema
+
+	return &mcp.Tool{
+			Name:        "get_bts_integrations",
+			Description: "Get the bug tracking system (BTS) integrations configured for a project (e.g. Jira, Azure DevOps). Returns integration IDs, types, base URLs and external project keys to reference when linking external issues to test items",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: properties,
+				Required:   nil,
+			},
+		}, utils.WithAnalytics(lr.analytics, "get_bts_integrations", func(ctx context.Context, request *mcp.CallToolRequest, args ProjectKeyArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			integrations, response, err := lr.client.IntegrationAPI.GetProjectIntegrations(ctx, project).
+				Execute()
+			if err != nil {
+				return nil, nil, fmt.Errorf(
+					"%s: %w",
+					utils.ExtractResponseError(err, response),
+					err,
+				)
+			}
+
+			btsIntegrations := make([]btsIntegration, 0, len(integrations))
+			for _, integration := range integrations {
+				integrationType := integration.GetIntegrationType()
+				if !strings.EqualFold(integrationType.GetGroupType(), btsIntegrationGroupType) {
+					continue
+				}
+				bts := btsIntegration{
+					ID:      integration.GetId(),
+					Name:    integration.GetName(),
+					Type:    integrationType.GetName(),
+					Enabled: integration.GetEnabled(),
+				}
+				if baseURL, ok := integration.IntegrationParameters["url"].(string); ok {
+					bts.URL = baseURL
+				}
+				if externalProject, ok := integration.IntegrationParameters["project"].(string); ok {
+					bts.ExternalProject = externalProject
+				}
+				btsIntegrations = append(btsIntegrations, bts)
+			}
+
+			r, err := json.Marshal(btsIntegrations)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
+
 // UpdateDefectTypeArgs holds params for update_defect_type_for_test_items.
 type UpdateDefectTypeArgs struct {
 	ProjectKey        string   `json:"projectKey"`
 	TestItemsIDs      []string `json:"test_items_ids"`
 	DefectTypeID      string   `json:"defect_type_id"`
 	DefectTypeComment string   `json:"defect_type_comment"`
+	DryRun            bool     `json:"dry_run"`
 }
 
 // toolUpdateDefectTypeForTestItems creates a tool to update the defect type for a list of specific test items.
@@ -1039,9 +2365,11 @@ func (lr *TestItemResources) toolUpdateDefectTypeForTestItems() (*mcp.Tool, Tool
 		Description: "Defect Type ID, all possible values can be received from the tool 'get_project_defect_types'. Example: {\"NO_DEFECT\": { \"locator\": \"nd001\" }} (where NO_DEFECT is the defect type name, nd001 is the defect type unique id)",
 	}
 	properties["defect_type_comment"] = &jsonschema.Schema{
-		Type:        "string",
-		Description: "The defect type comment provides a detailed description of the root cause of the test failure",
+		Type: "string",
+		Description: "The defect type comment provides a detailed description of the root cause of the test failure. " +
+			"It is set together with the defect type in the same request; when omitted, only the defect type is updated",
 	}
+	properties["dry_run"] = utils.DryRunSchema()
 
 	return &mcp.Tool{
 			Name:        "update_defect_type_for_test_items",
@@ -1075,8 +2403,8 @@ func (lr *TestItemResources) toolUpdateDefectTypeForTestItems() (*mcp.Tool, Tool
 				len(args.TestItemsIDs),
 			)
 			var commentPtr *string
-			if args.DefectTypeComment != "" {
-				commentPtr = &args.DefectTypeComment
+			if comment := strings.TrimSpace(args.DefectTypeComment); comment != "" {
+				commentPtr = &comment
 			}
 			for _, testItemIdStr := range args.TestItemsIDs {
 				testItemId, err := strconv.ParseInt(testItemIdStr, 10, 64)
@@ -1099,6 +2427,20 @@ func (lr *TestItemResources) toolUpdateDefectTypeForTestItems() (*mcp.Tool, Tool
 				})
 			}
 
+			if args.DryRun {
+				testItemIDs := make([]int64, 0, len(issues))
+				for _, issue := range issues {
+					testItemIDs = append(testItemIDs, issue.TestItemId)
+				}
+				return utils.DryRunResult("update_defect_type_for_test_items", map[string]any{
+					"operation":           "update_defect_type",
+					"project":             project,
+					"test_item_ids":       testItemIDs,
+					"defect_type_id":      args.DefectTypeID,
+					"defect_type_comment": args.DefectTypeComment,
+				})
+			}
+
 			apiRequest := lr.client.TestItemAPI.DefineTestItemIssueType(ctx, project).
 				ComEpamReportportalBaseModelIssueDefineIssueRQ(openapi.ComEpamReportportalBaseModelIssueDefineIssueRQ{
 					Issues: issues,
@@ -1119,60 +2461,697 @@ func (lr *TestItemResources) toolUpdateDefectTypeForTestItems() (*mcp.Tool, Tool
 		})
 }
 
-// GetTestItemsHistoryArgs holds filter and pagination params for get_test_items_history.
-type GetTestItemsHistoryArgs struct {
-	ProjectKey                  string   `json:"projectKey"`
-	FilterEqLaunchId            int32    `json:"filter-eq-launchId"`
-	FilterEqParentId            uint64   `json:"filter-eq-parentId"`
-	Page                        uint     `json:"page"`
-	PageSize                    uint     `json:"page-size"`
-	PageSort                    string   `json:"page-sort"`
-	HistoryDepth                int32    `json:"historyDepth"`
-	HistoryBase                 string   `json:"type"`
-	FilterCntName               string   `json:"filter-cnt-name"`
-	FilterHasCompositeAttribute string   `json:"filter-has-compositeAttribute"`
-	FilterAnyCompositeAttribute string   `json:"filter-any-compositeAttribute"`
-	FilterCntDescription        string   `json:"filter-cnt-description"`
-	FilterBtwStartTimeFrom      string   `json:"filter-btw-startTime-from"`
-	FilterBtwStartTimeTo        string   `json:"filter-btw-startTime-to"`
-	FilterInStatus              []string `json:"filter-in-status"`
-	FilterEqHasRetries          string   `json:"filter-eq-hasRetries"`
-	FilterCntIssueComment       string   `json:"filter-cnt-issueComment"`
-	FilterEqAutoAnalyzed        *bool    `json:"filter-eq-autoAnalyzed"`
-	FilterInIgnoreAnalyzer      *bool    `json:"filter-in-ignoreAnalyzer"`
-	FilterHasTicketId           string   `json:"filter-has-ticketId"`
-	FilterAnyPatternName        string   `json:"filter-any-patternName"`
+const (
+	// finishStuckItemsMaxItems caps the number of in-progress items finish_stuck_items handles per call.
+	finishStuckItemsMaxItems = 500
+	// finishStuckItemsDefaultStatus is the status given to stuck items when none is requested.
+	finishStuckItemsDefaultStatus = "INTERRUPTED"
+)
+
+// finishStuckItemsStatuses are the statuses finish_stuck_items can assign to stuck items.
+var finishStuckItemsStatuses = []string{"INTERRUPTED", "FAILED", "STOPPED", "SKIPPED", "PASSED"}
+
+// FinishStuckItemsArgs holds params for finish_stuck_items.
+type FinishStuckItemsArgs struct {
+	ProjectKey string `json:"projectKey"`
+	LaunchID   uint32 `json:"launch_id"`
+	Status     string `json:"status"`
+	DryRun     bool   `json:"dry_run"`
 }
 
-// toolGetTestItemsHistory creates a tool to retrieve history of test items.
-func (lr *TestItemResources) toolGetTestItemsHistory() (*mcp.Tool, ToolHandler[GetTestItemsHistoryArgs, any]) {
-	properties := make(map[string]*jsonschema.Schema)
+// fetchInProgressItems returns all IN_PROGRESS test items of a launch (suites, tests and steps)
+func (lr *TestItemResources) fetchInProgressItems(
+	ctx context.Context,
+	project string,
+	launchID uint32,
+) ([]openapi.ComEpamReportportalBaseReportingTestItemResource, error) {
+	launchIDStr := strconv.FormatUint(uint64(launchID), 10)
+	ctxWithParams := utils.WithQueryParams(ctx, url.Values{
+		"launchId":         {launchIDStr},
+		"providerType":     {utils.DefaultProviderType},
+		"filter.in.status": {utils.StatusInProgress},
+	})
+
+	var items []openapi.ComEpamReportportalBaseReportingTestItemResource
+	for page := uint(utils.FirstPage); ; page++ {
+		apiRequest, err := utils.ApplyPaginationOptions(
+			lr.client.TestItemAPI.GetTestItemsV2(ctxWithParams, project).
+				Params(map[string]string{"launchId": launchIDStr}),
+			lr.settings.Pagination,
+			page,
+			utils.DefaultPageSize,
+			"",
+			utils.DefaultSortingForItems,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		itemsPage, response, err := apiRequest.Execute()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+		}
+		items = append(items, itemsPage.Content...)
+		if len(items) > finishStuckItemsMaxItems {
+			return nil, fmt.Errorf(
+				"launch %d has more than %d in-progress items; finish the launch instead (launch_force_finish)",
+				launchID,
+				finishStuckItemsMaxItems,
+			)
+		}
+		if len(itemsPage.Content) < utils.DefaultPageSize {
+			return items, nil
+		}
+		if hasNext, ok := itemsPage.Page.GetHasNextOk(); ok && !*hasNext {
+			return items, nil
+		}
+	}
+}
+
+// toolFinishStuckItems creates a tool that force-finishes the IN_PROGRESS test items of a launch.
+// Nested items are finished before their parents so that suites are not left waiting on children.
+func (lr *TestItemResources) toolFinishStuckItems() (*mcp.Tool, ToolHandler[FinishStuckItemsArgs, any]) {
 	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
 	if err != nil {
 		slog.Error("failed to build project key schema", "error", err)
 	}
-	properties[utils.ProjectKeyField] = pkSchema
-	properties["filter-eq-launchId"] = &jsonschema.Schema{
-		Type:        "integer",
-		Description: "Filter by Launch ID. Conditionally required if Parent ID is not provided.",
-		Minimum:     openapi.PtrFloat64(0),
-	}
-	properties["filter-eq-parentId"] = &jsonschema.Schema{
-		Type:        "integer",
-		Description: "Filter by Parent Test Item ID (suite ID). Conditionally required if Launch ID is not provided.",
+	statusEnum := make([]any, 0, len(finishStuckItemsStatuses))
+	for _, status := range finishStuckItemsStatuses {
+		statusEnum = append(statusEnum, status)
 	}
 
-	paginationProps := utils.SetPaginationProperties(utils.DefaultSortingForItems)
-	for k, v := range paginationProps {
-		properties[k] = v
-	}
+	return &mcp.Tool{
+			Name: "finish_stuck_items",
+			Description: "Operational cleanup: finish all test items of a launch that are stuck IN_PROGRESS " +
+				"(e.g. because the reporting agent crashed) so the launch can be finished. " +
+				"Returns the number and IDs of the finished items",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "ID of the launch whose in-progress items should be finished",
+					},
+					"status": {
+						Type:        "string",
+						Description: "Status to finish the stuck items with",
+						Enum:        statusEnum,
+						Default:     mustMarshalJSON(finishStuckItemsDefaultStatus),
+					},
+					"dry_run": utils.DryRunSchema(),
+				},
+				Required: []string{"launch_id"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "finish_stuck_items", func(ctx context.Context, request *mcp.CallToolRequest, args FinishStuckItemsArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+			if args.LaunchID == 0 {
+				return nil, nil, fmt.Errorf("launch_id is required")
+			}
+			status := strings.ToUpper(strings.TrimSpace(args.Status))
+			if status == "" {
+				status = finishStuckItemsDefaultStatus
+			}
+			if !slices.Contains(finishStuckItemsStatuses, status) {
+				return nil, nil, fmt.Errorf(
+					"invalid status %q: must be one of %s",
+					args.Status,
+					strings.Join(finishStuckItemsStatuses, ", "),
+				)
+			}
 
-	properties["historyDepth"] = &jsonschema.Schema{
-		Type:        "integer",
-		Description: "Depth of history to retrieve. Allowed values: 1–30.",
-		Default:     mustMarshalJSON(10),
-		Minimum:     openapi.PtrFloat64(1),
-		Maximum:     openapi.PtrFloat64(30),
+			// Finishing an item requires the launch UUID
+			launch, response, err := lr.client.LaunchAPI.GetLaunch(
+				ctx,
+				strconv.FormatUint(uint64(args.LaunchID), 10),
+				project,
+			).Execute()
+			if err != nil {
+				return nil, nil, fmt.Errorf(
+					"%s: %w",
+					utils.ExtractResponseError(err, response),
+					err,
+				)
+			}
+
+			items, err := lr.fetchInProgressItems(ctx, project, args.LaunchID)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// Deepest items first: a parent cannot be finished properly while its children run
+			depth := func(item openapi.ComEpamReportportalBaseReportingTestItemResource) int {
+				return strings.Count(item.GetPath(), ".")
+			}
+			slices.SortStableFunc(
+				items,
+				func(a, b openapi.ComEpamReportportalBaseReportingTestItemResource) int {
+					return depth(b) - depth(a)
+				},
+			)
+
+			itemIDs := make([]int64, 0, len(items))
+			for _, item := range items {
+				itemIDs = append(itemIDs, item.GetId())
+			}
+
+			if args.DryRun {
+				return utils.DryRunResult("finish_stuck_items", map[string]any{
+					"operation": "finish_items",
+					"project":   project,
+					"launch_id": args.LaunchID,
+					"status":    status,
+					"item_ids":  itemIDs,
+				})
+			}
+
+			finishedIDs := make([]int64, 0, len(items))
+			failures := make(map[string]string)
+			for _, item := range items {
+				rq := openapi.NewComEpamReportportalBaseReportingFinishTestItemRQ(
+					time.Now(),
+					launch.Uuid,
+				)
+				rq.SetStatus(status)
+				_, response, err := lr.client.TestItemAPI.FinishTestItem2(ctx, project, item.GetUuid()).
+					ComEpamReportportalBaseReportingFinishTestItemRQ(*rq).
+					Execute()
+				if err != nil {
+					if ctx.Err() != nil {
+						return nil, nil, ctx.Err()
+					}
+					itemID := strconv.FormatInt(item.GetId(), 10)
+					failures[itemID] = utils.ExtractResponseError(err, response)
+					continue
+				}
+				finishedIDs = append(finishedIDs, item.GetId())
+			}
+
+			result := map[string]any{
+				"launch_id":         args.LaunchID,
+				"status":            status,
+				"finished_count":    len(finishedIDs),
+				"finished_item_ids": finishedIDs,
+			}
+			if len(failures) > 0 {
+				result["failed_items"] = failures
+			}
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
+
+const (
+	// bulkAddAttributeMaxItems caps the number of test items bulk_add_attribute_to_items updates per call.
+	bulkAddAttributeMaxItems = 200
+	// bulkAddAttributeConcurrency bounds parallel ReportPortal requests of bulk_add_attribute_to_items.
+	bulkAddAttributeConcurrency = 5
+)
+
+// Per-item outcomes reported by bulk_add_attribute_to_items and set_ignore_analyzer
+const (
+	bulkItemUpdated   = "updated"
+	bulkItemUnchanged = "unchanged"
+	bulkItemFailed    = "failed"
+)
+
+// BulkAddAttributeArgs holds params for bulk_add_attribute_to_items.
+type BulkAddAttributeArgs struct {
+	ProjectKey  string  `json:"projectKey"`
+	TestItemIDs []int64 `json:"test_item_ids"`
+	Key         string  `json:"key"`
+	Value       string  `json:"value"`
+	DryRun      bool    `json:"dry_run"`
+}
+
+// bulkItemResult is the outcome of a bulk operation for a single test item
+type bulkItemResult struct {
+	TestItemID int64  `json:"test_item_id"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// uniqueTestItemIDs validates the test_item_ids argument of bulk tools and de-duplicates the IDs
+// while preserving their order
+func uniqueTestItemIDs(ids []int64, maxItems int) ([]int64, error) {
+	itemIDs := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, fmt.Errorf("invalid non-positive test item ID %d", id)
+		}
+		if !slices.Contains(itemIDs, id) {
+			itemIDs = append(itemIDs, id)
+		}
+	}
+	if len(itemIDs) == 0 {
+		return nil, fmt.Errorf("test_item_ids is required and must be a non-empty array")
+	}
+	if len(itemIDs) > maxItems {
+		return nil, fmt.Errorf(
+			"too many test item IDs: %d (maximum is %d)",
+			len(itemIDs),
+			maxItems,
+		)
+	}
+	return itemIDs, nil
+}
+
+// mergeItemAttribute appends the key:value attribute unless an attribute with the same key and
+// value is already present. It reports whether the attribute was added; the input is not modified.
+func mergeItemAttribute(
+	attrs []openapi.ComEpamReportportalBaseReportingItemAttributeResource,
+	key, value string,
+) ([]openapi.ComEpamReportportalBaseReportingItemAttributeResource, bool) {
+	for _, attr := range attrs {
+		if attr.GetKey() == key && attr.Value == value {
+			return attrs, false
+		}
+	}
+	merged := slices.Clone(attrs)
+	attr := openapi.ComEpamReportportalBaseReportingItemAttributeResource{Value: value}
+	if key != "" {
+		attr.Key = &key
+	}
+	return append(merged, attr), true
+}
+
+// addAttributeToItem fetches the current attributes of a test item and adds the attribute to them
+func (lr *TestItemResources) addAttributeToItem(
+	ctx context.Context,
+	project string,
+	itemID int64,
+	key, value string,
+) (string, error) {
+	item, response, err := lr.client.TestItemAPI.GetTestItem(
+		ctx,
+		strconv.FormatInt(itemID, 10),
+		project,
+	).Execute()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+	}
+
+	attrs, added := mergeItemAttribute(item.Attributes, key, value)
+	if !added {
+		return bulkItemUnchanged, nil
+	}
+
+	updateRQ := openapi.NewComEpamReportportalBaseModelItemUpdateTestItemRQ()
+	updateRQ.SetAttributes(attrs)
+	_, response, err = lr.client.TestItemAPI.UpdateTestItem(ctx, itemID, project).
+		ComEpamReportportalBaseModelItemUpdateTestItemRQ(*updateRQ).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+	}
+	return bulkItemUpdated, nil
+}
+
+// toolBulkAddAttributeToItems creates a tool that adds one attribute to many test items.
+// Items that already carry the same key:value are left untouched, so the call can be repeated safely.
+func (lr *TestItemResources) toolBulkAddAttributeToItems() (*mcp.Tool, ToolHandler[BulkAddAttributeArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "bulk_add_attribute_to_items",
+			Description: "Add an attribute (e.g. triaged:true) to many test items at once, keeping their " +
+				"existing attributes. Items that already have the same key:value are reported as unchanged. " +
+				"Returns the outcome for each item",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"test_item_ids": {
+						Type:        "array",
+						Description: "IDs of the test items to tag",
+						Items:       &jsonschema.Schema{Type: "integer"},
+						MinItems:    openapi.PtrInt(1),
+						MaxItems:    openapi.PtrInt(bulkAddAttributeMaxItems),
+					},
+					"key": {
+						Type:        "string",
+						Description: "Attribute key (may be empty for tag-style attributes)",
+					},
+					"value": {
+						Type:        "string",
+						Description: "Attribute value",
+						MinLength:   openapi.PtrInt(1),
+					},
+					"dry_run": utils.DryRunSchema(),
+				},
+				Required: []string{"test_item_ids", "value"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "bulk_add_attribute_to_items", func(ctx context.Context, request *mcp.CallToolRequest, args BulkAddAttributeArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			key := strings.TrimSpace(args.Key)
+			value := strings.TrimSpace(args.Value)
+			if value == "" {
+				return nil, nil, fmt.Errorf("value is required")
+			}
+
+			itemIDs, err := uniqueTestItemIDs(args.TestItemIDs, bulkAddAttributeMaxItems)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if args.DryRun {
+				return utils.DryRunResult("bulk_add_attribute_to_items", map[string]any{
+					"operation":     "add_attribute",
+					"project":       project,
+					"test_item_ids": itemIDs,
+					"key":           key,
+					"value":         value,
+				})
+			}
+
+			results := make([]bulkItemResult, len(itemIDs))
+			errs := forEachBounded(
+				ctx,
+				len(itemIDs),
+				bulkAddAttributeConcurrency,
+				func(i int) error {
+					status, err := lr.addAttributeToItem(ctx, project, itemIDs[i], key, value)
+					results[i] = bulkItemResult{TestItemID: itemIDs[i], Status: status}
+					return err
+				},
+			)
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+
+			updated := 0
+			for i, err := range errs {
+				if err != nil {
+					results[i] = bulkItemResult{
+						TestItemID: itemIDs[i],
+						Status:     bulkItemFailed,
+						Error:      err.Error(),
+					}
+					continue
+				}
+				if results[i].Status == bulkItemUpdated {
+					updated++
+				}
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"key":           key,
+				"value":         value,
+				"updated_count": updated,
+				"results":       results,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
+
+const (
+	// setIgnoreAnalyzerMaxItems caps the number of test items set_ignore_analyzer updates per call.
+	setIgnoreAnalyzerMaxItems = 200
+	// setIgnoreAnalyzerConcurrency bounds parallel ReportPortal requests of set_ignore_analyzer.
+	setIgnoreAnalyzerConcurrency = 5
+)
+
+// SetIgnoreAnalyzerArgs holds params for set_ignore_analyzer.
+type SetIgnoreAnalyzerArgs struct {
+	ProjectKey     string  `json:"projectKey"`
+	TestItemIDs    []int64 `json:"test_item_ids"`
+	IgnoreAnalyzer bool    `json:"ignore_analyzer"`
+	DryRun         bool    `json:"dry_run"`
+}
+
+// ignoreAnalyzerIssue returns the issue definition that applies the ignoreAnalyzer flag to a
+// test item while keeping its defect type, comment and linked tickets. It returns a nil
+// definition when the item already has the requested flag.
+func ignoreAnalyzerIssue(
+	itemID int64,
+	current *openapi.ComEpamReportportalBaseReportingIssue,
+	ignoreAnalyzer bool,
+) (*openapi.ComEpamReportportalBaseModelIssueIssueDefinition, error) {
+	if current == nil || current.IssueType == "" {
+		return nil, fmt.Errorf(
+			"test item has no defect type; only items with a defect take part in auto-analysis",
+		)
+	}
+	if current.GetIgnoreAnalyzer() == ignoreAnalyzer {
+		return nil, nil
+	}
+	issue := *current
+	issue.IgnoreAnalyzer = openapi.PtrBool(ignoreAnalyzer)
+	return &openapi.ComEpamReportportalBaseModelIssueIssueDefinition{
+		TestItemId: itemID,
+		Issue:      issue,
+	}, nil
+}
+
+// defineIssueError is an entry of the errors array ReportPortal returns for issue definitions it
+// could not apply
+type defineIssueError struct {
+	TestItemID int64  `json:"testItemId"`
+	Message    string `json:"message"`
+}
+
+// defineIssueErrors extracts the per-item errors from a DefineTestItemIssueType response body.
+// A JSON array (the applied issues) or an empty body carries no errors.
+func defineIssueErrors(body []byte) ([]defineIssueError, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 || body[0] != '{' {
+		return nil, nil
+	}
+	var rs struct {
+		Errors []defineIssueError `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &rs); err != nil {
+		return nil, fmt.Errorf("failed to parse issue definition response: %w", err)
+	}
+	return rs.Errors, nil
+}
+
+// toolSetIgnoreAnalyzer creates a tool that includes test items in or excludes them from auto-analysis.
+// ReportPortal stores the flag on the item's issue, so the current issue is read first and sent
+// back unchanged apart from the flag.
+func (lr *TestItemResources) toolSetIgnoreAnalyzer() (*mcp.Tool, ToolHandler[SetIgnoreAnalyzerArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "set_ignore_analyzer",
+			Description: "Set (ignore_analyzer: true) or clear (ignore_analyzer: false) the ignoreAnalyzer flag of test items, " +
+				"which controls whether they take part in auto-analysis. Only items with a defect type can be changed; " +
+				"their defect type and comment are kept. Returns the outcome for each item",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"test_item_ids": {
+						Type:        "array",
+						Description: "IDs of the test items to update",
+						Items:       &jsonschema.Schema{Type: "integer"},
+						MinItems:    openapi.PtrInt(1),
+						MaxItems:    openapi.PtrInt(setIgnoreAnalyzerMaxItems),
+					},
+					"ignore_analyzer": {
+						Type:        "boolean",
+						Description: "true to exclude the items from auto-analysis, false to include them again",
+					},
+					"dry_run": utils.DryRunSchema(),
+				},
+				Required: []string{"test_item_ids", "ignore_analyzer"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "set_ignore_analyzer", func(ctx context.Context, request *mcp.CallToolRequest, args SetIgnoreAnalyzerArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			itemIDs, err := uniqueTestItemIDs(args.TestItemIDs, setIgnoreAnalyzerMaxItems)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if args.DryRun {
+				return utils.DryRunResult("set_ignore_analyzer", map[string]any{
+					"operation":       "set_ignore_analyzer",
+					"project":         project,
+					"test_item_ids":   itemIDs,
+					"ignore_analyzer": args.IgnoreAnalyzer,
+				})
+			}
+
+			// Read the current issue of every item to build the updated issue definitions
+			results := make([]bulkItemResult, len(itemIDs))
+			issues := make([]*openapi.ComEpamReportportalBaseModelIssueIssueDefinition, len(itemIDs))
+			errs := forEachBounded(
+				ctx,
+				len(itemIDs),
+				setIgnoreAnalyzerConcurrency,
+				func(i int) error {
+					item, response, err := lr.client.TestItemAPI.GetTestItem(
+						ctx,
+						strconv.FormatInt(itemIDs[i], 10),
+						project,
+					).Execute()
+					if err != nil {
+						return fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+					}
+					issues[i], err = ignoreAnalyzerIssue(itemIDs[i], item.Issue, args.IgnoreAnalyzer)
+					return err
+				},
+			)
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+
+			var definitions []openapi.ComEpamReportportalBaseModelIssueIssueDefinition
+			for i, err := range errs {
+				results[i] = bulkItemResult{TestItemID: itemIDs[i], Status: bulkItemUnchanged}
+				switch {
+				case err != nil:
+					results[i].Status = bulkItemFailed
+					results[i].Error = err.Error()
+				case issues[i] != nil:
+					results[i].Status = bulkItemUpdated
+					definitions = append(definitions, *issues[i])
+				}
+			}
+
+			// Apply all changes in a single request
+			updatedCount := len(definitions)
+			var unmatchedErrors []string
+			if len(definitions) > 0 {
+				_, response, err := lr.client.TestItemAPI.DefineTestItemIssueType(ctx, project).
+					ComEpamReportportalBaseModelIssueDefineIssueRQ(openapi.ComEpamReportportalBaseModelIssueDefineIssueRQ{
+						Issues: definitions,
+					}).
+					Execute()
+				// A successful response whose body does not match the generated model (an object
+				// with per-item errors instead of the list of issues) is read below
+				if err != nil && (response == nil || response.StatusCode >= http.StatusMultipleChoices) {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+				rawBody, err := utils.ReadResponseBodyRaw(response)
+				if err != nil {
+					return nil, nil, err
+				}
+				defineErrors, err := defineIssueErrors(rawBody)
+				if err != nil {
+					return nil, nil, err
+				}
+				for _, defineErr := range defineErrors {
+					i := slices.Index(itemIDs, defineErr.TestItemID)
+					if i < 0 || results[i].Status != bulkItemUpdated {
+						unmatchedErrors = append(unmatchedErrors, defineErr.Message)
+						continue
+					}
+					results[i].Status = bulkItemFailed
+					results[i].Error = defineErr.Message
+					updatedCount--
+				}
+			}
+
+			result := map[string]any{
+				"ignore_analyzer": args.IgnoreAnalyzer,
+				"updated_count":   updatedCount,
+				"results":         results,
+			}
+			if len(unmatchedErrors) > 0 {
+				result["errors"] = unmatchedErrors
+			}
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
+
+// GetTestItemsHistoryArgs holds filter and pagination params for get_test_items_history.
+type GetTestItemsHistoryArgs struct {
+	ProjectKey                  string   `json:"projectKey"`
+	FilterEqLaunchId            int32    `json:"filter-eq-launchId"`
+	FilterEqParentId            uint64   `json:"filter-eq-parentId"`
+	Page                        uint     `json:"page"`
+	PageSize                    uint     `json:"page-size"`
+	PageSort                    string   `json:"page-sort"`
+	HistoryDepth                int32    `json:"historyDepth"`
+	HistoryBase                 string   `json:"type"`
+	FilterCntName               string   `json:"filter-cnt-name"`
+	FilterHasCompositeAttribute string   `json:"filter-has-compositeAttribute"`
+	FilterAnyCompositeAttribute string   `json:"filter-any-compositeAttribute"`
+	FilterCntDescription        string   `json:"filter-cnt-description"`
+	FilterBtwStartTimeFrom      string   `json:"filter-btw-startTime-from"`
+	FilterBtwStartTimeTo        string   `json:"filter-btw-startTime-to"`
+	FilterInStatus              []string `json:"filter-in-status"`
+	FilterEqHasRetries          string   `json:"filter-eq-hasRetries"`
+	FilterCntIssueComment       string   `json:"filter-cnt-issueComment"`
+	FilterEqAutoAnalyzed        *bool    `json:"filter-eq-autoAnalyzed"`
+	FilterInIgnoreAnalyzer      *bool    `json:"filter-in-ignoreAnalyzer"`
+	FilterHasTicketId           string   `json:"filter-has-ticketId"`
+	FilterAnyPatternName        string   `json:"filter-any-patternName"`
+}
+
+// toolGetTestItemsHistory creates a tool to retrieve history of test items.
+func (lr *TestItemResources) toolGetTestItemsHistory() (*mcp.Tool, ToolHandler[GetTestItemsHistoryArgs, any]) {
+	properties := make(map[string]*jsonschema.Schema)
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	properties[utils.ProjectKeyField] = pkSchema
+	properties["filter-eq-launchId"] = &jsonschema.Schema{
+		Type:        "integer",
+		Description: "Filter by Launch ID. Conditionally required if Parent ID is not provided.",
+		Minimum:     openapi.PtrFloat64(0),
+	}
+	properties["filter-eq-parentId"] = &jsonschema.Schema{
+		Type:        "integer",
+		Description: "Filter by Parent Test Item ID (suite ID). Conditionally required if Launch ID is not provided.",
+	}
+
+	paginationProps := utils.SetPaginationProperties(
+		lr.settings.Pagination,
+		utils.DefaultSortingForItems,
+	)
+	for k, v := range paginationProps {
+		properties[k] = v
+	}
+
+	properties["historyDepth"] = &jsonschema.Schema{
+		Type:        "integer",
+		Description: "Depth of history to retrieve. Allowed values: 1–30.",
+		Default:     mustMarshalJSON(10),
+		Minimum:     openapi.PtrFloat64(1),
+		Maximum:     openapi.PtrFloat64(30),
 	}
 	properties["type"] = &jsonschema.Schema{
 		Type:        "string",
@@ -1190,7 +3169,7 @@ func (lr *TestItemResources) toolGetTestItemsHistory() (*mcp.Tool, ToolHandler[G
 	}
 	properties["filter-any-compositeAttribute"] = &jsonschema.Schema{
 		Type:        "string",
-		Description: "Maps to filter.any.compositeAttribute. Format: attribute1Key:attribute1Value,attribute2Key:attribute2Value,attribute3Value, e.g. demo,platform:ios,build:1.2.3",
+		Description: "Maps to filter.any.compositeAttribute. Format: attribute1Key:attribute1Value,attribute2Key:attribute2Value,attribute3Value without spaces, e.g. demo,platform:ios,build:1.2.3",
 	}
 	properties["filter-cnt-description"] = &jsonschema.Schema{
 		Type:        "string",
@@ -1255,12 +3234,25 @@ func (lr *TestItemResources) toolGetTestItemsHistory() (*mcp.Tool, ToolHandler[G
 				Required:   nil,
 			},
 		}, utils.WithAnalytics(lr.analytics, "get_test_items_history", func(ctx context.Context, request *mcp.CallToolRequest, args GetTestItemsHistoryArgs) (*mcp.CallToolResult, any, error) {
-			slog.Debug("START PROCESSING")
+			slog.DebugContext(ctx, "START PROCESSING")
 			project, err := utils.ExtractProject(ctx, args.ProjectKey)
 			if err != nil {
 				return nil, nil, err
 			}
 
+			if err := utils.ValidateCompositeAttribute(
+				"filter-has-compositeAttribute",
+				args.FilterHasCompositeAttribute,
+			); err != nil {
+				return nil, nil, err
+			}
+			if err := utils.ValidateCompositeAttribute(
+				"filter-any-compositeAttribute",
+				args.FilterAnyCompositeAttribute,
+			); err != nil {
+				return nil, nil, err
+			}
+
 			if args.FilterEqLaunchId == 0 && args.FilterEqParentId == 0 {
 				return nil, nil, fmt.Errorf(
 					"either filter-eq-launchId or filter-eq-parentId is required",
@@ -1349,13 +3341,17 @@ func (lr *TestItemResources) toolGetTestItemsHistory() (*mcp.Tool, ToolHandler[G
 				apiRequest = apiRequest.FilterEqAutoAnalyzed(*args.FilterEqAutoAnalyzed)
 			}
 
-			apiRequest = utils.ApplyPaginationOptions(
+			apiRequest, err = utils.ApplyPaginationOptions(
 				apiRequest,
+				lr.settings.Pagination,
 				args.Page,
 				args.PageSize,
 				args.PageSort,
 				utils.DefaultSortingForItems,
 			)
+			if err != nil {
+				return nil, nil, err
+			}
 
 			_, response, err := apiRequest.Execute()
 			if err != nil {
@@ -1369,3 +3365,1444 @@ func (lr *TestItemResources) toolGetTestItemsHistory() (*mcp.Tool, ToolHandler[G
 			return utils.ReadResponseBody(response)
 		})
 }
+
+const (
+	// testCaseHistoryDefaultDepth is the number of launches covered when history_depth is not set
+	testCaseHistoryDefaultDepth = 10
+	// testCaseHistoryMaxDepth is the largest history depth accepted by ReportPortal
+	testCaseHistoryMaxDepth = 30
+	// testCaseHistoryScanLaunches is how many of the latest launches are searched for the
+	// test case when no launch_id is given
+	testCaseHistoryScanLaunches = 20
+)
+
+// GetTestCaseHistoryByHashArgs holds params for get_test_case_history_by_hash.
+type GetTestCaseHistoryByHashArgs struct {
+	ProjectKey   string `json:"projectKey"`
+	TestCaseHash *int32 `json:"test_case_hash"`
+	HistoryDepth int32  `json:"history_depth"`
+	LaunchID     int32  `json:"launch_id"`
+}
+
+// testCaseOccurrence is one run of a test case in get_test_case_history_by_hash
+type testCaseOccurrence struct {
+	LaunchID      int64      `json:"launch_id"`
+	LaunchNumber  int64      `json:"launch_number,omitempty"`
+	LaunchName    string     `json:"launch_name,omitempty"`
+	TestItemID    int64      `json:"test_item_id"`
+	Status        string     `json:"status"`
+	StartTime     *time.Time `json:"start_time,omitempty"`
+	DefectType    string     `json:"defect_type,omitempty"`
+	DefectComment string     `json:"defect_comment,omitempty"`
+}
+
+// newTestCaseOccurrence extracts the status and defect of a test item. Launch number and name
+// are filled in separately since history resources only carry the launch ID.
+func newTestCaseOccurrence(
+	item *openapi.ComEpamReportportalBaseReportingTestItemResource,
+) testCaseOccurrence {
+	occurrence := testCaseOccurrence{
+		LaunchID:   item.GetLaunchId(),
+		TestItemID: item.GetId(),
+		Status:     item.GetStatus(),
+		StartTime:  item.StartTime,
+	}
+	if item.Issue != nil {
+		occurrence.DefectType = item.Issue.IssueType
+		occurrence.DefectComment = item.Issue.GetComment()
+	}
+	return occurrence
+}
+
+// getLaunchesByID fetches the given launches in a single request, keyed by launch ID
+func (lr *TestItemResources) getLaunchesByID(
+	ctx context.Context,
+	project string,
+	launchIDs []int64,
+) (map[int64]openapi.ComEpamReportportalBaseReportingLaunchResource, error) {
+	launches := make(map[int64]openapi.ComEpamReportportalBaseReportingLaunchResource)
+	if len(launchIDs) == 0 {
+		return launches, nil
+	}
+	ids := make([]string, 0, len(launchIDs))
+	for _, id := range launchIDs {
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	ctxWithParams := utils.WithQueryParams(ctx, url.Values{
+		"filter.in.id": {strings.Join(ids, ",")},
+	})
+	apiRequest, err := utils.ApplyPaginationOptions(
+		lr.client.LaunchAPI.GetProjectLaunches(ctxWithParams, project),
+		lr.settings.Pagination,
+		utils.FirstPage,
+		uint(len(launchIDs)),
+		utils.DefaultSortingForLaunches,
+		utils.DefaultSortingForLaunches,
+	)
+	if err != nil {
+		return nil, err
+	}
+	page, response, err := apiRequest.Execute()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+	}
+	for _, launch := range page.Content {
+		launches[launch.Id] = launch
+	}
+	return launches, nil
+}
+
+// toolGetTestCaseHistoryByHash creates a tool that returns the run history of a test case across launches
+func (lr *TestItemResources) toolGetTestCaseHistoryByHash() (*mcp.Tool, ToolHandler[GetTestCaseHistoryByHashArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_test_case_history_by_hash",
+			Description: "Get the status history of a logical test case across launches by its testCaseHash, " +
+				"which stays stable across reruns unlike test item IDs. Returns the launch number, status " +
+				"and defect of every occurrence, newest first. Without launch_id the latest " +
+				fmt.Sprintf("%d launches are searched for the test case", testCaseHistoryScanLaunches),
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"test_case_hash": {
+						Type:        "integer",
+						Description: "Test case hash (testCaseHash field of a test item)",
+					},
+					"history_depth": {
+						Type:        "integer",
+						Description: "Number of launches to collect the history from",
+						Default:     mustMarshalJSON(testCaseHistoryDefaultDepth),
+						Minimum:     openapi.PtrFloat64(1),
+						Maximum:     openapi.PtrFloat64(testCaseHistoryMaxDepth),
+					},
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch to anchor the history on (default: latest launch containing the test case)",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+				},
+				Required: []string{"test_case_hash"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "get_test_case_history_by_hash", func(ctx context.Context, request *mcp.CallToolRequest, args GetTestCaseHistoryByHashArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if args.TestCaseHash == nil {
+				return nil, nil, fmt.Errorf("test_case_hash is required")
+			}
+			historyDepth := args.HistoryDepth
+			if historyDepth == 0 {
+				historyDepth = testCaseHistoryDefaultDepth
+			}
+			if historyDepth < 1 || historyDepth > testCaseHistoryMaxDepth {
+				return nil, nil, fmt.Errorf(
+					"history_depth must be between 1 and %d, got %d",
+					testCaseHistoryMaxDepth,
+					historyDepth,
+				)
+			}
+
+			// Launches to anchor the history on, newest first
+			var launchIDs []int64
+			launches := make(map[int64]openapi.ComEpamReportportalBaseReportingLaunchResource)
+			if args.LaunchID > 0 {
+				launchIDs = append(launchIDs, int64(args.LaunchID))
+			} else {
+				apiRequest, err := utils.ApplyPaginationOptions(
+					lr.client.LaunchAPI.GetProjectLaunches(ctx, project),
+					lr.settings.Pagination,
+					utils.FirstPage,
+					testCaseHistoryScanLaunches,
+					utils.DefaultSortingForLaunches,
+					utils.DefaultSortingForLaunches,
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+				latest, response, err := apiRequest.Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+				for _, launch := range latest.Content {
+					launchIDs = append(launchIDs, launch.Id)
+					launches[launch.Id] = launch
+				}
+			}
+
+			var occurrences []testCaseOccurrence
+			for _, launchID := range launchIDs {
+				history, response, err := lr.client.TestItemAPI.GetItemsHistory(ctx, project).
+					FilterEqLaunchId(int32(launchID)). //nolint:gosec // launch IDs fit into int32 on the RP side
+					FilterEqTestCaseHash(*args.TestCaseHash).
+					HistoryDepth(historyDepth).
+					Type_("table").
+					Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+				for _, element := range history.Content {
+					for i := range element.Resources {
+						item := &element.Resources[i]
+						if item.TestCaseHash != nil && *item.TestCaseHash != *args.TestCaseHash {
+							continue
+						}
+						occurrences = append(occurrences, newTestCaseOccurrence(item))
+					}
+				}
+				if len(occurrences) > 0 {
+					break
+				}
+			}
+
+			result := map[string]any{
+				"test_case_hash": *args.TestCaseHash,
+				"history_depth":  historyDepth,
+			}
+			if len(occurrences) == 0 {
+				result["message"] = "no test item with this test_case_hash found"
+				if args.LaunchID == 0 {
+					result["message"] = fmt.Sprintf(
+						"no test item with this test_case_hash found in the latest %d launches",
+						len(launchIDs),
+					)
+				}
+				result["occurrences"] = []testCaseOccurrence{}
+			} else {
+				var missing []int64
+				for _, occurrence := range occurrences {
+					if _, ok := launches[occurrence.LaunchID]; !ok &&
+						!slices.Contains(missing, occurrence.LaunchID) {
+						missing = append(missing, occurrence.LaunchID)
+					}
+				}
+				fetched, err := lr.getLaunchesByID(ctx, project, missing)
+				if err != nil {
+					return nil, nil, err
+				}
+				for i := range occurrences {
+					launch, ok := launches[occurrences[i].LaunchID]
+					if !ok {
+						launch, ok = fetched[occurrences[i].LaunchID]
+					}
+					if ok {
+						occurrences[i].LaunchNumber = launch.Number
+						occurrences[i].LaunchName = launch.Name
+					}
+				}
+				slices.SortStableFunc(occurrences, func(a, b testCaseOccurrence) int {
+					if a.StartTime == nil || b.StartTime == nil {
+						return 0
+					}
+					return b.StartTime.Compare(*a.StartTime)
+				})
+				result["occurrences"] = occurrences
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
+
+const (
+	// failureContextDefaultLines is the number of logs returned on each side of the first error
+	failureContextDefaultLines = 10
+	// failureContextMaxLines caps context_lines to keep the result small
+	failureContextMaxLines = 100
+	// failureContextPageSize is the number of logs requested per page while scanning for the error
+	failureContextPageSize = 300
+	// failureContextMaxPages bounds the scan for items with very large log sets
+	failureContextMaxPages = 20
+)
+
+// failureLogLevels are the log levels that mark the failure point of a test item
+var failureLogLevels = []string{"ERROR", "FATAL"}
+
+// GetFailureContextLogsArgs holds params for get_failure_context_logs.
+type GetFailureContextLogsArgs struct {
+	ProjectKey   string `json:"projectKey"`
+	TestItemID   int64  `json:"test_item_id"`
+	ContextLines *int   `json:"context_lines"`
+}
+
+// failureContextWindow collects the logs around the first ERROR/FATAL log while they are scanned in
+// logTime order. Only the last contextLines logs before the error are kept.
+type failureContextWindow struct {
+	contextLines int
+	scanned      int
+	before       []openapi.ComEpamReportportalBaseModelLogLogResource
+	errorLog     *openapi.ComEpamReportportalBaseModelLogLogResource
+	after        []openapi.ComEpamReportportalBaseModelLogLogResource
+}
+
+// add consumes the next log and reports whether the window is complete
+func (w *failureContextWindow) add(entry openapi.ComEpamReportportalBaseModelLogLogResource) bool {
+	w.scanned++
+	switch {
+	case w.errorLog != nil:
+		w.after = append(w.after, entry)
+	case slices.ContainsFunc(failureLogLevels, func(level string) bool {
+		return strings.EqualFold(entry.GetLevel(), level)
+	}):
+		w.errorLog = &entry
+	default:
+		w.before = append(w.before, entry)
+		if len(w.before) > w.contextLines {
+			w.before = w.before[1:]
+		}
+	}
+	return w.errorLog != nil && len(w.after) >= w.contextLines
+}
+
+// toolGetFailureContextLogs creates a tool that returns the logs around the first error of a test item
+func (lr *TestItemResources) toolGetFailureContextLogs() (*mcp.Tool, ToolHandler[GetFailureContextLogsArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_failure_context_logs",
+			Description: "Get the logs around the failure of a test item: finds its first ERROR or FATAL log " +
+				"(by logTime) and returns it together with the context_lines logs before and after it, " +
+				"instead of the whole log set",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"test_item_id": {
+						Type:        "integer",
+						Description: "Test item ID",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+					"context_lines": {
+						Type:        "integer",
+						Description: "Number of logs to return on each side of the first error",
+						Default:     mustMarshalJSON(failureContextDefaultLines),
+						Minimum:     openapi.PtrFloat64(0),
+						Maximum:     openapi.PtrFloat64(failureContextMaxLines),
+					},
+				},
+				Required: []string{"test_item_id"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "get_failure_context_logs", func(ctx context.Context, request *mcp.CallToolRequest, args GetFailureContextLogsArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if args.TestItemID <= 0 {
+				return nil, nil, fmt.Errorf("test_item_id is required")
+			}
+			contextLines := failureContextDefaultLines
+			if args.ContextLines != nil {
+				contextLines = *args.ContextLines
+			}
+			if contextLines < 0 || contextLines > failureContextMaxLines {
+				return nil, nil, fmt.Errorf(
+					"context_lines must be between 0 and %d, got %d",
+					failureContextMaxLines,
+					contextLines,
+				)
+			}
+
+			window := &failureContextWindow{contextLines: contextLines}
+			complete, truncated := false, false
+			for page := uint(utils.FirstPage); !complete; page++ {
+				if page > failureContextMaxPages {
+					truncated = true
+					break
+				}
+				apiRequest, err := utils.ApplyPaginationOptions(
+					lr.client.LogAPI.GetLogs(ctx, project).
+						FilterEqItem(int32(args.TestItemID)), //nolint:gosec // item IDs fit into int32 on the RP side
+					lr.settings.Pagination,
+					page,
+					failureContextPageSize,
+					utils.DefaultSortingForLogs,
+					utils.DefaultSortingForLogs,
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+				logs, response, err := apiRequest.Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				for _, logEntry := range logs.Content {
+					if complete = window.add(logEntry); complete {
+						break
+					}
+				}
+				if len(logs.Content) == 0 || logs.Page == nil || !logs.Page.GetHasNext() {
+					break
+				}
+			}
+
+			result := map[string]any{
+				"test_item_id":  args.TestItemID,
+				"context_lines": contextLines,
+				"scanned_logs":  window.scanned,
+			}
+			if window.errorLog == nil {
+				result["message"] = "no ERROR or FATAL log found for the test item"
+				if truncated {
+					result["message"] = fmt.Sprintf(
+						"no ERROR or FATAL log found in the first %d logs of the test item",
+						window.scanned,
+					)
+				}
+			} else {
+				result["error_log"] = window.errorLog
+				result["logs_before"] = window.before
+				result["logs_after"] = window.after
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
+
+const (
+	// failureSummaryMaxItems caps the failed items summarized in one call
+	failureSummaryMaxItems = 100
+	// failureSummaryConcurrency bounds the parallel first-error log lookups
+	failureSummaryConcurrency = 5
+	// failureSummaryDefaultMessageLength is the default number of characters kept per error message
+	failureSummaryDefaultMessageLength = 300
+	// failureSummaryMaxMessageLength caps max_message_length to keep the digest compact
+	failureSummaryMaxMessageLength = 5000
+)
+
+// GetLaunchFailureSummaryArgs holds params for get_launch_failure_summary.
+type GetLaunchFailureSummaryArgs struct {
+	ProjectKey       string `json:"projectKey"`
+	LaunchID         uint32 `json:"launch_id"`
+	MaxMessageLength *int   `json:"max_message_length"`
+}
+
+// failureSummaryItem is one failed test item of the get_launch_failure_summary digest
+type failureSummaryItem struct {
+	TestItemID       int64  `json:"test_item_id"`
+	Name             string `json:"name"`
+	DefectType       string `json:"defect_type,omitempty"`
+	ErrorMessage     string `json:"error_message,omitempty"`
+	MessageTruncated bool   `json:"message_truncated,omitempty"`
+	Error            string `json:"error,omitempty"` // set when the logs of the item could not be read
+}
+
+// truncateMessage shortens message to at most maxLength characters
+func truncateMessage(message string, maxLength int) (string, bool) {
+	runes := []rune(message)
+	if len(runes) <= maxLength {
+		return message, false
+	}
+	return string(runes[:maxLength]) + "…", true
+}
+
+// firstErrorLogMessage returns the message of the earliest ERROR-or-higher log of a test item,
+// or an empty string if the item has none
+func (lr *TestItemResources) firstErrorLogMessage(
+	ctx context.Context,
+	project string,
+	itemID int64,
+) (string, error) {
+	ctxWithParams := utils.WithQueryParams(ctx, url.Values{
+		"filter.gte.level": {"ERROR"},
+	})
+	apiRequest, err := utils.ApplyPaginationOptions(
+		lr.client.LogAPI.GetLogs(ctxWithParams, project).
+			FilterEqItem(int32(itemID)), //nolint:gosec // item IDs fit into int32 on the RP side
+		lr.settings.Pagination,
+		utils.FirstPage,
+		1,
+		utils.DefaultSortingForLogs,
+		utils.DefaultSortingForLogs,
+	)
+	if err != nil {
+		return "", err
+	}
+	logs, response, err := apiRequest.Execute()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+	}
+	if len(logs.Content) == 0 {
+		return "", nil
+	}
+	return logs.Content[0].GetMessage(), nil
+}
+
+// fetchFailedLaunchItems returns the first limit failed test items of a launch
+func (lr *TestItemResources) fetchFailedLaunchItems(
+	ctx context.Context,
+	project string,
+	launchID uint32,
+	limit uint,
+) (*openapi.ComEpamReportportalBaseModelPageComEpamReportportalBaseReportingTestItemResource, error) {
+	return lr.fetchLaunchItemsByStatus(ctx, project, launchID, "FAILED", limit)
+}
+
+// fetchLaunchItemsByStatus returns the first limit test items of a launch with the given status
+func (lr *TestItemResources) fetchLaunchItemsByStatus(
+	ctx context.Context,
+	project string,
+	launchID uint32,
+	status string,
+	limit uint,
+) (*openapi.ComEpamReportportalBaseModelPageComEpamReportportalBaseReportingTestItemResource, error) {
+	launchIDStr := strconv.FormatUint(uint64(launchID), 10)
+	ctxWithParams := utils.WithQueryParams(ctx, url.Values{
+		"launchId":              {launchIDStr},
+		"providerType":          {utils.DefaultProviderType},
+		"filter.eq.hasStats":    {utils.DefaultFilterEqHasStats},
+		"filter.eq.hasChildren": {utils.DefaultFilterEqHasChildren},
+		"filter.in.type":        {utils.DefaultFilterInType},
+		"filter.in.status":      {status},
+	})
+	apiRequest, err := utils.ApplyPaginationOptions(
+		lr.client.TestItemAPI.GetTestItemsV2(ctxWithParams, project).
+			Params(map[string]string{"launchId": launchIDStr}),
+		lr.settings.Pagination,
+		utils.FirstPage,
+		limit,
+		utils.DefaultSortingForItems,
+		utils.DefaultSortingForItems,
+	)
+	if err != nil {
+		return nil, err
+	}
+	itemsPage, response, err := apiRequest.Execute()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+	}
+	return itemsPage, nil
+}
+
+// itemsPageTotal returns the number of matching items reported by the page metadata
+func itemsPageTotal(
+	itemsPage *openapi.ComEpamReportportalBaseModelPageComEpamReportportalBaseReportingTestItemResource,
+) int64 {
+	if itemsPage.Page != nil && itemsPage.Page.TotalElements != nil {
+		return *itemsPage.Page.TotalElements
+	}
+	return int64(len(itemsPage.Content))
+}
+
+// toolGetLaunchFailureSummary creates a tool that lists the failed items of a launch with their first error
+func (lr *TestItemResources) toolGetLaunchFailureSummary() (*mcp.Tool, ToolHandler[GetLaunchFailureSummaryArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_launch_failure_summary",
+			Description: "Get a compact triage digest of a launch: lists its failed test items (up to " +
+				fmt.Sprintf("%d) ", failureSummaryMaxItems) +
+				"with the defect type and only the first ERROR log message of each, truncated to " +
+				"max_message_length characters, instead of all logs",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+					"max_message_length": {
+						Type:        "integer",
+						Description: "Maximum number of characters kept of each error message",
+						Default:     mustMarshalJSON(failureSummaryDefaultMessageLength),
+						Minimum:     openapi.PtrFloat64(1),
+						Maximum:     openapi.PtrFloat64(failureSummaryMaxMessageLength),
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "get_launch_failure_summary", func(ctx context.Context, request *mcp.CallToolRequest, args GetLaunchFailureSummaryArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if args.LaunchID == 0 {
+				return nil, nil, fmt.Errorf("launch_id is required")
+			}
+			maxMessageLength := failureSummaryDefaultMessageLength
+			if args.MaxMessageLength != nil {
+				maxMessageLength = *args.MaxMessageLength
+			}
+			if maxMessageLength < 1 || maxMessageLength > failureSummaryMaxMessageLength {
+				return nil, nil, fmt.Errorf(
+					"max_message_length must be between 1 and %d, got %d",
+					failureSummaryMaxMessageLength,
+					maxMessageLength,
+				)
+			}
+
+			itemsPage, err := lr.fetchFailedLaunchItems(
+				ctx,
+				project,
+				args.LaunchID,
+				failureSummaryMaxItems,
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			items := make([]failureSummaryItem, len(itemsPage.Content))
+			for i := range itemsPage.Content {
+				item := &itemsPage.Content[i]
+				items[i] = failureSummaryItem{TestItemID: item.GetId(), Name: item.GetName()}
+				if item.Issue != nil {
+					items[i].DefectType = item.Issue.IssueType
+				}
+			}
+			errs := forEachBounded(ctx, len(items), failureSummaryConcurrency, func(i int) error {
+				message, err := lr.firstErrorLogMessage(ctx, project, items[i].TestItemID)
+				if err != nil {
+					return err
+				}
+				items[i].ErrorMessage, items[i].MessageTruncated = truncateMessage(
+					message,
+					maxMessageLength,
+				)
+				return nil
+			})
+			for i, err := range errs {
+				if err != nil {
+					items[i].Error = err.Error()
+				}
+			}
+
+			totalFailed := itemsPageTotal(itemsPage)
+			result := map[string]any{
+				"launch_id":    args.LaunchID,
+				"failed_items": totalFailed,
+				"items":        items,
+			}
+			if totalFailed > int64(len(items)) {
+				result["message"] = fmt.Sprintf(
+					"showing the first %d of %d failed items",
+					len(items),
+					totalFailed,
+				)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
+
+const (
+	// logsByItemDefaultMaxItems is the default number of items whose logs get_logs_grouped_by_item returns
+	logsByItemDefaultMaxItems = 20
+	// logsByItemMaxItems caps max_items of get_logs_grouped_by_item
+	logsByItemMaxItems = 100
+	// logsByItemDefaultMaxLogs is the default number of logs returned per item
+	logsByItemDefaultMaxLogs = 20
+	// logsByItemMaxLogs caps max_logs_per_item of get_logs_grouped_by_item
+	logsByItemMaxLogs = 300
+	// logsByItemConcurrency bounds the parallel per-item log lookups
+	logsByItemConcurrency = 5
+)
+
+// GetLogsGroupedByItemArgs holds params for get_logs_grouped_by_item.
+type GetLogsGroupedByItemArgs struct {
+	ProjectKey     string `json:"projectKey"`
+	LaunchID       uint32 `json:"launch_id"`
+	Status         string `json:"status"`
+	MaxItems       *int   `json:"max_items"`
+	MaxLogsPerItem *int   `json:"max_logs_per_item"`
+}
+
+// itemLogs is the log group of one test item in the get_logs_grouped_by_item result
+type itemLogs struct {
+	Name          string                                               `json:"name"`
+	Logs          []openapi.ComEpamReportportalBaseModelLogLogResource `json:"logs"`
+	LogsTruncated bool                                                 `json:"logs_truncated,omitempty"`
+	Error         string                                               `json:"error,omitempty"` // set when the logs of the item could not be read
+}
+
+// fetchItemLogs returns the first limit logs of a test item in logTime order and whether the item
+// has more logs
+func (lr *TestItemResources) fetchItemLogs(
+	ctx context.Context,
+	project string,
+	itemID int64,
+	limit uint,
+) ([]openapi.ComEpamReportportalBaseModelLogLogResource, bool, error) {
+	apiRequest, err := utils.ApplyPaginationOptions(
+		lr.client.LogAPI.GetLogs(ctx, project).
+			FilterEqItem(int32(itemID)), //nolint:gosec // item IDs fit into int32 on the RP side
+		lr.settings.Pagination,
+		utils.FirstPage,
+		limit,
+		utils.DefaultSortingForLogs,
+		utils.DefaultSortingForLogs,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	logs, response, err := apiRequest.Execute()
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+	}
+	hasMore := logs.Page != nil && logs.Page.GetHasNext()
+	return logs.Content, hasMore, nil
+}
+
+// toolGetLogsGroupedByItem creates a tool that returns the logs of the matching test items of a
+// launch, keyed by test item ID
+func (lr *TestItemResources) toolGetLogsGroupedByItem() (*mcp.Tool, ToolHandler[GetLogsGroupedByItemArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	statusEnum := make([]any, 0, len(testItemFilterStatuses))
+	for _, status := range testItemFilterStatuses {
+		statusEnum = append(statusEnum, status)
+	}
+
+	return &mcp.Tool{
+			Name: "get_logs_grouped_by_item",
+			Description: "Get the logs of the test items of a launch that have the given status, grouped by item: " +
+				"returns an object mapping each test item ID to its name and logs (in logTime order), so it is " +
+				"clear which log belongs to which item. Both the number of items and the logs per item are capped",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+					"status": {
+						Type:        "string",
+						Description: "Status of the test items whose logs are returned",
+						Enum:        statusEnum,
+						Default:     mustMarshalJSON("FAILED"),
+					},
+					"max_items": {
+						Type:        "integer",
+						Description: "Maximum number of test items whose logs are returned",
+						Default:     mustMarshalJSON(logsByItemDefaultMaxItems),
+						Minimum:     openapi.PtrFloat64(1),
+						Maximum:     openapi.PtrFloat64(logsByItemMaxItems),
+					},
+					"max_logs_per_item": {
+						Type:        "integer",
+						Description: "Maximum number of logs returned per test item",
+						Default:     mustMarshalJSON(logsByItemDefaultMaxLogs),
+						Minimum:     openapi.PtrFloat64(1),
+						Maximum:     openapi.PtrFloat64(logsByItemMaxLogs),
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "get_logs_grouped_by_item", func(ctx context.Context, request *mcp.CallToolRequest, args GetLogsGroupedByItemArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if args.LaunchID == 0 {
+				return nil, nil, fmt.Errorf("launch_id is required")
+			}
+			status := strings.ToUpper(strings.TrimSpace(args.Status))
+			if status == "" {
+				status = "FAILED"
+			}
+			if !slices.Contains(testItemFilterStatuses, status) {
+				return nil, nil, fmt.Errorf(
+					"invalid status %q: must be one of %s",
+					args.Status,
+					strings.Join(testItemFilterStatuses, ", "),
+				)
+			}
+			maxItems := logsByItemDefaultMaxItems
+			if args.MaxItems != nil {
+				maxItems = *args.MaxItems
+			}
+			if maxItems < 1 || maxItems > logsByItemMaxItems {
+				return nil, nil, fmt.Errorf(
+					"max_items must be between 1 and %d, got %d",
+					logsByItemMaxItems,
+					maxItems,
+				)
+			}
+			maxLogs := logsByItemDefaultMaxLogs
+			if args.MaxLogsPerItem != nil {
+				maxLogs = *args.MaxLogsPerItem
+			}
+			if maxLogs < 1 || maxLogs > logsByItemMaxLogs {
+				return nil, nil, fmt.Errorf(
+					"max_logs_per_item must be between 1 and %d, got %d",
+					logsByItemMaxLogs,
+					maxLogs,
+				)
+			}
+
+			itemsPage, err := lr.fetchLaunchItemsByStatus(
+				ctx,
+				project,
+				args.LaunchID,
+				status,
+				uint(maxItems), //nolint:gosec // validated above
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			groups := make([]itemLogs, len(itemsPage.Content))
+			errs := forEachBounded(ctx, len(groups), logsByItemConcurrency, func(i int) error {
+				item := &itemsPage.Content[i]
+				groups[i].Name = item.GetName()
+				logs, hasMore, err := lr.fetchItemLogs(
+					ctx,
+					project,
+					item.GetId(),
+					uint(maxLogs), //nolint:gosec // validated above
+				)
+				if err != nil {
+					return err
+				}
+				groups[i].Logs, groups[i].LogsTruncated = logs, hasMore
+				return nil
+			})
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+
+			logsByItem := make(map[string]itemLogs, len(groups))
+			for i := range groups {
+				if errs[i] != nil {
+					groups[i].Error = errs[i].Error()
+				}
+				if groups[i].Logs == nil {
+					groups[i].Logs = []openapi.ComEpamReportportalBaseModelLogLogResource{}
+				}
+				logsByItem[strconv.FormatInt(itemsPage.Content[i].GetId(), 10)] = groups[i]
+			}
+
+			total := itemsPageTotal(itemsPage)
+			result := map[string]any{
+				"launch_id": args.LaunchID,
+				"status":    status,
+				"items":     logsByItem,
+			}
+			if total > int64(len(groups)) {
+				result["message"] = fmt.Sprintf(
+					"showing the logs of the first %d of %d %s items",
+					len(groups),
+					total,
+					status,
+				)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
+
+const (
+	// uniqueFailuresDefaultMaxItems is the default number of failed items scanned for messages
+	uniqueFailuresDefaultMaxItems = 100
+	// uniqueFailuresMaxExamples caps the example item IDs returned per distinct message
+	uniqueFailuresMaxExamples = 5
+)
+
+// numbersPattern matches the digit runs stripped from messages when remove_numbers is set
+var numbersPattern = regexp.MustCompile(`[0-9]+`)
+
+// GetUniqueFailureMessagesArgs holds params for get_unique_failure_messages.
+type GetUniqueFailureMessagesArgs struct {
+	ProjectKey       string `json:"projectKey"`
+	LaunchID         uint32 `json:"launch_id"`
+	RemoveNumbers    bool   `json:"remove_numbers"`
+	MaxItems         *int   `json:"max_items"`
+	MaxMessageLength *int   `json:"max_message_length"`
+}
+
+// uniqueFailureMessage is one distinct error message of get_unique_failure_messages
+type uniqueFailureMessage struct {
+	Message          string  `json:"message"`
+	MessageTruncated bool    `json:"message_truncated,omitempty"`
+	Count            int     `json:"count"`
+	ExampleItemIDs   []int64 `json:"example_item_ids"`
+}
+
+// normalizeFailureMessage collapses whitespace of an error message and optionally strips numbers,
+// so that messages differing only in IDs, timings or line numbers are counted together
+func normalizeFailureMessage(message string, removeNumbers bool) string {
+	if removeNumbers {
+		message = numbersPattern.ReplaceAllString(message, "")
+	}
+	return strings.Join(strings.Fields(message), " ")
+}
+
+// toolGetUniqueFailureMessages creates a tool that deduplicates the first error messages of the
+// failed items of a launch
+func (lr *TestItemResources) toolGetUniqueFailureMessages() (*mcp.Tool, ToolHandler[GetUniqueFailureMessagesArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_unique_failure_messages",
+			Description: "Get the distinct failure messages of a launch: reads the first ERROR log message " +
+				"of its failed test items, normalizes and deduplicates them and returns each distinct " +
+				"message with its occurrence count and example item IDs, most frequent first",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+					"remove_numbers": {
+						Type:        "boolean",
+						Description: "Strip numbers from the messages before comparing them, like remove_numbers of run_unique_error_analysis",
+						Default:     mustMarshalJSON(false),
+					},
+					"max_items": {
+						Type:        "integer",
+						Description: "Maximum number of failed test items scanned",
+						Default:     mustMarshalJSON(uniqueFailuresDefaultMaxItems),
+						Minimum:     openapi.PtrFloat64(1),
+						Maximum:     openapi.PtrFloat64(utils.MaxDefaultPageSize),
+					},
+					"max_message_length": {
+						Type:        "integer",
+						Description: "Maximum number of characters returned of each distinct message",
+						Default:     mustMarshalJSON(failureSummaryDefaultMessageLength),
+						Minimum:     openapi.PtrFloat64(1),
+						Maximum:     openapi.PtrFloat64(failureSummaryMaxMessageLength),
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "get_unique_failure_messages", func(ctx context.Context, request *mcp.CallToolRequest, args GetUniqueFailureMessagesArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if args.LaunchID == 0 {
+				return nil, nil, fmt.Errorf("launch_id is required")
+			}
+			maxItems := uniqueFailuresDefaultMaxItems
+			if args.MaxItems != nil {
+				maxItems = *args.MaxItems
+			}
+			if maxItems < 1 || maxItems > utils.MaxDefaultPageSize {
+				return nil, nil, fmt.Errorf(
+					"max_items must be between 1 and %d, got %d",
+					utils.MaxDefaultPageSize,
+					maxItems,
+				)
+			}
+			maxMessageLength := failureSummaryDefaultMessageLength
+			if args.MaxMessageLength != nil {
+				maxMessageLength = *args.MaxMessageLength
+			}
+			if maxMessageLength < 1 || maxMessageLength > failureSummaryMaxMessageLength {
+				return nil, nil, fmt.Errorf(
+					"max_message_length must be between 1 and %d, got %d",
+					failureSummaryMaxMessageLength,
+					maxMessageLength,
+				)
+			}
+
+			itemsPage, err := lr.fetchFailedLaunchItems(
+				ctx,
+				project,
+				args.LaunchID,
+				uint(maxItems),
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			messages := make([]string, len(itemsPage.Content))
+			errs := forEachBounded(ctx, len(messages), failureSummaryConcurrency, func(i int) error {
+				message, err := lr.firstErrorLogMessage(ctx, project, itemsPage.Content[i].GetId())
+				messages[i] = message
+				return err
+			})
+
+			var (
+				distinct      = make([]*uniqueFailureMessage, 0)
+				byMessage     = make(map[string]*uniqueFailureMessage)
+				withoutErrors []int64
+				lookupErrors  []map[string]any
+			)
+			for i, message := range messages {
+				itemID := itemsPage.Content[i].GetId()
+				if errs[i] != nil {
+					lookupErrors = append(lookupErrors, map[string]any{
+						"test_item_id": itemID,
+						"error":        errs[i].Error(),
+					})
+					continue
+				}
+				normalized := normalizeFailureMessage(message, args.RemoveNumbers)
+				if normalized == "" {
+					withoutErrors = append(withoutErrors, itemID)
+					continue
+				}
+				entry, ok := byMessage[normalized]
+				if !ok {
+					entry = &uniqueFailureMessage{}
+					entry.Message, entry.MessageTruncated = truncateMessage(
+						normalized,
+						maxMessageLength,
+					)
+					byMessage[normalized] = entry
+					distinct = append(distinct, entry)
+				}
+				entry.Count++
+				if len(entry.ExampleItemIDs) < uniqueFailuresMaxExamples {
+					entry.ExampleItemIDs = append(entry.ExampleItemIDs, itemID)
+				}
+			}
+			// Most frequent first; ties keep the order of the first occurrence
+			slices.SortStableFunc(distinct, func(a, b *uniqueFailureMessage) int {
+				return b.Count - a.Count
+			})
+
+			totalFailed := itemsPageTotal(itemsPage)
+			result := map[string]any{
+				"launch_id":     args.LaunchID,
+				"failed_items":  totalFailed,
+				"items_scanned": len(messages),
+				"messages":      distinct,
+				"total":         len(distinct),
+			}
+			if len(withoutErrors) > 0 {
+				result["items_without_error_log"] = withoutErrors
+			}
+			if len(lookupErrors) > 0 {
+				result["errors"] = lookupErrors
+			}
+			if totalFailed > int64(len(messages)) {
+				result["message"] = fmt.Sprintf(
+					"only the first %d of %d failed items were scanned",
+					len(messages),
+					totalFailed,
+				)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
+
+// flakyItemsMaxItems caps the retried items of a launch checked by get_flaky_items
+const flakyItemsMaxItems = 100
+
+// flakyItem is a test item whose retries ended with different statuses
+type flakyItem struct {
+	ID             int64    `json:"id"`
+	Name           string   `json:"name"`
+	Status         string   `json:"status"`
+	Retries        int      `json:"retries"`
+	StatusSequence []string `json:"status_sequence"` // retries in start order, final attempt last
+}
+
+// retryStatusSequence returns the statuses of the retries in start order followed by the status of
+// the final attempt, and whether any retry ended differently from the final attempt
+func retryStatusSequence(
+	item openapi.ComEpamReportportalBaseReportingTestItemResource,
+	retries []openapi.ComEpamReportportalBaseReportingTestItemResource,
+) ([]string, bool) {
+	retries = slices.Clone(retries)
+	slices.SortStableFunc(retries, func(a, b openapi.ComEpamReportportalBaseReportingTestItemResource) int {
+		return a.GetStartTime().Compare(b.GetStartTime())
+	})
+
+	sequence := make([]string, 0, len(retries)+1)
+	flaky := false
+	for _, retry := range retries {
+		sequence = append(sequence, retry.GetStatus())
+		flaky = flaky || retry.GetStatus() != item.GetStatus()
+	}
+	return append(sequence, item.GetStatus()), flaky
+}
+
+// toolGetFlakyItems creates a tool that lists the test items of a launch whose retries ended with
+// different statuses
+func (lr *TestItemResources) toolGetFlakyItems() (*mcp.Tool, ToolHandler[LaunchIDArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_flaky_items",
+			Description: "Get the flaky test items of a launch: items with retries where at least one retry " +
+				"ended with a different status than the final attempt, e.g. failed and then passed on " +
+				"retry. Returns each item with its status sequence, final attempt last. " +
+				fmt.Sprintf("At most %d items with retries are checked", flakyItemsMaxItems),
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "get_flaky_items", func(ctx context.Context, request *mcp.CallToolRequest, args LaunchIDArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if args.LaunchID == 0 {
+				return nil, nil, fmt.Errorf("launch_id is required")
+			}
+
+			launchIDStr := strconv.FormatUint(uint64(args.LaunchID), 10)
+			ctxWithParams := utils.WithQueryParams(ctx, url.Values{
+				"launchId":              {launchIDStr},
+				"providerType":          {utils.DefaultProviderType},
+				"filter.eq.hasStats":    {utils.DefaultFilterEqHasStats},
+				"filter.eq.hasChildren": {utils.DefaultFilterEqHasChildren},
+				"filter.in.type":        {utils.DefaultFilterInType},
+			})
+			apiRequest, err := utils.ApplyPaginationOptions(
+				lr.client.TestItemAPI.GetTestItemsV2(ctxWithParams, project).
+					Params(map[string]string{"launchId": launchIDStr}).
+					FilterEqHasRetries(true),
+				lr.settings.Pagination,
+				utils.FirstPage,
+				flakyItemsMaxItems,
+				utils.DefaultSortingForItems,
+				utils.DefaultSortingForItems,
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+			itemsPage, response, err := apiRequest.Execute()
+			if err != nil {
+				return nil, nil, fmt.Errorf(
+					"%s: %w",
+					utils.ExtractResponseError(err, response),
+					err,
+				)
+			}
+
+			retried := itemsPage.Content
+			retries := make([][]openapi.ComEpamReportportalBaseReportingTestItemResource, len(retried))
+			errs := forEachBounded(ctx, len(retried), expandRetriesConcurrency, func(i int) error {
+				itemRetries, err := lr.fetchItemRetries(ctx, project, retried[i].GetUuid())
+				retries[i] = itemRetries
+				return err
+			})
+
+			flaky := []flakyItem{}
+			var lookupErrors []map[string]any
+			for i, item := range retried {
+				if errs[i] != nil {
+					lookupErrors = append(lookupErrors, map[string]any{
+						"test_item_id": item.GetId(),
+						"error":        errs[i].Error(),
+					})
+					continue
+				}
+				sequence, isFlaky := retryStatusSequence(item, retries[i])
+				if !isFlaky {
+					continue
+				}
+				flaky = append(flaky, flakyItem{
+					ID:             item.GetId(),
+					Name:           item.GetName(),
+					Status:         item.GetStatus(),
+					Retries:        len(retries[i]),
+					StatusSequence: sequence,
+				})
+			}
+
+			totalRetried := itemsPageTotal(itemsPage)
+			result := map[string]any{
+				"launch_id":          args.LaunchID,
+				"flaky_items":        flaky,
+				"total":              len(flaky),
+				"items_with_retries": totalRetried,
+				"items_checked":      len(retried),
+			}
+			if len(lookupErrors) > 0 {
+				result["errors"] = lookupErrors
+			}
+			if totalRetried > int64(len(retried)) {
+				result["message"] = fmt.Sprintf(
+					"only the first %d of %d items with retries were checked",
+					len(retried),
+					totalRetried,
+				)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
+
+const (
+	// nestedStepsMaxItems caps the steps returned by get_nested_steps
+	nestedStepsMaxItems = 1000
+	// nestedStepsMaxDepth bounds how deep recursive get_nested_steps calls descend
+	nestedStepsMaxDepth = 10
+)
+
+// GetNestedStepsArgs holds params for get_nested_steps.
+type GetNestedStepsArgs struct {
+	ProjectKey   string `json:"projectKey"`
+	ParentItemID int64  `json:"parent_item_id"`
+	Recursive    bool   `json:"recursive"`
+}
+
+// nestedStep is one STEP item returned by get_nested_steps
+type nestedStep struct {
+	ID          int64      `json:"id"`
+	ParentID    int64      `json:"parent_id"`
+	Depth       int        `json:"depth"` // 1 for direct children of parent_item_id
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	StartTime   *time.Time `json:"start_time,omitempty"`
+	HasChildren bool       `json:"has_children"`
+	DefectType  string     `json:"defect_type,omitempty"`
+}
+
+// fetchChildSteps returns all STEP children of a test item, including nested steps without statistics
+func (lr *TestItemResources) fetchChildSteps(
+	ctx context.Context,
+	project, launchID string,
+	parentID int64,
+	maxItems int,
+) ([]openapi.ComEpamReportportalBaseReportingTestItemResource, error) {
+	ctxWithParams := utils.WithQueryParams(ctx, url.Values{
+		"launchId":           {launchID},
+		"providerType":       {utils.DefaultProviderType},
+		"filter.eq.parentId": {strconv.FormatInt(parentID, 10)},
+		"filter.in.type":     {utils.DefaultFilterInType},
+	})
+
+	var steps []openapi.ComEpamReportportalBaseReportingTestItemResource
+	for page := uint(utils.FirstPage); len(steps) < maxItems; page++ {
+		apiRequest, err := utils.ApplyPaginationOptions(
+			lr.client.TestItemAPI.GetTestItemsV2(ctxWithParams, project).
+				Params(map[string]string{"launchId": launchID}),
+			lr.settings.Pagination,
+			page,
+			utils.DefaultPageSize,
+			utils.DefaultSortingForSuites,
+			utils.DefaultSortingForSuites,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		stepsPage, response, err := apiRequest.Execute()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+		}
+		steps = append(steps, stepsPage.Content...)
+		if len(stepsPage.Content) < utils.DefaultPageSize {
+			break
+		}
+		if hasNext, ok := stepsPage.Page.GetHasNextOk(); ok && !*hasNext {
+			break
+		}
+	}
+	return steps, nil
+}
+
+// toolGetNestedSteps creates a tool that lists the STEP children of a test item
+func (lr *TestItemResources) toolGetNestedSteps() (*mcp.Tool, ToolHandler[GetNestedStepsArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_nested_steps",
+			Description: "Get the STEP children of a test item with their statuses, in execution order, " +
+				"to drill into step-level failures. Returns direct children only unless recursive is " +
+				fmt.Sprintf("true (up to %d steps)", nestedStepsMaxItems),
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"parent_item_id": {
+						Type:        "integer",
+						Description: "ID of the test item whose steps are returned",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+					"recursive": {
+						Type: "boolean",
+						Description: "Also return the steps nested under the child steps, " +
+							fmt.Sprintf("up to %d levels deep", nestedStepsMaxDepth),
+						Default: mustMarshalJSON(false),
+					},
+				},
+				Required: []string{"parent_item_id"},
+			},
+		}, utils.WithAnalytics(lr.analytics, "get_nested_steps", func(ctx context.Context, request *mcp.CallToolRequest, args GetNestedStepsArgs) (*mcp.CallToolResult, any, error) {
+			project, err := utils.ExtractProject(ctx, args.ProjectKey)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if args.ParentItemID <= 0 {
+				return nil, nil, fmt.Errorf("parent_item_id is required")
+			}
+
+			// The item list API is scoped to a launch, so resolve the launch of the parent first
+			parent, response, err := lr.client.TestItemAPI.GetTestItem(
+				ctx,
+				strconv.FormatInt(args.ParentItemID, 10),
+				project,
+			).Execute()
+			if err != nil {
+				return nil, nil, fmt.Errorf(
+					"%s: %w",
+					utils.ExtractResponseError(err, response),
+					err,
+				)
+			}
+			launchID := strconv.FormatInt(parent.GetLaunchId(), 10)
+
+			steps := []nestedStep{}
+			truncated := false
+			parents := []int64{args.ParentItemID}
+			for depth := 1; len(parents) > 0 && !truncated; depth++ {
+				var next []int64
+				for _, parentID := range parents {
+					if len(steps) == nestedStepsMaxItems {
+						truncated = true
+						break
+					}
+					children, err := lr.fetchChildSteps(
+						ctx,
+						project,
+						launchID,
+						parentID,
+						nestedStepsMaxItems-len(steps),
+					)
+					if err != nil {
+						return nil, nil, err
+					}
+					for i := range children {
+						if len(steps) == nestedStepsMaxItems {
+							truncated = true
+							break
+						}
+						child := &children[i]
+						step := nestedStep{
+							ID:          child.GetId(),
+							ParentID:    parentID,
+							Depth:       depth,
+							Name:        child.GetName(),
+							Status:      child.GetStatus(),
+							StartTime:   child.StartTime,
+							HasChildren: child.GetHasChildren(),
+						}
+						if child.Issue != nil {
+							step.DefectType = child.Issue.IssueType
+						}
+						steps = append(steps, step)
+						if step.HasChildren {
+							next = append(next, step.ID)
+						}
+					}
+				}
+				if !args.Recursive || depth == nestedStepsMaxDepth {
+					break
+				}
+				parents = next
+			}
+
+			result := map[string]any{
+				"parent_item_id": args.ParentItemID,
+				"launch_id":      parent.GetLaunchId(),
+				"count":          len(steps),
+				"steps":          steps,
+			}
+			if truncated {
+				result["message"] = fmt.Sprintf("showing the first %d steps", nestedStepsMaxItems)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+			}, nil, nil
+		})
+}
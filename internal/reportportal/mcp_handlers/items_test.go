@@ -1,13 +1,29 @@
 package mcphandlers
 
 import (
+	"cmp"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/reportportal/goRP/v5/pkg/gorp"
+	"github.com/reportportal/goRP/v5/pkg/openapi"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
 )
 
 func TestGetDefectTypesFromJson(t *testing.T) {
@@ -188,3 +204,2245 @@ func TestUpdateDefectTypeForTestItemsTool(t *testing.T) {
 	require.NotNil(t, testItemsIDsProp.Items, "test_items_ids must have items property (issue #66)")
 	require.Equal(t, "string", testItemsIDsProp.Items.Type, "items should be of type string")
 }
+
+// TestGetProjectDefectTypesTool_GzipResponse verifies that gzip-encoded ReportPortal replies
+// are decoded before being returned to the client
+func TestGetProjectDefectTypesTool_GzipResponse(t *testing.T) {
+	ctx := context.Background()
+	projectJSON := `{
+		"projectId": 123,
+		"projectName": "test-project",
+		"creationDate": "2024-01-01T00:00:00Z",
+		"configuration": {
+			"attributes": {},
+			"subTypes": {
+				"NO_DEFECT": [{"locator": "nd001", "typeRef": "NO_DEFECT", "longName": "No Defect"}]
+			}
+		}
+	}`
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		_, _ = zw.Write([]byte(projectJSON))
+		_ = zw.Close()
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolGetProjectDefectTypes()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, ProjectKeyArgs{ProjectKey: "test-project"})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+	assert.Contains(t, textContent.Text, "nd001")
+}
+
+// TestUpdateDefectTypeForTestItemsTool_DryRun verifies that dry_run describes the change
+// without calling ReportPortal
+func TestUpdateDefectTypeForTestItemsTool_DryRun(t *testing.T) {
+	ctx := context.Background()
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request in dry-run mode: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolUpdateDefectTypeForTestItems()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, UpdateDefectTypeArgs{
+		ProjectKey:   "test-project",
+		TestItemsIDs: []string{"11", "12"},
+		DefectTypeID: "pb001",
+		DryRun:       true,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+	assert.JSONEq(t, `{
+		"dry_run": true,
+		"tool": "update_defect_type_for_test_items",
+		"change": {
+			"operation": "update_defect_type",
+			"project": "test-project",
+			"test_item_ids": [11, 12],
+			"defect_type_id": "pb001",
+			"defect_type_comment": ""
+		}
+	}`, textContent.Text)
+
+	// Invalid IDs are still rejected in dry-run mode
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, UpdateDefectTypeArgs{
+		ProjectKey:   "test-project",
+		TestItemsIDs: []string{"abc"},
+		DefectTypeID: "pb001",
+		DryRun:       true,
+	})
+	require.Error(t, err)
+}
+
+// TestUpdateDefectTypeForTestItemsTool_Comment verifies that the optional comment is sent with
+// the defect type in the same request and left out of the payload when omitted
+func TestUpdateDefectTypeForTestItemsTool_Comment(t *testing.T) {
+	ctx := context.Background()
+	var issues []map[string]any
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/test-project/item", r.URL.Path)
+		var rq struct {
+			Issues []map[string]any `json:"issues"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&rq))
+		issues = rq.Issues
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolUpdateDefectTypeForTestItems()
+
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, UpdateDefectTypeArgs{
+		ProjectKey:        "test-project",
+		TestItemsIDs:      []string{"11"},
+		DefectTypeID:      "pb001",
+		DefectTypeComment: "Timeout in the payment gateway mock",
+	})
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, float64(11), issues[0]["testItemId"])
+	issue, ok := issues[0]["issue"].(map[string]any)
+	require.True(t, ok, "expected issue object in payload")
+	assert.Equal(t, "pb001", issue["issueType"])
+	assert.Equal(t, "Timeout in the payment gateway mock", issue["comment"])
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, UpdateDefectTypeArgs{
+		ProjectKey:   "test-project",
+		TestItemsIDs: []string{"11"},
+		DefectTypeID: "pb001",
+	})
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	issue, ok = issues[0]["issue"].(map[string]any)
+	require.True(t, ok, "expected issue object in payload")
+	assert.NotContains(t, issue, "comment")
+}
+
+// TestGetBTSIntegrationsTool verifies that only BTS integrations are returned and that
+// credentials from integration parameters are not exposed
+func TestGetBTSIntegrationsTool(t *testing.T) {
+	ctx := context.Background()
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/integration/project/test-project/all", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{
+				"id": 7,
+				"name": "Company Jira",
+				"enabled": true,
+				"integrationType": {"name": "jira", "groupType": "BTS"},
+				"integrationParameters": {
+					"url": "https://jira.example.com",
+					"project": "QA",
+					"password": "secret"
+				}
+			},
+			{
+				"id": 8,
+				"name": "Email",
+				"enabled": true,
+				"integrationType": {"name": "email", "groupType": "NOTIFICATION"}
+			}
+		]`))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolGetBTSIntegrations()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, ProjectKeyArgs{ProjectKey: "test-project"})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+	assert.JSONEq(t, `[{
+		"id": 7,
+		"name": "Company Jira",
+		"type": "jira",
+		"enabled": true,
+		"url": "https://jira.example.com",
+		"external_project": "QA"
+	}]`, textContent.Text)
+}
+
+func TestGetTestItemParametersTool(t *testing.T) {
+	ctx := context.Background()
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/test-project/item/42":
+			_, _ = w.Write([]byte(`{
+				"id": 42,
+				"name": "login works",
+				"type": "STEP",
+				"status": "FAILED",
+				"description": "a long description",
+				"parameters": [
+					{"key": "browser", "value": "firefox"},
+					{"key": "locale"}
+				]
+			}`))
+		case "/api/v1/test-project/item/43":
+			_, _ = w.Write([]byte(`{"id": 43, "name": "no params", "type": "STEP"}`))
+		default:
+			t.Errorf("unexpected request path %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolGetTestItemParameters()
+
+	tests := []struct {
+		name       string
+		testItemID int64
+		want       string
+	}{
+		{
+			name:       "parameters only",
+			testItemID: 42,
+			want:       `[{"key": "browser", "value": "firefox"}, {"key": "locale"}]`,
+		},
+		{
+			name:       "item without parameters",
+			testItemID: 43,
+			want:       `[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetTestItemParametersArgs{
+				ProjectKey: "test-project",
+				TestItemID: tt.testItemID,
+			})
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			require.True(t, ok, "expected TextContent")
+			assert.JSONEq(t, tt.want, textContent.Text)
+		})
+	}
+
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, GetTestItemParametersArgs{
+		ProjectKey: "test-project",
+	})
+	require.ErrorContains(t, err, "test_item_id is required")
+}
+
+func TestGetTestItemIssueTool(t *testing.T) {
+	ctx := context.Background()
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/test-project/item/42":
+			_, _ = w.Write([]byte(`{
+				"id": 42,
+				"name": "login works",
+				"type": "STEP",
+				"status": "FAILED",
+				"issue": {
+					"issueType": "pb001",
+					"comment": "backend returns 500",
+					"autoAnalyzed": true,
+					"ignoreAnalyzer": false,
+					"externalSystemIssues": [
+						{"ticketId": "PROJ-1", "url": "https://jira.example.com/browse/PROJ-1"}
+					]
+				}
+			}`))
+		case "/api/v1/test-project/item/43":
+			_, _ = w.Write([]byte(`{"id": 43, "name": "passes", "type": "STEP", "status": "PASSED"}`))
+		default:
+			t.Errorf("unexpected request path %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolGetTestItemIssue()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetTestItemIssueArgs{
+		ProjectKey: "test-project",
+		TestItemID: 42,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var issue map[string]any
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &issue))
+	assert.Equal(t, "pb001", issue["issueType"])
+	assert.Equal(t, "backend returns 500", issue["comment"])
+	assert.Equal(t, true, issue["autoAnalyzed"])
+	assert.Equal(t, false, issue["ignoreAnalyzer"])
+	assert.Len(t, issue["externalSystemIssues"], 1)
+	assert.NotContains(t, issue, "name")
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetTestItemIssueArgs{
+		ProjectKey: "test-project",
+		TestItemID: 43,
+	})
+	require.ErrorContains(t, err, "has no issue")
+}
+
+func TestGetItemsByCodeRefTool(t *testing.T) {
+	ctx := context.Background()
+	var (
+		mu            sync.Mutex
+		queriedLaunch []string
+	)
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/test-project/launch":
+			assert.Equal(t, strconv.Itoa(codeRefScanLaunches), r.URL.Query().Get("page.size"))
+			_, _ = w.Write([]byte(`{"content":[` +
+				`{"id":12,"uuid":"launch-12","name":"nightly","number":2,` +
+				`"startTime":"2024-01-02T00:00:00Z","status":"FAILED"},` +
+				`{"id":11,"uuid":"launch-11","name":"nightly","number":1,` +
+				`"startTime":"2024-01-01T00:00:00Z","status":"PASSED"}` +
+				`],"page":{"totalElements":2}}`))
+		case "/api/v1/test-project/item/v2":
+			assert.Equal(t, "launch", r.URL.Query().Get("providerType"))
+			launchID := r.URL.Query().Get("launchId")
+			condition, codeRef := "cnt", r.URL.Query().Get("filter.cnt.codeRef")
+			if codeRef == "" {
+				condition, codeRef = "eq", r.URL.Query().Get("filter.eq.codeRef")
+			}
+			assert.Equal(t, "com.example.LoginTest", codeRef)
+			mu.Lock()
+			queriedLaunch = append(queriedLaunch, condition+":"+launchID)
+			mu.Unlock()
+			_, _ = fmt.Fprintf(w,
+				`{"content":[{"id":%s1,"launchId":%s,"codeRef":"com.example.LoginTest.testLogin"}],`+
+					`"page":{"totalElements":1}}`,
+				launchID, launchID)
+		default:
+			t.Errorf("unexpected request path %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		newQueryParamsClient(ctx, serverURL),
+		nil,
+		"",
+	).toolGetItemsByCodeRef()
+
+	type itemsResponse struct {
+		Content []struct {
+			ID       int64 `json:"id"`
+			LaunchID int64 `json:"launchId"`
+		} `json:"content"`
+		Launches []struct {
+			LaunchID int32 `json:"launch_id"`
+		} `json:"launches"`
+	}
+	call := func(args GetItemsByCodeRefArgs) itemsResponse {
+		t.Helper()
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, args)
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok, "expected TextContent")
+		var response itemsResponse
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		return response
+	}
+
+	// Without launch_id the latest launches are searched, newest first
+	response := call(GetItemsByCodeRefArgs{
+		ProjectKey: "test-project",
+		CodeRef:    " com.example.LoginTest ",
+	})
+	require.Len(t, response.Content, 2)
+	assert.Equal(t, int64(121), response.Content[0].ID)
+	assert.Equal(t, int64(111), response.Content[1].ID)
+	require.Len(t, response.Launches, 2)
+	assert.Equal(t, int32(12), response.Launches[0].LaunchID)
+	assert.ElementsMatch(t, []string{"cnt:12", "cnt:11"}, queriedLaunch)
+
+	// With launch_id only that launch is searched, exact uses filter.eq.codeRef
+	queriedLaunch = nil
+	response = call(GetItemsByCodeRefArgs{
+		ProjectKey: "test-project",
+		CodeRef:    "com.example.LoginTest",
+		LaunchID:   7,
+		Exact:      true,
+	})
+	require.Len(t, response.Content, 1)
+	assert.Equal(t, int64(71), response.Content[0].ID)
+	assert.Equal(t, []string{"eq:7"}, queriedLaunch)
+
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, GetItemsByCodeRefArgs{
+		ProjectKey: "test-project",
+		CodeRef:    "  ",
+	})
+	require.ErrorContains(t, err, "code_ref is required")
+}
+
+// TestGetTestItemsByFilterTool_DefectType verifies that filter-eq-defect-type is sent as
+// filter.eq.issueType and that a blank locator is rejected
+func TestGetTestItemsByFilterTool_DefectType(t *testing.T) {
+	ctx := context.Background()
+	var capturedIssueType string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedIssueType = r.URL.Query().Get("filter.eq.issueType")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"content":[]}`))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolGetTestItemsByFilter()
+
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+		ProjectKey:         "test-project",
+		LaunchID:           42,
+		FilterEqDefectType: " pb001 ",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pb001", capturedIssueType)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+		ProjectKey:         "test-project",
+		LaunchID:           42,
+		FilterEqDefectType: "   ",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "filter-eq-defect-type")
+}
+
+func TestGetTestItemsByFilterTool_ExpandRetries(t *testing.T) {
+	ctx := context.Background()
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/test-project/item/v2":
+			_, _ = w.Write([]byte(`{"content":[` +
+				`{"id":9007199254740993,"uuid":"a","hasRetries":true},` +
+				`{"id":2,"uuid":"b","hasRetries":false},` +
+				`{"id":3,"uuid":"c","hasRetries":true}` +
+				`],"page":{"totalElements":3}}`))
+		case "/api/v1/test-project/item/uuid/a":
+			_ = json.NewEncoder(w).Encode(openapi.ComEpamReportportalBaseReportingTestItemResourceOld{
+				Retries: []openapi.ComEpamReportportalBaseReportingTestItemResource{
+					{Id: openapi.PtrInt64(11), Status: openapi.PtrString("FAILED")},
+					{Id: openapi.PtrInt64(12), Status: openapi.PtrString("FAILED")},
+				},
+			})
+		case "/api/v1/test-project/item/uuid/c":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolGetTestItemsByFilter()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+		ProjectKey:         "test-project",
+		LaunchID:           42,
+		FilterEqHasRetries: "--",
+		ExpandRetries:      true,
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+	// Large IDs survive the generic re-encoding
+	assert.Contains(t, textContent.Text, `"id":9007199254740993`)
+
+	var response struct {
+		Content []struct {
+			UUID         string                                                     `json:"uuid"`
+			Retries      []openapi.ComEpamReportportalBaseReportingTestItemResource `json:"retries"`
+			RetriesError string                                                     `json:"retries_error"`
+		} `json:"content"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	require.Len(t, response.Content, 3)
+	require.Len(t, response.Content[0].Retries, 2)
+	assert.Equal(t, int64(11), response.Content[0].Retries[0].GetId())
+	assert.Nil(t, response.Content[1].Retries, "items without retries are not expanded")
+	assert.NotEmpty(t, response.Content[2].RetriesError)
+}
+
+func TestGetFlakyItemsTool(t *testing.T) {
+	ctx := context.Background()
+
+	retry := func(id int64, status string, minute int) openapi.ComEpamReportportalBaseReportingTestItemResource {
+		startTime := time.Date(2026, 1, 1, 10, minute, 0, 0, time.UTC)
+		return openapi.ComEpamReportportalBaseReportingTestItemResource{
+			Id:        openapi.PtrInt64(id),
+			Status:    openapi.PtrString(status),
+			StartTime: &startTime,
+		}
+	}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/test-project/item/v2":
+			assert.Equal(t, "42", r.URL.Query().Get("launchId"))
+			assert.Equal(t, "true", r.URL.Query().Get("filter.eq.hasRetries"))
+			_, _ = w.Write([]byte(`{"content":[` +
+				`{"id":1,"uuid":"a","name":"login","status":"PASSED","hasRetries":true},` +
+				`{"id":2,"uuid":"b","name":"cart","status":"FAILED","hasRetries":true},` +
+				`{"id":3,"uuid":"c","name":"search","status":"PASSED","hasRetries":true}` +
+				`],"page":{"totalElements":150}}`))
+		case "/api/v1/test-project/item/uuid/a":
+			// Retries are reported out of order and sorted by start time
+			_ = json.NewEncoder(w).Encode(openapi.ComEpamReportportalBaseReportingTestItemResourceOld{
+				Retries: []openapi.ComEpamReportportalBaseReportingTestItemResource{
+					retry(12, "INTERRUPTED", 2),
+					retry(11, "FAILED", 1),
+				},
+			})
+		case "/api/v1/test-project/item/uuid/b":
+			_ = json.NewEncoder(w).Encode(openapi.ComEpamReportportalBaseReportingTestItemResourceOld{
+				Retries: []openapi.ComEpamReportportalBaseReportingTestItemResource{
+					retry(21, "FAILED", 1),
+				},
+			})
+		case "/api/v1/test-project/item/uuid/c":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(newQueryParamsClient(ctx, serverURL), nil, "").
+		toolGetFlakyItems()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, LaunchIDArgs{
+		ProjectKey: "test-project",
+		LaunchID:   42,
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var response struct {
+		FlakyItems       []flakyItem      `json:"flaky_items"`
+		Total            int              `json:"total"`
+		ItemsWithRetries int64            `json:"items_with_retries"`
+		ItemsChecked     int              `json:"items_checked"`
+		Errors           []map[string]any `json:"errors"`
+		Message          string           `json:"message"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+	// The item that failed on every attempt is not flaky
+	assert.Equal(t, []flakyItem{{
+		ID:             1,
+		Name:           "login",
+		Status:         "PASSED",
+		Retries:        2,
+		StatusSequence: []string{"FAILED", "INTERRUPTED", "PASSED"},
+	}}, response.FlakyItems)
+	assert.Equal(t, 1, response.Total)
+	assert.Equal(t, int64(150), response.ItemsWithRetries)
+	assert.Equal(t, 3, response.ItemsChecked)
+	require.Len(t, response.Errors, 1)
+	assert.EqualValues(t, 3, response.Errors[0]["test_item_id"])
+	assert.Contains(t, response.Message, "first 3 of 150")
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, LaunchIDArgs{ProjectKey: "test-project"})
+	assert.ErrorContains(t, err, "launch_id is required")
+}
+
+func TestGetTestItemsByFilterTool_FlattenAttributes(t *testing.T) {
+	ctx := context.Background()
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/test-project/item/v2", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"content":[` +
+			`{"id":9007199254740993,"attributes":[` +
+			`{"key":"browser","value":"firefox"},` +
+			`{"value":"smoke"}]},` +
+			`{"id":2}` +
+			`],"page":{"totalElements":2}}`))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolGetTestItemsByFilter()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+		ProjectKey:         "test-project",
+		LaunchID:           42,
+		FilterEqHasRetries: "--",
+		FlattenAttributes:  true,
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	assert.JSONEq(t, `{"content":[
+		{
+			"id": 9007199254740993,
+			"attributes": [
+				{"key": "browser", "value": "firefox"},
+				{"value": "smoke"}
+			],
+			"flatAttributes": ["browser:firefox", "smoke"]
+		},
+		{"id": 2, "flatAttributes": []}
+	],"page":{"totalElements":2}}`, textContent.Text)
+}
+
+func TestGetTestItemsByFilterTool_CountOnly(t *testing.T) {
+	ctx := context.Background()
+	totals := map[string]int{"1": 37, "2": 5}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/test-project/item/v2", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("page.page"))
+		assert.Equal(t, "1", r.URL.Query().Get("page.size"))
+		assert.Equal(t, "FAILED", r.URL.Query().Get("filter.in.status"))
+		total, ok := totals[r.URL.Query().Get("launchId")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Launch not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"content":[{"id":1}],"page":{"number":1,"size":1,"totalElements":%d}}`, total)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		newQueryParamsClient(ctx, serverURL),
+		nil,
+		"",
+	).toolGetTestItemsByFilter()
+
+	tests := []struct {
+		name        string
+		args        GetTestItemsByFilterArgs
+		expected    string
+		expectError string
+	}{
+		{
+			name:     "single launch",
+			args:     GetTestItemsByFilterArgs{LaunchID: 1, Page: 3, PageSize: 50, FlattenAttributes: true},
+			expected: "37",
+		},
+		{
+			name:     "several launches are summed",
+			args:     GetTestItemsByFilterArgs{LaunchIDs: []int32{1, 2}},
+			expected: "42",
+		},
+		{
+			name:        "a failed launch fails the count",
+			args:        GetTestItemsByFilterArgs{LaunchIDs: []int32{1, 3}},
+			expectError: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.ProjectKey = "test-project"
+			tt.args.FilterEqHasRetries = "--"
+			tt.args.FilterInStatus = "FAILED"
+			tt.args.CountOnly = true
+
+			result, _, err := handler(ctx, &mcp.CallToolRequest{}, tt.args)
+			if tt.expectError != "" {
+				require.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			require.True(t, ok, "expected TextContent")
+			assert.Equal(t, tt.expected, textContent.Text)
+		})
+	}
+}
+
+func TestGetTestItemsByFilterTool_MultipleLaunches(t *testing.T) {
+	ctx := context.Background()
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/test-project/item/v2", r.URL.Path)
+		assert.Equal(t, "launch", r.URL.Query().Get("providerType"))
+		w.Header().Set("Content-Type", "application/json")
+		switch launchID := r.URL.Query().Get("launchId"); launchID {
+		case "1", "2":
+			_, _ = fmt.Fprintf(w, `{"content":[{"id":%s0,"launchId":%s}],"page":{"totalElements":1}}`,
+				launchID, launchID)
+		case "3":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Launch '3' not found"}`))
+		default:
+			t.Errorf("unexpected launchId %q", launchID)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		newQueryParamsClient(ctx, serverURL),
+		nil,
+		"",
+	).toolGetTestItemsByFilter()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+		ProjectKey:         "test-project",
+		LaunchIDs:          []int32{2, 1, 3},
+		FilterEqHasRetries: "--",
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var response struct {
+		Content []struct {
+			ID       int64 `json:"id"`
+			LaunchID int64 `json:"launchId"`
+		} `json:"content"`
+		Launches []struct {
+			LaunchID int32          `json:"launch_id"`
+			Page     map[string]any `json:"page"`
+			Error    string         `json:"error"`
+		} `json:"launches"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	// Items keep the order of launch-ids
+	require.Len(t, response.Content, 2)
+	assert.Equal(t, int64(20), response.Content[0].ID)
+	assert.Equal(t, int64(10), response.Content[1].ID)
+	require.Len(t, response.Launches, 3)
+	assert.Equal(t, int32(2), response.Launches[0].LaunchID)
+	assert.NotNil(t, response.Launches[0].Page)
+	assert.Equal(t, int32(3), response.Launches[2].LaunchID)
+	assert.Contains(t, response.Launches[2].Error, "not found")
+}
+
+func TestGetTestItemsByFilterTool_MultipleLaunchesValidation(t *testing.T) {
+	ctx := context.Background()
+	_, handler := NewTestItemResources(
+		gorp.NewClient(&url.URL{Scheme: "http", Host: "localhost"}, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolGetTestItemsByFilter()
+
+	tests := []struct {
+		name    string
+		args    GetTestItemsByFilterArgs
+		wantErr string
+	}{
+		{
+			name:    "no launch selector",
+			args:    GetTestItemsByFilterArgs{},
+			wantErr: "either launch-id, launch-ids or filter-name is required",
+		},
+		{
+			name:    "launch-ids with launch-id",
+			args:    GetTestItemsByFilterArgs{LaunchID: 1, LaunchIDs: []int32{2}},
+			wantErr: "cannot be combined",
+		},
+		{
+			name:    "launch-ids with filter-name",
+			args:    GetTestItemsByFilterArgs{FilterName: "nightly", LaunchIDs: []int32{2}},
+			wantErr: "cannot be combined",
+		},
+		{
+			name:    "non-positive launch ID",
+			args:    GetTestItemsByFilterArgs{LaunchIDs: []int32{1, 0}},
+			wantErr: "must contain positive launch IDs",
+		},
+		{
+			name:    "too many launches",
+			args:    GetTestItemsByFilterArgs{LaunchIDs: make([]int32, multiLaunchItemsMaxLaunches+1)},
+			wantErr: "at most 20 launch IDs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.ProjectKey = "test-project"
+			_, _, err := handler(ctx, &mcp.CallToolRequest{}, tt.args)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGetTestItemsByFilterTool_FetchAll(t *testing.T) {
+	ctx := context.Background()
+	const totalItems = 7
+	var requestedPages []string
+	var mu sync.Mutex
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/test-project/item/v2", r.URL.Path)
+		assert.Equal(t, "5", r.URL.Query().Get("launchId"))
+		pageNumber, _ := strconv.Atoi(r.URL.Query().Get("page.page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("page.size"))
+		mu.Lock()
+		requestedPages = append(requestedPages, r.URL.Query().Get("page.page"))
+		mu.Unlock()
+
+		content := []map[string]any{}
+		for id := (pageNumber-1)*pageSize + 1; id <= min(pageNumber*pageSize, totalItems); id++ {
+			content = append(content, map[string]any{"id": id, "name": fmt.Sprintf("item-%d", id)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"content": content,
+			"page": map[string]any{
+				"number":        pageNumber,
+				"size":          pageSize,
+				"totalElements": totalItems,
+				"totalPages":    (totalItems + pageSize - 1) / pageSize,
+			},
+		})
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	testItems := NewTestItemResources(newQueryParamsClient(ctx, serverURL), nil, "")
+	_, handler := testItems.toolGetTestItemsByFilter()
+
+	type fetchAllResponse struct {
+		Content []struct {
+			ID int64 `json:"id"`
+		} `json:"content"`
+		Page struct {
+			TotalElements int64 `json:"totalElements"`
+			PagesFetched  int   `json:"pagesFetched"`
+		} `json:"page"`
+		Truncated bool `json:"truncated"`
+	}
+
+	tests := []struct {
+		name            string
+		pageSize        uint
+		maxPages        int
+		maxTotalResults int
+		expectedIDs     int
+		expectedPages   []string
+		expectTruncated bool
+	}{
+		{
+			name:          "all pages",
+			pageSize:      3,
+			expectedIDs:   7,
+			expectedPages: []string{"1", "2", "3"},
+		},
+		{
+			name:          "default page size",
+			expectedIDs:   7,
+			expectedPages: []string{"1"},
+		},
+		{
+			name:            "capped by max pages",
+			pageSize:        3,
+			maxPages:        2,
+			expectedIDs:     6,
+			expectedPages:   []string{"1", "2"},
+			expectTruncated: true,
+		},
+		{
+			name:            "capped by max total results",
+			pageSize:        3,
+			maxTotalResults: 4,
+			expectedIDs:     4,
+			expectedPages:   []string{"1", "2"},
+			expectTruncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := utils.NewFetchAllLimits(
+				cmp.Or(tt.maxPages, utils.DefaultFetchAllMaxPages),
+				cmp.Or(tt.maxTotalResults, utils.DefaultFetchAllMaxTotalResults),
+			)
+			require.NoError(t, err)
+			testItems.settings.FetchAll = limits
+			requestedPages = nil
+
+			result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+				ProjectKey:         "test-project",
+				LaunchID:           5,
+				Page:               2, // Ignored with fetch_all
+				PageSize:           tt.pageSize,
+				FilterEqHasRetries: "--",
+				FetchAll:           true,
+			})
+			require.NoError(t, err)
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			require.True(t, ok, "expected TextContent")
+
+			var response fetchAllResponse
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			require.Len(t, response.Content, tt.expectedIDs)
+			for i, item := range response.Content {
+				assert.Equal(t, int64(i+1), item.ID)
+			}
+			assert.Equal(t, int64(totalItems), response.Page.TotalElements)
+			assert.Equal(t, len(tt.expectedPages), response.Page.PagesFetched)
+			assert.Equal(t, tt.expectTruncated, response.Truncated)
+			assert.Equal(t, tt.expectedPages, requestedPages)
+		})
+	}
+
+	t.Run("cancelled context", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, _, err := handler(cancelledCtx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+			ProjectKey:         "test-project",
+			LaunchID:           5,
+			FilterEqHasRetries: "--",
+			FetchAll:           true,
+		})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("combined with count_only", func(t *testing.T) {
+		_, _, err := handler(ctx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+			ProjectKey: "test-project",
+			LaunchID:   5,
+			FetchAll:   true,
+			CountOnly:  true,
+		})
+		require.ErrorContains(t, err, "fetch_all cannot be combined")
+	})
+}
+
+// TestGetTestItemsByFilterTool_MalformedCompositeAttribute verifies that a malformed attribute
+// filter is rejected with a descriptive error before any request is sent
+func TestGetTestItemsByFilterTool_MalformedCompositeAttribute(t *testing.T) {
+	ctx := context.Background()
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolGetTestItemsByFilter()
+
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+		ProjectKey:                  "test-project",
+		LaunchID:                    1,
+		FilterHasCompositeAttribute: "demo, platform:ios",
+		FilterEqHasRetries:          "--",
+	})
+	require.ErrorContains(t, err, "invalid filter-has-compositeAttribute")
+	require.ErrorContains(t, err, "contains whitespace")
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+		ProjectKey:                  "test-project",
+		LaunchID:                    1,
+		FilterAnyCompositeAttribute: "demo,,smoke",
+		FilterEqHasRetries:          "--",
+	})
+	require.ErrorContains(t, err, "invalid filter-any-compositeAttribute")
+	require.ErrorContains(t, err, "is empty")
+}
+
+func TestGetTestItemsByFilterTool_NotEqualFilters(t *testing.T) {
+	ctx := context.Background()
+	var capturedQuery url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedQuery = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"content":[]}`))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		newQueryParamsClient(ctx, serverURL),
+		nil,
+		"",
+	).toolGetTestItemsByFilter()
+
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+		ProjectKey:     "test-project",
+		LaunchID:       42,
+		FilterNeStatus: " passed ",
+		FilterNeName:   "flaky login test",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "PASSED", capturedQuery.Get("filter.ne.status"))
+	assert.Equal(t, "flaky login test", capturedQuery.Get("filter.ne.name"))
+
+	// Not-equal filters are optional
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+		ProjectKey: "test-project",
+		LaunchID:   42,
+	})
+	require.NoError(t, err)
+	assert.False(t, capturedQuery.Has("filter.ne.status"))
+	assert.False(t, capturedQuery.Has("filter.ne.name"))
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+		ProjectKey:     "test-project",
+		LaunchID:       42,
+		FilterNeStatus: "GREEN",
+	})
+	require.ErrorContains(t, err, "invalid filter-ne-status")
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetTestItemsByFilterArgs{
+		ProjectKey:   "test-project",
+		LaunchID:     42,
+		FilterNeName: "  ",
+	})
+	require.ErrorContains(t, err, "filter-ne-name")
+}
+
+// TestFinishStuckItemsTool verifies that in-progress items are finished deepest first with the
+// requested status and that dry-run mode only lists them
+func TestFinishStuckItemsTool(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	var finishedUUIDs []string
+	var finishedStatuses []string
+	const itemPathPrefix = "/api/v1/test-project/item/"
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/test-project/launch/5":
+			_, _ = w.Write([]byte(`{"id":5,"uuid":"launch-uuid","name":"nightly","number":1,` +
+				`"status":"IN_PROGRESS","startTime":"2024-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/test-project/item/v2":
+			assert.Equal(t, "IN_PROGRESS", r.URL.Query().Get("filter.in.status"))
+			assert.Equal(t, "5", r.URL.Query().Get("launchId"))
+			_, _ = w.Write([]byte(`{"content":[` +
+				`{"id":10,"uuid":"suite","path":"10","status":"IN_PROGRESS"},` +
+				`{"id":12,"uuid":"step","path":"10.11.12","status":"IN_PROGRESS"},` +
+				`{"id":11,"uuid":"test","path":"10.11","status":"IN_PROGRESS"}],` +
+				`"page":{"hasNext":false}}`))
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, itemPathPrefix):
+			var rq map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&rq))
+			assert.Equal(t, "launch-uuid", rq["launchUuid"])
+			mu.Lock()
+			finishedUUIDs = append(finishedUUIDs, strings.TrimPrefix(r.URL.Path, itemPathPrefix))
+			finishedStatuses = append(finishedStatuses, rq["status"].(string))
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"message":"finished"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		newQueryParamsClient(ctx, serverURL),
+		nil,
+		"",
+	).toolFinishStuckItems()
+
+	// Dry run lists the items without finishing them
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, FinishStuckItemsArgs{
+		ProjectKey: "test-project",
+		LaunchID:   5,
+		DryRun:     true,
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+	assert.Contains(t, textContent.Text, `"item_ids":[12,11,10]`)
+	assert.Empty(t, finishedUUIDs)
+
+	result, _, err = handler(ctx, &mcp.CallToolRequest{}, FinishStuckItemsArgs{
+		ProjectKey: "test-project",
+		LaunchID:   5,
+		Status:     "failed",
+	})
+	require.NoError(t, err)
+	textContent, ok = result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+	assert.JSONEq(t, `{
+		"launch_id": 5,
+		"status": "FAILED",
+		"finished_count": 3,
+		"finished_item_ids": [12, 11, 10]
+	}`, textContent.Text)
+	assert.Equal(t, []string{"step", "test", "suite"}, finishedUUIDs)
+	assert.Equal(t, []string{"FAILED", "FAILED", "FAILED"}, finishedStatuses)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, FinishStuckItemsArgs{
+		ProjectKey: "test-project",
+		LaunchID:   5,
+		Status:     "DONE",
+	})
+	require.ErrorContains(t, err, "invalid status")
+}
+
+func TestBulkAddAttributeToItemsTool(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	updatedAttrs := map[string][]map[string]any{}
+	const itemPathPrefix = "/api/v1/test-project/item/"
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, itemPathPrefix), "/update")
+		switch {
+		case r.Method == http.MethodGet && id == "1":
+			_, _ = w.Write([]byte(`{"id":1,"attributes":[{"key":"os","value":"linux"}]}`))
+		case r.Method == http.MethodGet && id == "2":
+			_, _ = w.Write([]byte(`{"id":2,"attributes":[{"key":"triaged","value":"true"}]}`))
+		case r.Method == http.MethodGet && id == "3":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":40422,"message":"Test item not found"}`))
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/update"):
+			var rq struct {
+				Attributes []map[string]any `json:"attributes"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&rq))
+			mu.Lock()
+			updatedAttrs[id] = rq.Attributes
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"message":"updated"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolBulkAddAttributeToItems()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, BulkAddAttributeArgs{
+		ProjectKey:  "test-project",
+		TestItemIDs: []int64{1, 2, 3, 1},
+		Key:         "triaged",
+		Value:       "true",
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var response struct {
+		UpdatedCount int              `json:"updated_count"`
+		Results      []bulkItemResult `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	assert.Equal(t, 1, response.UpdatedCount)
+	require.Len(t, response.Results, 3)
+	assert.Equal(t, bulkItemUpdated, response.Results[0].Status)
+	assert.Equal(t, bulkItemUnchanged, response.Results[1].Status)
+	assert.Equal(t, bulkItemFailed, response.Results[2].Status)
+	assert.Contains(t, response.Results[2].Error, "Test item not found")
+
+	// Existing attributes are kept and only the item missing the attribute is updated
+	assert.Equal(t, map[string][]map[string]any{
+		"1": {
+			{"key": "os", "value": "linux"},
+			{"key": "triaged", "value": "true"},
+		},
+	}, updatedAttrs)
+}
+
+func TestMergeItemAttribute(t *testing.T) {
+	existing := []openapi.ComEpamReportportalBaseReportingItemAttributeResource{
+		{Key: openapi.PtrString("os"), Value: "linux"},
+		{Value: "smoke"},
+	}
+
+	merged, added := mergeItemAttribute(existing, "os", "linux")
+	assert.False(t, added)
+	assert.Len(t, merged, 2)
+
+	merged, added = mergeItemAttribute(existing, "", "smoke")
+	assert.False(t, added, "tag-style attribute without key is matched by value")
+	assert.Len(t, merged, 2)
+
+	merged, added = mergeItemAttribute(existing, "os", "windows")
+	assert.True(t, added)
+	assert.Len(t, merged, 3)
+	assert.Len(t, existing, 2, "input must not be modified")
+}
+
+// TestGetFailureContextLogsTool tests that the logs around the first error are returned,
+// reading further pages only until the window after the error is complete
+func TestGetFailureContextLogsTool(t *testing.T) {
+	ctx := context.Background()
+	var requestedPages []string
+
+	logEntry := func(id int64, level string) openapi.ComEpamReportportalBaseModelLogLogResource {
+		return openapi.ComEpamReportportalBaseModelLogLogResource{
+			Id:    id,
+			Uuid:  fmt.Sprintf("log-%d", id),
+			Level: openapi.PtrString(level),
+		}
+	}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/test-project/log", r.URL.Path)
+		assert.Equal(t, "42", r.URL.Query().Get("filter.eq.item"))
+		assert.Equal(t, utils.DefaultSortingForLogs, r.URL.Query().Get("page.sort"))
+		requestedPages = append(requestedPages, r.URL.Query().Get("page.page"))
+
+		page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseModelLogLogResource()
+		switch r.URL.Query().Get("page.page") {
+		case "1":
+			page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{
+				logEntry(1, "INFO"),
+				logEntry(2, "DEBUG"),
+				logEntry(3, "WARN"),
+				logEntry(4, "ERROR"),
+				logEntry(5, "INFO"),
+			})
+			page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+				HasNext: openapi.PtrBool(true),
+			})
+		case "2":
+			page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{
+				logEntry(6, "FATAL"),
+				logEntry(7, "INFO"),
+			})
+			page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+				HasNext: openapi.PtrBool(true),
+			})
+		default:
+			t.Errorf("unexpected page request %q", r.URL.Query().Get("page.page"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolGetFailureContextLogs()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetFailureContextLogsArgs{
+		ProjectKey:   "test-project",
+		TestItemID:   42,
+		ContextLines: openapi.PtrInt(2),
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var response struct {
+		ErrorLog   openapi.ComEpamReportportalBaseModelLogLogResource   `json:"error_log"`
+		LogsBefore []openapi.ComEpamReportportalBaseModelLogLogResource `json:"logs_before"`
+		LogsAfter  []openapi.ComEpamReportportalBaseModelLogLogResource `json:"logs_after"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+	logIDs := func(logs []openapi.ComEpamReportportalBaseModelLogLogResource) []int64 {
+		ids := make([]int64, 0, len(logs))
+		for _, l := range logs {
+			ids = append(ids, l.Id)
+		}
+		return ids
+	}
+	assert.Equal(t, int64(4), response.ErrorLog.Id)
+	assert.Equal(t, []int64{2, 3}, logIDs(response.LogsBefore))
+	assert.Equal(t, []int64{5, 6}, logIDs(response.LogsAfter))
+	assert.Equal(t, []string{"1", "2"}, requestedPages)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetFailureContextLogsArgs{
+		ProjectKey:   "test-project",
+		TestItemID:   42,
+		ContextLines: openapi.PtrInt(failureContextMaxLines + 1),
+	})
+	require.Error(t, err)
+}
+
+// TestListTestItemAttachmentsTool tests that attachments are collected from all log pages of the
+// item, with sizes read from HEAD requests for the attachments
+func TestGetItemLogsTextTool(t *testing.T) {
+	ctx := context.Background()
+	var requestedPages []string
+
+	logEntry := func(id int64, level, message string) openapi.ComEpamReportportalBaseModelLogLogResource {
+		return openapi.ComEpamReportportalBaseModelLogLogResource{
+			Id:      id,
+			Uuid:    fmt.Sprintf("log-%d", id),
+			Level:   openapi.PtrString(level),
+			Message: openapi.PtrString(message),
+		}
+	}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/test-project/log", r.URL.Path)
+		assert.Equal(t, "42", r.URL.Query().Get("filter.eq.item"))
+		assert.Equal(t, "INFO", r.URL.Query().Get("filter.gte.level"))
+		assert.Equal(t, utils.DefaultSortingForLogs, r.URL.Query().Get("page.sort"))
+		requestedPages = append(requestedPages, r.URL.Query().Get("page.page"))
+
+		page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseModelLogLogResource()
+		switch r.URL.Query().Get("page.page") {
+		case "1":
+			page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{
+				logEntry(1, "INFO", "starting test"),
+				logEntry(2, "WARN", "slow response"),
+			})
+			page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+				HasNext: openapi.PtrBool(true),
+			})
+		case "2":
+			page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{
+				logEntry(3, "ERROR", "assertion failed"),
+			})
+			page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+				HasNext: openapi.PtrBool(false),
+			})
+		default:
+			t.Errorf("unexpected page request %q", r.URL.Query().Get("page.page"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		newQueryParamsClient(ctx, serverURL),
+		nil,
+		"",
+	).toolGetItemLogsText()
+
+	textOf := func(result *mcp.CallToolResult) string {
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok, "expected TextContent")
+		return textContent.Text
+	}
+
+	t.Run("all logs with level prefixes", func(t *testing.T) {
+		requestedPages = nil
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetItemLogsTextArgs{
+			ProjectKey:     "test-project",
+			TestItemID:     42,
+			FilterGteLevel: "INFO",
+			IncludeLevel:   true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2"}, requestedPages)
+		assert.Equal(t,
+			"[INFO] starting test\n[WARN] slow response\n[ERROR] assertion failed\n",
+			textOf(result),
+		)
+	})
+
+	t.Run("max_lines caps the logs", func(t *testing.T) {
+		requestedPages = nil
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetItemLogsTextArgs{
+			ProjectKey:     "test-project",
+			TestItemID:     42,
+			FilterGteLevel: "INFO",
+			MaxLines:       openapi.PtrInt(2),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1"}, requestedPages)
+		assert.Equal(t,
+			"starting test\nslow response\n[truncated: only the first 2 logs are included]\n",
+			textOf(result),
+		)
+	})
+
+	t.Run("invalid max_lines", func(t *testing.T) {
+		_, _, err := handler(ctx, &mcp.CallToolRequest{}, GetItemLogsTextArgs{
+			ProjectKey: "test-project",
+			TestItemID: 42,
+			MaxLines:   openapi.PtrInt(0),
+		})
+		assert.ErrorContains(t, err, "max_lines must be between 1 and")
+	})
+}
+
+func TestListTestItemAttachmentsTool(t *testing.T) {
+	ctx := context.Background()
+	var (
+		mu           sync.Mutex
+		headRequests []string
+	)
+
+	attachmentLog := func(id int64, attachmentID, contentType string) openapi.ComEpamReportportalBaseModelLogLogResource {
+		return openapi.ComEpamReportportalBaseModelLogLogResource{
+			Id:      id,
+			Uuid:    fmt.Sprintf("log-%d", id),
+			Message: openapi.PtrString(fmt.Sprintf("log %d", id)),
+			BinaryContent: openapi.NewComEpamReportportalBaseModelLogLogResourceBinaryContent(
+				attachmentID,
+				attachmentID+"-thumb",
+				contentType,
+			),
+		}
+	}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			mu.Lock()
+			headRequests = append(headRequests, r.URL.Path)
+			mu.Unlock()
+			switch r.URL.Path {
+			case "/api/v1/data/test-project/101":
+				w.Header().Set("Content-Length", "2048")
+			case "/api/v1/data/test-project/102":
+				w.Header().Set("Content-Length", "17")
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+			return
+		}
+
+		assert.Equal(t, "/api/v1/test-project/log", r.URL.Path)
+		assert.Equal(t, "42", r.URL.Query().Get("filter.eq.item"))
+		assert.Equal(t, "true", r.URL.Query().Get("filter.ex.binaryContent"))
+
+		page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseModelLogLogResource()
+		switch r.URL.Query().Get("page.page") {
+		case "1":
+			page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{
+				attachmentLog(1, "101", "image/png"),
+				{Id: 2, Uuid: "log-2"},
+			})
+			page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+				HasNext: openapi.PtrBool(true),
+			})
+		case "2":
+			page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{
+				attachmentLog(3, "102", "text/plain"),
+				attachmentLog(4, "103", "application/zip"),
+			})
+			page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+				HasNext: openapi.PtrBool(false),
+			})
+		default:
+			t.Errorf("unexpected page request %q", r.URL.Query().Get("page.page"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		newQueryParamsClient(ctx, serverURL),
+		nil,
+		"",
+	).toolListTestItemAttachments()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, ListTestItemAttachmentsArgs{
+		ProjectKey: "test-project",
+		TestItemID: 42,
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var response struct {
+		Total       int              `json:"total"`
+		Attachments []itemAttachment `json:"attachments"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	require.Equal(t, 3, response.Total)
+	require.Len(t, response.Attachments, 3)
+
+	assert.Equal(t, "101", response.Attachments[0].AttachmentID)
+	assert.Equal(t, "image/png", response.Attachments[0].ContentType)
+	assert.Equal(t, int64(1), response.Attachments[0].LogID)
+	require.NotNil(t, response.Attachments[0].Size)
+	assert.Equal(t, int64(2048), *response.Attachments[0].Size)
+
+	assert.Equal(t, "102", response.Attachments[1].AttachmentID)
+	require.NotNil(t, response.Attachments[1].Size)
+	assert.Equal(t, int64(17), *response.Attachments[1].Size)
+
+	// A failed size lookup is reported on the attachment instead of failing the whole call
+	assert.Equal(t, "103", response.Attachments[2].AttachmentID)
+	assert.Nil(t, response.Attachments[2].Size)
+	assert.Contains(t, response.Attachments[2].Error, "HTTP 404")
+	assert.Len(t, headRequests, 3)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, ListTestItemAttachmentsArgs{
+		ProjectKey: "test-project",
+	})
+	require.Error(t, err)
+}
+
+// TestListTestItemAttachmentsTool_Truncated verifies that the listed attachments, and so the
+// size lookups, are capped and the result is marked truncated
+func TestListTestItemAttachmentsTool_Truncated(t *testing.T) {
+	ctx := context.Background()
+	var headRequests atomic.Int32
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			headRequests.Add(1)
+			w.Header().Set("Content-Length", "1")
+			return
+		}
+
+		logs := make([]openapi.ComEpamReportportalBaseModelLogLogResource, 0, itemAttachmentsPageSize)
+		for i := range itemAttachmentsPageSize {
+			id := r.URL.Query().Get("page.page") + "-" + strconv.Itoa(i)
+			logs = append(logs, openapi.ComEpamReportportalBaseModelLogLogResource{
+				Id:            int64(i),
+				Uuid:          "log-" + id,
+				BinaryContent: openapi.NewComEpamReportportalBaseModelLogLogResourceBinaryContent(id, id, "image/png"),
+			})
+		}
+		page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseModelLogLogResource()
+		page.SetContent(logs)
+		page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{HasNext: openapi.PtrBool(true)})
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		newQueryParamsClient(ctx, serverURL),
+		nil,
+		"",
+	).toolListTestItemAttachments()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, ListTestItemAttachmentsArgs{
+		ProjectKey: "test-project",
+		TestItemID: 42,
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var response struct {
+		Total     int    `json:"total"`
+		Truncated bool   `json:"truncated"`
+		Message   string `json:"message"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	assert.Equal(t, itemAttachmentsMaxAttachments, response.Total)
+	assert.True(t, response.Truncated)
+	assert.Contains(t, response.Message, "first 100 attachments")
+	assert.Equal(t, int32(itemAttachmentsMaxAttachments), headRequests.Load())
+}
+
+// TestSetIgnoreAnalyzerTool verifies that only items with a defect and a different flag are
+// updated, in one request that keeps their defect type and comment
+func TestSetIgnoreAnalyzerTool(t *testing.T) {
+	ctx := context.Background()
+	var definedIssues []map[string]any
+	defineRequests := 0
+	const itemPathPrefix = "/api/v1/test-project/item/"
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == itemPathPrefix+"1":
+			_, _ = w.Write([]byte(`{"id":1,"issue":{"issueType":"pb001","comment":"flaky env"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == itemPathPrefix+"2":
+			_, _ = w.Write([]byte(`{"id":2,"issue":{"issueType":"ti001","ignoreAnalyzer":true}}`))
+		case r.Method == http.MethodGet && r.URL.Path == itemPathPrefix+"3":
+			_, _ = w.Write([]byte(`{"id":3,"status":"PASSED"}`))
+		case r.Method == http.MethodGet && r.URL.Path == itemPathPrefix+"4":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":40422,"message":"Test item not found"}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/test-project/item":
+			defineRequests++
+			var rq struct {
+				Issues []map[string]any `json:"issues"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&rq))
+			definedIssues = rq.Issues
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolSetIgnoreAnalyzer()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, SetIgnoreAnalyzerArgs{
+		ProjectKey:     "test-project",
+		TestItemIDs:    []int64{1, 2, 3, 4},
+		IgnoreAnalyzer: true,
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var response struct {
+		UpdatedCount int              `json:"updated_count"`
+		Results      []bulkItemResult `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	assert.Equal(t, 1, response.UpdatedCount)
+	require.Len(t, response.Results, 4)
+	assert.Equal(t, bulkItemUpdated, response.Results[0].Status)
+	assert.Equal(t, bulkItemUnchanged, response.Results[1].Status)
+	assert.Equal(t, bulkItemFailed, response.Results[2].Status)
+	assert.Contains(t, response.Results[2].Error, "no defect type")
+	assert.Equal(t, bulkItemFailed, response.Results[3].Status)
+	assert.Contains(t, response.Results[3].Error, "Test item not found")
+
+	assert.Equal(t, 1, defineRequests)
+	assert.Equal(t, []map[string]any{{
+		"testItemId": float64(1),
+		"issue": map[string]any{
+			"issueType":      "pb001",
+			"comment":        "flaky env",
+			"ignoreAnalyzer": true,
+		},
+	}}, definedIssues)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, SetIgnoreAnalyzerArgs{
+		ProjectKey:  "test-project",
+		TestItemIDs: []int64{0},
+	})
+	require.Error(t, err)
+}
+
+// TestSetIgnoreAnalyzerTool_DefineErrors verifies that items ReportPortal rejects in the
+// issue definition response are reported as failed
+func TestSetIgnoreAnalyzerTool_DefineErrors(t *testing.T) {
+	ctx := context.Background()
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/test-project/item/1":
+			_, _ = w.Write([]byte(`{"id":1,"issue":{"issueType":"pb001"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/test-project/item/2":
+			_, _ = w.Write([]byte(`{"id":2,"issue":{"issueType":"ti001"}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/test-project/item":
+			_, _ = w.Write([]byte(`{"errors":[` +
+				`{"testItemId":2,"message":"Test item 2 is not a step"},` +
+				`{"testItemId":9,"message":"Test item 9 not found"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	).toolSetIgnoreAnalyzer()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, SetIgnoreAnalyzerArgs{
+		ProjectKey:     "test-project",
+		TestItemIDs:    []int64{1, 2},
+		IgnoreAnalyzer: true,
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var response struct {
+		UpdatedCount int              `json:"updated_count"`
+		Results      []bulkItemResult `json:"results"`
+		Errors       []string         `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	assert.Equal(t, 1, response.UpdatedCount)
+	require.Len(t, response.Results, 2)
+	assert.Equal(t, bulkItemUpdated, response.Results[0].Status)
+	assert.Equal(t, bulkItemFailed, response.Results[1].Status)
+	assert.Equal(t, "Test item 2 is not a step", response.Results[1].Error)
+	assert.Equal(t, []string{"Test item 9 not found"}, response.Errors)
+}
+
+func TestGetTestCaseHistoryByHashTool(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	var historyLaunches []string
+
+	launch := func(id, number int64) openapi.ComEpamReportportalBaseReportingLaunchResource {
+		return openapi.ComEpamReportportalBaseReportingLaunchResource{
+			Id:        id,
+			Uuid:      fmt.Sprintf("launch-%d", id),
+			Name:      "Nightly",
+			Number:    number,
+			StartTime: now,
+			Status:    "FAILED",
+		}
+	}
+	item := func(
+		id, launchID int64,
+		status string,
+		started time.Time,
+	) openapi.ComEpamReportportalBaseReportingTestItemResource {
+		return openapi.ComEpamReportportalBaseReportingTestItemResource{
+			Id:           openapi.PtrInt64(id),
+			LaunchId:     openapi.PtrInt64(launchID),
+			Status:       openapi.PtrString(status),
+			StartTime:    &started,
+			TestCaseHash: openapi.PtrInt32(-1234),
+		}
+	}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/v1/test-project/launch":
+			page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseReportingLaunchResource()
+			if query.Get("filter.in.id") != "" {
+				assert.Equal(t, "7", query.Get("filter.in.id"))
+				page.SetContent([]openapi.ComEpamReportportalBaseReportingLaunchResource{launch(7, 3)})
+			} else {
+				page.SetContent([]openapi.ComEpamReportportalBaseReportingLaunchResource{
+					launch(12, 6),
+					launch(11, 5),
+				})
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		case "/api/v1/test-project/item/history":
+			assert.Equal(t, "-1234", query.Get("filter.eq.testCaseHash"))
+			assert.Equal(t, "5", query.Get("historyDepth"))
+			historyLaunches = append(historyLaunches, query.Get("filter.eq.launchId"))
+
+			page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseModelTestItemHistoryElement()
+			if query.Get("filter.eq.launchId") == "11" {
+				failed := item(110, 11, "FAILED", now)
+				failed.Issue = &openapi.ComEpamReportportalBaseReportingIssue{
+					IssueType: "pb001",
+					Comment:   openapi.PtrString("known bug"),
+				}
+				page.SetContent([]openapi.ComEpamReportportalBaseModelTestItemHistoryElement{{
+					GroupingField: openapi.PtrString("-1234"),
+					Resources: []openapi.ComEpamReportportalBaseReportingTestItemResource{
+						item(70, 7, "PASSED", now.Add(-time.Hour)),
+						failed,
+					},
+				}})
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(newQueryParamsClient(ctx, serverURL), nil, "").
+		toolGetTestCaseHistoryByHash()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetTestCaseHistoryByHashArgs{
+		ProjectKey:   "test-project",
+		TestCaseHash: openapi.PtrInt32(-1234),
+		HistoryDepth: 5,
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var response struct {
+		Occurrences []testCaseOccurrence `json:"occurrences"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+	// The latest launch does not contain the test case, so the search moves on to the next one
+	assert.Equal(t, []string{"12", "11"}, historyLaunches)
+	require.Len(t, response.Occurrences, 2)
+	assert.Equal(t, int64(110), response.Occurrences[0].TestItemID)
+	assert.Equal(t, int64(5), response.Occurrences[0].LaunchNumber)
+	assert.Equal(t, "FAILED", response.Occurrences[0].Status)
+	assert.Equal(t, "pb001", response.Occurrences[0].DefectType)
+	assert.Equal(t, "known bug", response.Occurrences[0].DefectComment)
+	assert.Equal(t, int64(70), response.Occurrences[1].TestItemID)
+	assert.Equal(t, int64(3), response.Occurrences[1].LaunchNumber)
+	assert.Equal(t, "PASSED", response.Occurrences[1].Status)
+	assert.Empty(t, response.Occurrences[1].DefectType)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetTestCaseHistoryByHashArgs{
+		ProjectKey: "test-project",
+	})
+	require.Error(t, err)
+}
+
+func TestGetLaunchFailureSummaryTool(t *testing.T) {
+	ctx := context.Background()
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/v1/test-project/item/v2":
+			assert.Equal(t, "77", query.Get("launchId"))
+			assert.Equal(t, "FAILED", query.Get("filter.in.status"))
+
+			page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseReportingTestItemResource()
+			page.SetContent([]openapi.ComEpamReportportalBaseReportingTestItemResource{
+				{
+					Id:   openapi.PtrInt64(1),
+					Name: openapi.PtrString("login test"),
+					Issue: &openapi.ComEpamReportportalBaseReportingIssue{
+						IssueType: "ti001",
+					},
+				},
+				{Id: openapi.PtrInt64(2), Name: openapi.PtrString("logout test")},
+				{Id: openapi.PtrInt64(3), Name: openapi.PtrString("broken test")},
+			})
+			page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+				TotalElements: openapi.PtrInt64(3),
+			})
+			_ = json.NewEncoder(w).Encode(page)
+		case "/api/v1/test-project/log":
+			assert.Equal(t, "ERROR", query.Get("filter.gte.level"))
+			assert.Equal(t, "1", query.Get("page.size"))
+
+			page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseModelLogLogResource()
+			switch query.Get("filter.eq.item") {
+			case "1":
+				page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{{
+					Id:      10,
+					Uuid:    "log-10",
+					Message: openapi.PtrString("AssertionError: expected 200 but got 500"),
+				}})
+			case "3":
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(newQueryParamsClient(ctx, serverURL), nil, "").
+		toolGetLaunchFailureSummary()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetLaunchFailureSummaryArgs{
+		ProjectKey:       "test-project",
+		LaunchID:         77,
+		MaxMessageLength: openapi.PtrInt(14),
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var response struct {
+		FailedItems int64                `json:"failed_items"`
+		Items       []failureSummaryItem `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+	assert.Equal(t, int64(3), response.FailedItems)
+	require.Len(t, response.Items, 3)
+	assert.Equal(t, "ti001", response.Items[0].DefectType)
+	assert.Equal(t, "AssertionError…", response.Items[0].ErrorMessage)
+	assert.True(t, response.Items[0].MessageTruncated)
+	// An item without error logs is listed without a message
+	assert.Empty(t, response.Items[1].ErrorMessage)
+	assert.Empty(t, response.Items[1].Error)
+	// A failed log lookup is reported on its item instead of failing the whole summary
+	assert.NotEmpty(t, response.Items[2].Error)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetLaunchFailureSummaryArgs{
+		ProjectKey:       "test-project",
+		LaunchID:         77,
+		MaxMessageLength: openapi.PtrInt(0),
+	})
+	require.Error(t, err)
+}
+
+func TestGetLogsGroupedByItemTool(t *testing.T) {
+	ctx := context.Background()
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/v1/test-project/item/v2":
+			assert.Equal(t, "77", query.Get("launchId"))
+			assert.Equal(t, "INTERRUPTED", query.Get("filter.in.status"))
+			assert.Equal(t, "2", query.Get("page.size"))
+
+			page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseReportingTestItemResource()
+			page.SetContent([]openapi.ComEpamReportportalBaseReportingTestItemResource{
+				{Id: openapi.PtrInt64(1), Name: openapi.PtrString("login test")},
+				{Id: openapi.PtrInt64(2), Name: openapi.PtrString("logout test")},
+			})
+			page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+				TotalElements: openapi.PtrInt64(5),
+			})
+			_ = json.NewEncoder(w).Encode(page)
+		case "/api/v1/test-project/log":
+			assert.Equal(t, "3", query.Get("page.size"))
+
+			page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseModelLogLogResource()
+			switch query.Get("filter.eq.item") {
+			case "1":
+				page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{
+					{Id: 10, Uuid: "log-10", Message: openapi.PtrString("opening login page")},
+					{Id: 11, Uuid: "log-11", Message: openapi.PtrString("session expired")},
+				})
+				page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+					HasNext: openapi.PtrBool(true),
+				})
+			case "2":
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(newQueryParamsClient(ctx, serverURL), nil, "").
+		toolGetLogsGroupedByItem()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetLogsGroupedByItemArgs{
+		ProjectKey:     "test-project",
+		LaunchID:       77,
+		Status:         "interrupted",
+		MaxItems:       openapi.PtrInt(2),
+		MaxLogsPerItem: openapi.PtrInt(3),
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var response struct {
+		Status  string              `json:"status"`
+		Items   map[string]itemLogs `json:"items"`
+		Message string              `json:"message"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+
+	assert.Equal(t, "INTERRUPTED", response.Status)
+	require.Len(t, response.Items, 2)
+	assert.Equal(t, "login test", response.Items["1"].Name)
+	require.Len(t, response.Items["1"].Logs, 2)
+	assert.Equal(t, "session expired", response.Items["1"].Logs[1].GetMessage())
+	assert.True(t, response.Items["1"].LogsTruncated)
+	// A failed log lookup is reported on its item instead of failing the whole result
+	assert.Equal(t, "logout test", response.Items["2"].Name)
+	assert.Empty(t, response.Items["2"].Logs)
+	assert.NotEmpty(t, response.Items["2"].Error)
+	assert.Contains(t, response.Message, "first 2 of 5")
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetLogsGroupedByItemArgs{
+		ProjectKey:     "test-project",
+		LaunchID:       77,
+		MaxLogsPerItem: openapi.PtrInt(0),
+	})
+	require.Error(t, err)
+}
+
+func TestGetUniqueFailureMessagesTool(t *testing.T) {
+	ctx := context.Background()
+
+	logMessages := map[string]string{
+		"1": "Timeout after 3000 ms waiting for #login",
+		"2": "Timeout after  5000 ms\nwaiting for #login",
+		"3": "NullPointerException at Cart.java:42",
+		"4": "Timeout after 100 ms waiting for #login",
+	}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/v1/test-project/item/v2":
+			assert.Equal(t, "77", query.Get("launchId"))
+			assert.Equal(t, "FAILED", query.Get("filter.in.status"))
+			assert.Equal(t, "6", query.Get("page.size"))
+
+			page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseReportingTestItemResource()
+			var content []openapi.ComEpamReportportalBaseReportingTestItemResource
+			for id := int64(1); id <= 6; id++ {
+				content = append(content, openapi.ComEpamReportportalBaseReportingTestItemResource{
+					Id: openapi.PtrInt64(id),
+				})
+			}
+			page.SetContent(content)
+			page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+				TotalElements: openapi.PtrInt64(10),
+			})
+			_ = json.NewEncoder(w).Encode(page)
+		case "/api/v1/test-project/log":
+			assert.Equal(t, "ERROR", query.Get("filter.gte.level"))
+
+			itemID := query.Get("filter.eq.item")
+			if itemID == "6" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseModelLogLogResource()
+			if message, ok := logMessages[itemID]; ok {
+				page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{{
+					Id:      10,
+					Uuid:    "log-10",
+					Message: openapi.PtrString(message),
+				}})
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(newQueryParamsClient(ctx, serverURL), nil, "").
+		toolGetUniqueFailureMessages()
+
+	type response struct {
+		FailedItems          int64                  `json:"failed_items"`
+		ItemsScanned         int                    `json:"items_scanned"`
+		Messages             []uniqueFailureMessage `json:"messages"`
+		Total                int                    `json:"total"`
+		ItemsWithoutErrorLog []int64                `json:"items_without_error_log"`
+		Errors               []map[string]any       `json:"errors"`
+		Message              string                 `json:"message"`
+	}
+	call := func(args GetUniqueFailureMessagesArgs) response {
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, args)
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok, "expected TextContent")
+		var resp response
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &resp))
+		return resp
+	}
+
+	t.Run("remove_numbers groups messages differing in numbers", func(t *testing.T) {
+		resp := call(GetUniqueFailureMessagesArgs{
+			ProjectKey:    "test-project",
+			LaunchID:      77,
+			RemoveNumbers: true,
+			MaxItems:      openapi.PtrInt(6),
+		})
+		assert.Equal(t, int64(10), resp.FailedItems)
+		assert.Equal(t, 6, resp.ItemsScanned)
+		assert.Equal(t, 2, resp.Total)
+		assert.Equal(t, []uniqueFailureMessage{
+			{
+				Message:        "Timeout after ms waiting for #login",
+				Count:          3,
+				ExampleItemIDs: []int64{1, 2, 4},
+			},
+			{
+				Message:        "NullPointerException at Cart.java:",
+				Count:          1,
+				ExampleItemIDs: []int64{3},
+			},
+		}, resp.Messages)
+		assert.Equal(t, []int64{5}, resp.ItemsWithoutErrorLog)
+		require.Len(t, resp.Errors, 1)
+		assert.EqualValues(t, 6, resp.Errors[0]["test_item_id"])
+		assert.Contains(t, resp.Message, "first 6 of 10")
+	})
+
+	t.Run("numbers are kept by default", func(t *testing.T) {
+		resp := call(GetUniqueFailureMessagesArgs{
+			ProjectKey:       "test-project",
+			LaunchID:         77,
+			MaxItems:         openapi.PtrInt(6),
+			MaxMessageLength: openapi.PtrInt(7),
+		})
+		assert.Equal(t, 4, resp.Total)
+		assert.Equal(t, "Timeout…", resp.Messages[0].Message)
+		assert.True(t, resp.Messages[0].MessageTruncated)
+	})
+
+	t.Run("invalid max_items", func(t *testing.T) {
+		_, _, err := handler(ctx, &mcp.CallToolRequest{}, GetUniqueFailureMessagesArgs{
+			ProjectKey: "test-project",
+			LaunchID:   77,
+			MaxItems:   openapi.PtrInt(utils.MaxDefaultPageSize + 1),
+		})
+		assert.ErrorContains(t, err, "max_items must be between 1 and")
+	})
+}
+
+func TestGetUniqueFailureMessagesTool_NoFailures(t *testing.T) {
+	ctx := context.Background()
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		assert.Equal(t, "/api/v1/test-project/item/v2", r.URL.Path)
+		page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseReportingTestItemResource()
+		page.SetContent([]openapi.ComEpamReportportalBaseReportingTestItemResource{})
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(newQueryParamsClient(ctx, serverURL), nil, "").
+		toolGetUniqueFailureMessages()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetUniqueFailureMessagesArgs{
+		ProjectKey: "test-project",
+		LaunchID:   77,
+	})
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var response map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	// An empty launch yields an empty list, not null
+	assert.JSONEq(t, "[]", string(response["messages"]))
+	assert.JSONEq(t, "0", string(response["total"]))
+}
+
+func TestGetNestedStepsTool(t *testing.T) {
+	ctx := context.Background()
+
+	step := func(
+		id int64,
+		status string,
+		hasChildren bool,
+	) openapi.ComEpamReportportalBaseReportingTestItemResource {
+		return openapi.ComEpamReportportalBaseReportingTestItemResource{
+			Id:          openapi.PtrInt64(id),
+			Name:        openapi.PtrString(fmt.Sprintf("step %d", id)),
+			Type:        openapi.PtrString("STEP"),
+			Status:      openapi.PtrString(status),
+			HasChildren: openapi.PtrBool(hasChildren),
+		}
+	}
+	children := map[string][]openapi.ComEpamReportportalBaseReportingTestItemResource{
+		"5": {step(6, "FAILED", true), step(7, "PASSED", false)},
+		"6": {step(8, "FAILED", false)},
+	}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/v1/test-project/item/5":
+			_ = json.NewEncoder(w).Encode(openapi.ComEpamReportportalBaseReportingTestItemResource{
+				Id:       openapi.PtrInt64(5),
+				LaunchId: openapi.PtrInt64(9),
+			})
+		case "/api/v1/test-project/item/v2":
+			assert.Equal(t, "9", query.Get("launchId"))
+			assert.Equal(t, "STEP", query.Get("filter.in.type"))
+			assert.Empty(t, query.Get("filter.eq.hasStats"), "nested steps have no statistics")
+
+			page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseReportingTestItemResource()
+			page.SetContent(children[query.Get("filter.eq.parentId")])
+			_ = json.NewEncoder(w).Encode(page)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewTestItemResources(newQueryParamsClient(ctx, serverURL), nil, "").
+		toolGetNestedSteps()
+
+	getSteps := func(recursive bool) []nestedStep {
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetNestedStepsArgs{
+			ProjectKey:   "test-project",
+			ParentItemID: 5,
+			Recursive:    recursive,
+		})
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok, "expected TextContent")
+
+		var response struct {
+			Steps []nestedStep `json:"steps"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		return response.Steps
+	}
+
+	// Direct children only by default
+	steps := getSteps(false)
+	require.Len(t, steps, 2)
+	assert.Equal(t, int64(6), steps[0].ID)
+	assert.Equal(t, "FAILED", steps[0].Status)
+	assert.True(t, steps[0].HasChildren)
+	assert.Equal(t, int64(7), steps[1].ID)
+
+	steps = getSteps(true)
+	require.Len(t, steps, 3)
+	assert.Equal(t, int64(8), steps[2].ID)
+	assert.Equal(t, int64(6), steps[2].ParentID)
+	assert.Equal(t, 2, steps[2].Depth)
+}
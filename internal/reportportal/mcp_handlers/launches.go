@@ -1,25 +1,32 @@
 package mcphandlers
 
 import (
+	"archive/zip"
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"mime"
 	"mime/multipart"
+	"net"
 	"net/http"
 	"net/textproto"
 	"net/url"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/reportportal/goRP/v5/pkg/gorp"
 	"github.com/reportportal/goRP/v5/pkg/openapi"
@@ -35,6 +42,35 @@ const (
 	// JSON string so the full content is already resident in memory; this limit
 	// prevents an abnormally large value from being processed further.
 	importMaxFileSizeBytes = 50 * 1024 * 1024 // 50 MiB
+	// lastLaunchesByNamesConcurrency bounds parallel ReportPortal queries issued by
+	// get_last_launches_by_names.
+	lastLaunchesByNamesConcurrency = 5
+	// lastLaunchesByNamesMaxNames caps the number of names accepted per call.
+	lastLaunchesByNamesMaxNames = 50
+	// launchComparisonMaxLaunches caps the number of launches accepted by compare_launches_table.
+	launchComparisonMaxLaunches = 50
+	// launchTrendDefaultDepth is the default number of launches in a get_launch_trend series.
+	launchTrendDefaultDepth = 10
+	// launchTrendMaxDepth caps the number of launches in a get_launch_trend series.
+	launchTrendMaxDepth = 200
+	// launchTrendPageSize is the page size of the launch queries issued by get_launch_trend.
+	launchTrendPageSize = 50
+	// baselineDiffMaxItems caps the items read from each launch by diff_against_baseline.
+	baselineDiffMaxItems = 3000
+	// launchItemsPageSize is the page size of the item queries issued by fetchLaunchItems.
+	launchItemsPageSize = 300
+	// launchScanMaxItems caps the items read from a launch by tools that scan all of its items.
+	launchScanMaxItems = 5000
+	// slowestItemsDefaultLimit is the number of items returned by get_slowest_items by default.
+	slowestItemsDefaultLimit = 10
+	// slowestItemsMaxLimit caps the limit of get_slowest_items.
+	slowestItemsMaxLimit = 100
+	// bulkFinishLaunchesMaxLaunches caps the number of launches accepted by bulk_finish_launches.
+	bulkFinishLaunchesMaxLaunches = 50
+	// bulkFinishLaunchesConcurrency bounds parallel ReportPortal requests of bulk_finish_launches.
+	bulkFinishLaunchesConcurrency = 5
+	// baselineLaunchSort picks the most recent passing launch as the baseline.
+	baselineLaunchSort = "startTime,DESC"
 )
 
 // ToolHandler is a function type for MCP tool handlers with typed input and output.
@@ -73,28 +109,80 @@ func mustMarshalJSON(v any) json.RawMessage {
 	return b
 }
 
+// countOnlyField is the argument of filter tools that returns only the number of matches
+const countOnlyField = "count_only"
+
+// countOnlySchema returns the schema of the count_only argument of filter tools
+func countOnlySchema(entities string) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "boolean",
+		Description: fmt.Sprintf(
+			"Return only the total number of matching %s as a bare number instead of the %s themselves "+
+				"(a single-element page is queried). Pagination and output options are ignored. Default: false",
+			entities,
+			entities,
+		),
+		Default: mustMarshalJSON(false),
+	}
+}
+
+// countOnlyResult returns the total number of matches as the bare number answered for count_only
+func countOnlyResult(total int64) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: strconv.FormatInt(total, 10)}},
+	}
+}
+
 // RegisterLaunchTools registers all launch-related tools and resources with the MCP server.
 // httpClient is an optional pre-configured HTTP client used for the import-launch multipart
 // upload.  When nil a default client with a 30 s timeout is created.
+// When requireConfirm is true, destructive tools refuse to run unless called with confirm: true.
 func RegisterLaunchTools(
 	s *mcp.Server,
 	rpClient *gorp.Client,
 	defaultProjectKey string,
 	analyticsClient *analytics.Analytics,
 	httpClient *http.Client,
+	requireConfirm bool,
+	settings ToolSettings,
 ) {
 	launches := NewLaunchResources(rpClient, analyticsClient, defaultProjectKey, httpClient)
+	launches.requireConfirm = requireConfirm
+	launches.settings = settings
 
 	registerTool(s, launches.toolGetLaunches)
 	registerTool(s, launches.toolGetLastLaunchByName)
+	registerTool(s, launches.toolGetLastLaunchesByNames)
+	registerTool(s, launches.toolCompareLaunchesTable)
+	registerTool(s, launches.toolDiffAgainstBaseline)
+	registerTool(s, launches.toolGetLaunchTrend)
+	registerTool(s, launches.toolGetActiveLaunches)
+	registerTool(s, launches.toolGetLaunchDefectDistribution)
+	registerTool(s, launches.toolGetLaunchLinkedIssues)
+	registerTool(s, launches.toolGetSlowestItems)
+	registerTool(s, launches.toolGetProjectMembers)
 	registerTool(s, launches.toolGetLaunchById)
+	registerTool(s, launches.toolGetLaunchMeta)
+	registerTool(s, launches.toolGetLaunchAttributesMap)
+	registerTool(s, launches.toolGetLaunchAttributeValues)
+	registerTool(s, launches.toolGetLaunchByNumber)
+	registerTool(s, launches.toolGetLaunchByUUID)
 	registerTool(s, launches.toolUpdateLaunch)
 	registerTool(s, launches.toolForceFinishLaunch)
+	registerTool(s, launches.toolStopLaunch)
+	registerTool(s, launches.toolBulkFinishLaunches)
 	registerTool(s, launches.toolDeleteLaunch)
+	registerTool(s, launches.toolGetAnalyzerConfig)
+	registerTool(s, launches.toolUpdateAnalyzerConfig)
+	registerTool(s, launches.toolGetRetentionSettings)
 	registerTool(s, launches.toolRunAutoAnalysis)
+	registerTool(s, launches.toolGetLaunchAnalysisStatus)
 	registerTool(s, launches.toolUniqueErrorAnalysis)
 	registerTool(s, launches.toolRunQualityGate)
 	registerTool(s, launches.toolImportLaunchFromFile)
+	registerTool(s, launches.toolExportLaunch)
+	registerTool(s, launches.toolGetLaunchLogArchive)
+	registerTool(s, launches.toolGetLaunchAttachments)
 
 	registerResourceTemplate(s, launches.resourceLaunch)
 }
@@ -243,6 +331,8 @@ type LaunchResources struct {
 	analytics         *analytics.Analytics
 	importPlugins     importPluginCache
 	httpClient        *http.Client // HTTP client for import multipart upload
+	requireConfirm    bool         // Destructive tools require an explicit confirm: true argument
+	settings          ToolSettings // Server-wide tool defaults
 }
 
 func NewLaunchResources(
@@ -292,7 +382,7 @@ func (lr *LaunchResources) fetchAndCacheImportPlugins(ctx context.Context) error
 		}
 	}
 	lr.importPlugins.set(infos)
-	slog.Debug("import plugin cache refreshed", "plugins", infos)
+	slog.DebugContext(ctx, "import plugin cache refreshed", "plugins", infos)
 	return nil
 }
 
@@ -310,12 +400,50 @@ type GetLaunchesArgs struct {
 	FilterBtwStartTimeTo        string `json:"filter-btw-startTime-to"`
 	FilterGteNumber             uint32 `json:"filter-gte-number"`
 	FilterInUser                string `json:"filter-in-user"`
+	FilterFinishedOnly          bool   `json:"filter-finished-only"`
+	LastHours                   uint   `json:"last_hours"`
+	LastDays                    uint   `json:"last_days"`
+	BeforeID                    uint64 `json:"before_id"`
+	AfterID                     uint64 `json:"after_id"`
+	IncludeLinks                bool   `json:"include_links"`
+	CountOnly                   bool   `json:"count_only"`
+}
+
+// Keyset pagination sort orders for get_launches: walking back from before_id returns the
+// closest older launches first, walking forward from after_id the closest newer ones first.
+const (
+	keysetSortBefore = "id,DESC"
+	keysetSortAfter  = "id,ASC"
+)
+
+// launchesKeysetCursor is the cursor returned by get_launches in keyset mode
+type launchesKeysetCursor struct {
+	BeforeID int64 `json:"before_id,omitempty"`
+	AfterID  int64 `json:"after_id,omitempty"`
+}
+
+// addNextCursor adds a "next_cursor" field to the JSON launches page. A nil cursor is written as
+// null to signal that there are no further launches in that direction.
+func addNextCursor(rawBody []byte, cursor *launchesKeysetCursor) ([]byte, error) {
+	var page map[string]json.RawMessage
+	if err := json.Unmarshal(rawBody, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse launches page: %w", err)
+	}
+	nextCursor, err := json.Marshal(cursor)
+	if err != nil {
+		return nil, err
+	}
+	page["next_cursor"] = nextCursor
+	return json.Marshal(page)
 }
 
 // toolGetLaunches creates a tool to retrieve a paginated list of launches from ReportPortal.
 func (lr *LaunchResources) toolGetLaunches() (*mcp.Tool, ToolHandler[GetLaunchesArgs, any]) {
 	// Build JSON Schema for input parameters
-	properties := utils.SetPaginationProperties(utils.DefaultSortingForLaunches)
+	properties := utils.SetPaginationProperties(
+		lr.settings.Pagination,
+		utils.DefaultSortingForLaunches,
+	)
 	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
 	if err != nil {
 		slog.Error("failed to build project key schema", "error", err)
@@ -327,7 +455,7 @@ func (lr *LaunchResources) toolGetLaunches() (*mcp.Tool, ToolHandler[GetLaunches
 	}
 	properties["filter-has-compositeAttribute"] = &jsonschema.Schema{
 		Type:        "string",
-		Description: "Launches have this combination of the attributes values, format: attribute1,attribute2:attribute3,... etc. string without spaces",
+		Description: "Launches have this combination of the attributes values, format: comma-separated value, key:value or key: (any value of the key) segments without spaces, e.g. demo,platform:ios,build:",
 	}
 	properties["filter-has-attributeKey"] = &jsonschema.Schema{
 		Type:        "string",
@@ -353,6 +481,27 @@ func (lr *LaunchResources) toolGetLaunches() (*mcp.Tool, ToolHandler[GetLaunches
 		Type:        "string",
 		Description: "List of the owner names",
 	}
+	properties["filter-finished-only"] = &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "Exclude launches that are still in progress. Default: false (all launches)",
+		Default:     mustMarshalJSON(false),
+	}
+	utils.SetTimeWindowProperties(properties)
+	properties["before_id"] = &jsonschema.Schema{
+		Type: "integer",
+		Description: "Keyset pagination: return launches with ID lower than this one, newest first. " +
+			"Use next_cursor from the previous response; avoids slow deep page scans. " +
+			"page and page-sort are ignored in keyset mode",
+		Minimum: openapi.PtrFloat64(1),
+	}
+	properties["after_id"] = &jsonschema.Schema{
+		Type: "integer",
+		Description: "Keyset pagination: return launches with ID greater than this one, oldest first. " +
+			"Use next_cursor from the previous response; cannot be combined with before_id",
+		Minimum: openapi.PtrFloat64(1),
+	}
+	properties[utils.IncludeLinksField] = utils.IncludeLinksSchema()
+	properties[countOnlyField] = countOnlySchema("launches")
 
 	return &mcp.Tool{
 			Name:        "get_launches",
@@ -372,6 +521,13 @@ func (lr *LaunchResources) toolGetLaunches() (*mcp.Tool, ToolHandler[GetLaunches
 					return nil, nil, err
 				}
 
+				if err := utils.ValidateCompositeAttribute(
+					"filter-has-compositeAttribute",
+					args.FilterHasCompositeAttribute,
+				); err != nil {
+					return nil, nil, err
+				}
+
 				urlValues := url.Values{}
 
 				// Add optional filters to urlValues if they have values
@@ -381,9 +537,11 @@ func (lr *LaunchResources) toolGetLaunches() (*mcp.Tool, ToolHandler[GetLaunches
 				if args.FilterCntDescription != "" {
 					urlValues.Add("filter.cnt.description", args.FilterCntDescription)
 				}
-				filterStartTime, err := utils.ProcessStartTimeFilter(
+				filterStartTime, err := utils.ProcessStartTimeWindow(
 					args.FilterBtwStartTimeFrom,
 					args.FilterBtwStartTimeTo,
+					args.LastHours,
+					args.LastDays,
 				)
 				if err != nil {
 					return nil, nil, err
@@ -400,19 +558,53 @@ func (lr *LaunchResources) toolGetLaunches() (*mcp.Tool, ToolHandler[GetLaunches
 						strconv.FormatUint(uint64(args.FilterGteNumber), 10),
 					)
 				}
+				if args.FilterFinishedOnly {
+					urlValues.Add("filter.ne.status", utils.StatusInProgress)
+				}
+
+				// Keyset mode replaces the page offset with an ID bound and a fixed ID ordering
+				keyset := args.BeforeID > 0 || args.AfterID > 0
+				page, pageSize, pageSort := args.Page, args.PageSize, args.PageSort
+				if keyset {
+					if args.BeforeID > 0 && args.AfterID > 0 {
+						return nil, nil, fmt.Errorf("before_id and after_id cannot be combined")
+					}
+					if args.Page > utils.FirstPage {
+						return nil, nil, fmt.Errorf(
+							"page cannot be combined with before_id/after_id: use next_cursor instead",
+						)
+					}
+					page = utils.FirstPage
+					if args.BeforeID > 0 {
+						urlValues.Add("filter.lt.id", strconv.FormatUint(args.BeforeID, 10))
+						pageSort = keysetSortBefore
+					} else {
+						urlValues.Add("filter.gt.id", strconv.FormatUint(args.AfterID, 10))
+						pageSort = keysetSortAfter
+					}
+				}
+
+				// Only the page metadata is needed to count the launches
+				if args.CountOnly {
+					page, pageSize = utils.FirstPage, 1
+				}
 
 				ctxWithParams := utils.WithQueryParams(ctx, urlValues)
 				// Build API request and apply pagination directly
 				apiRequest := lr.client.LaunchAPI.GetProjectLaunches(ctxWithParams, project)
 
 				// Apply pagination parameters
-				apiRequest = utils.ApplyPaginationOptions(
+				apiRequest, err = utils.ApplyPaginationOptions(
 					apiRequest,
-					args.Page,
-					args.PageSize,
-					args.PageSort,
+					lr.settings.Pagination,
+					page,
+					pageSize,
+					pageSort,
 					utils.DefaultSortingForLaunches,
 				)
+				if err != nil {
+					return nil, nil, err
+				}
 
 				// Process attribute keys and combine with composite attributes
 				filterAttributes := utils.ProcessAttributeKeys(
@@ -423,7 +615,7 @@ func (lr *LaunchResources) toolGetLaunches() (*mcp.Tool, ToolHandler[GetLaunches
 					apiRequest = apiRequest.FilterHasCompositeAttribute(filterAttributes)
 				}
 
-				_, response, err := apiRequest.Execute()
+				launches, response, err := apiRequest.Execute()
 				if err != nil {
 					return nil, nil, fmt.Errorf(
 						"%s: %w",
@@ -432,7 +624,50 @@ func (lr *LaunchResources) toolGetLaunches() (*mcp.Tool, ToolHandler[GetLaunches
 					)
 				}
 
-				return utils.ReadResponseBody(response)
+				if args.CountOnly {
+					if launches.Page == nil {
+						return nil, nil, fmt.Errorf("launches response has no page metadata")
+					}
+					return countOnlyResult(launches.Page.GetTotalElements()), nil, nil
+				}
+				if !keyset && !args.IncludeLinks {
+					return utils.ReadResponseBody(response)
+				}
+
+				r, err := utils.ReadResponseBodyRaw(response)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+
+				if keyset {
+					// A full page means there may be more launches past the last one returned
+					var nextCursor *launchesKeysetCursor
+					pageSize := args.PageSize
+					if pageSize == 0 {
+						pageSize = lr.settings.Pagination.PageSize()
+					}
+					if n := len(launches.Content); n > 0 && uint(n) >= pageSize {
+						lastID := launches.Content[n-1].Id
+						if args.BeforeID > 0 {
+							nextCursor = &launchesKeysetCursor{BeforeID: lastID}
+						} else {
+							nextCursor = &launchesKeysetCursor{AfterID: lastID}
+						}
+					}
+					r, err = addNextCursor(r, nextCursor)
+					if err != nil {
+						return nil, nil, err
+					}
+				}
+
+				r, err = lr.withLaunchLinks(r, project, args.IncludeLinks)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
 			},
 		)
 }
@@ -449,8 +684,11 @@ func (lr *LaunchResources) toolRunQualityGate() (*mcp.Tool, ToolHandler[LaunchID
 		slog.Error("failed to build project key schema", "error", err)
 	}
 	return &mcp.Tool{
-			Name:        "run_quality_gate",
-			Description: "Run quality gate on ReportPortal launches",
+			Name: "run_quality_gate",
+			Description: "Run quality gate on ReportPortal launches. The call waits for the verdict " +
+				"for at most the ReportPortal client timeout (30s) and, in HTTP mode, the request " +
+				"timeout; if the quality gate takes longer, a timeout result is returned while it " +
+				"keeps running in ReportPortal",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
@@ -476,12 +714,35 @@ func (lr *LaunchResources) toolRunQualityGate() (*mcp.Tool, ToolHandler[LaunchID
 					return nil, nil, fmt.Errorf("launch_id is required")
 				}
 
-				_, response, err := lr.client.PluginAPI.ExecutePluginCommand(ctx, "startQualityGate", "quality gate", project).
+				// The synchronous quality gate call can take minutes; keep the client informed
+				stopProgress := newProgressReporter(req).heartbeat(
+					ctx,
+					progressHeartbeatInterval,
+					fmt.Sprintf("Quality gate is still running for launch %d", args.LaunchID),
+				)
+				// Give up shortly before the request deadline so that the timeout can be reported
+				callCtx := ctx
+				if deadline, ok := ctx.Deadline(); ok {
+					var cancel context.CancelFunc
+					callCtx, cancel = context.WithDeadline(ctx, deadline.Add(-requestDeadlineMargin))
+					defer cancel()
+				}
+				_, response, err := lr.client.PluginAPI.ExecutePluginCommand(callCtx, "startQualityGate", "quality gate", project).
 					RequestBody(map[string]interface{}{
 						"async":    false,
 						"launchId": args.LaunchID,
 					}).
 					Execute()
+				stopProgress()
+				if err != nil && ctx.Err() == nil && isTimeoutError(err) {
+					return &mcp.CallToolResult{
+						Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf(
+							"Quality gate for launch %d did not finish within the request time limit; "+
+								"it keeps running in ReportPortal, check the launch later",
+							args.LaunchID,
+						)}},
+					}, nil, nil
+				}
 				if err != nil {
 					return nil, nil, fmt.Errorf(
 						"%s: %w",
@@ -497,16 +758,20 @@ func (lr *LaunchResources) toolRunQualityGate() (*mcp.Tool, ToolHandler[LaunchID
 
 // GetLastLaunchByNameArgs holds params for get_last_launch_by_name.
 type GetLastLaunchByNameArgs struct {
-	ProjectKey string `json:"projectKey"`
-	Launch     string `json:"launch"`
-	Page       uint   `json:"page"`
-	PageSize   uint   `json:"page-size"`
-	PageSort   string `json:"page-sort"`
+	ProjectKey   string `json:"projectKey"`
+	Launch       string `json:"launch"`
+	Page         uint   `json:"page"`
+	PageSize     uint   `json:"page-size"`
+	PageSort     string `json:"page-sort"`
+	IncludeLinks bool   `json:"include_links"`
 }
 
 // toolGetLastLaunchByName creates a tool to retrieve the last launch by its name.
 func (lr *LaunchResources) toolGetLastLaunchByName() (*mcp.Tool, ToolHandler[GetLastLaunchByNameArgs, any]) {
-	properties := utils.SetPaginationProperties(utils.DefaultSortingForLaunches)
+	properties := utils.SetPaginationProperties(
+		lr.settings.Pagination,
+		utils.DefaultSortingForLaunches,
+	)
 	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
 	if err != nil {
 		slog.Error("failed to build project key schema", "error", err)
@@ -516,6 +781,7 @@ func (lr *LaunchResources) toolGetLastLaunchByName() (*mcp.Tool, ToolHandler[Get
 		Type:        "string",
 		Description: "Launch name",
 	}
+	properties[utils.IncludeLinksField] = utils.IncludeLinksSchema()
 
 	return &mcp.Tool{
 			Name:        "get_last_launch_by_name",
@@ -539,32 +805,30 @@ func (lr *LaunchResources) toolGetLastLaunchByName() (*mcp.Tool, ToolHandler[Get
 					return nil, nil, fmt.Errorf("launch parameter is required")
 				}
 
-				urlValues := url.Values{
-					"filter.cnt.name": {args.Launch},
-				}
-				ctxWithParams := utils.WithQueryParams(ctx, urlValues)
-				apiRequest := lr.client.LaunchAPI.GetProjectLaunches(ctxWithParams, project)
-				apiRequest = utils.ApplyPaginationOptions(
-					apiRequest,
+				launch, err := lr.fetchLastLaunchByName(
+					ctx,
+					project,
+					args.Launch,
 					args.Page,
 					args.PageSize,
 					args.PageSort,
-					utils.DefaultSortingForLaunches,
 				)
-
-				launches, _, err := apiRequest.Execute()
 				if err != nil {
 					return nil, nil, err
 				}
 
-				if len(launches.Content) < 1 {
+				if launch == nil {
 					return nil, nil, fmt.Errorf("no launches found")
 				}
 
-				r, err := json.Marshal(launches.Content[0])
+				r, err := json.Marshal(launch)
 				if err != nil {
 					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 				}
+				r, err = lr.withLaunchLinks(r, project, args.IncludeLinks)
+				if err != nil {
+					return nil, nil, err
+				}
 
 				return &mcp.CallToolResult{
 					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
@@ -573,51 +837,171 @@ func (lr *LaunchResources) toolGetLastLaunchByName() (*mcp.Tool, ToolHandler[Get
 		)
 }
 
-// toolGetLaunchById creates a tool to retrieve a specific launch by its ID directly.
-func (lr *LaunchResources) toolGetLaunchById() (*mcp.Tool, ToolHandler[LaunchIDArgs, any]) {
+// fetchLastLaunchByName returns the first launch whose name contains the given value
+// according to the requested sorting, or nil if no launch matches.
+func (lr *LaunchResources) fetchLastLaunchByName(
+	ctx context.Context,
+	project, name string,
+	page, pageSize uint,
+	pageSort string,
+) (*openapi.ComEpamReportportalBaseReportingLaunchResource, error) {
+	urlValues := url.Values{
+		"filter.cnt.name": {name},
+	}
+	ctxWithParams := utils.WithQueryParams(ctx, urlValues)
+	apiRequest, err := utils.ApplyPaginationOptions(
+		lr.client.LaunchAPI.GetProjectLaunches(ctxWithParams, project),
+		lr.settings.Pagination,
+		page,
+		pageSize,
+		pageSort,
+		utils.DefaultSortingForLaunches,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	launches, _, err := apiRequest.Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(launches.Content) < 1 {
+		return nil, nil
+	}
+
+	return &launches.Content[0], nil
+}
+
+// forEachBounded calls fn for every index in [0, n) using at most concurrency goroutines and
+// returns the error of each call by index. Calls not started before ctx is done get ctx.Err().
+func forEachBounded(ctx context.Context, n, concurrency int, fn func(i int) error) []error {
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			errs[i] = fn(i)
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// GetLastLaunchesByNamesArgs holds params for get_last_launches_by_names.
+type GetLastLaunchesByNamesArgs struct {
+	ProjectKey  string   `json:"projectKey"`
+	LaunchNames []string `json:"launch_names"`
+}
+
+// toolGetLastLaunchesByNames creates a tool to retrieve the last launch for each of several names.
+// Names are queried in parallel (bounded by lastLaunchesByNamesConcurrency); names without any
+// launch are mapped to null in the result.
+func (lr *LaunchResources) toolGetLastLaunchesByNames() (*mcp.Tool, ToolHandler[GetLastLaunchesByNamesArgs, any]) {
 	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
 	if err != nil {
 		slog.Error("failed to build project key schema", "error", err)
 	}
+
 	return &mcp.Tool{
-			Name:        "get_launch_by_id",
-			Description: "Get a specific launch by its ID directly",
+			Name:        "get_last_launches_by_names",
+			Description: "Get the last ReportPortal launch for each of the given launch names. Returns a JSON object mapping each name to its last launch, or null when no launch matches the name",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					utils.ProjectKeyField: pkSchema,
-					"launch_id": {
-						Type:        "integer",
-						Description: "Launch ID",
+					"launch_names": {
+						Type:        "array",
+						Description: "Launch names to look up",
+						Items:       &jsonschema.Schema{Type: "string"},
+						MinItems:    openapi.PtrInt(1),
+						MaxItems:    openapi.PtrInt(lastLaunchesByNamesMaxNames),
 					},
 				},
-				Required: []string{"launch_id"},
+				Required: []string{"launch_names"},
 			},
 		},
 		utils.WithAnalytics(
 			lr.analytics,
-			"get_launch_by_id",
-			func(ctx context.Context, req *mcp.CallToolRequest, args LaunchIDArgs) (*mcp.CallToolResult, any, error) {
+			"get_last_launches_by_names",
+			func(ctx context.Context, req *mcp.CallToolRequest, args GetLastLaunchesByNamesArgs) (*mcp.CallToolResult, any, error) {
 				project, err := utils.ExtractProject(ctx, args.ProjectKey)
 				if err != nil {
 					return nil, nil, err
 				}
 
-				if args.LaunchID == 0 {
-					return nil, nil, fmt.Errorf("launch_id is required")
+				// De-duplicate names while preserving their order
+				names := make([]string, 0, len(args.LaunchNames))
+				seen := make(map[string]struct{}, len(args.LaunchNames))
+				for _, name := range args.LaunchNames {
+					name = strings.TrimSpace(name)
+					if name == "" {
+						continue
+					}
+					if _, ok := seen[name]; ok {
+						continue
+					}
+					seen[name] = struct{}{}
+					names = append(names, name)
 				}
-
-				launch, response, err := lr.client.LaunchAPI.GetLaunch(ctx, strconv.FormatUint(uint64(args.LaunchID), 10), project).
-					Execute()
-				if err != nil {
+				if len(names) == 0 {
+					return nil, nil, fmt.Errorf("launch_names parameter is required")
+				}
+				if len(names) > lastLaunchesByNamesMaxNames {
 					return nil, nil, fmt.Errorf(
-						"%s: %w",
-						utils.ExtractResponseError(err, response),
-						err,
+						"too many launch names: %d (maximum is %d)",
+						len(names),
+						lastLaunchesByNamesMaxNames,
 					)
 				}
 
-				r, err := json.Marshal(launch)
+				launches := make([]*openapi.ComEpamReportportalBaseReportingLaunchResource, len(names))
+				errs := forEachBounded(
+					ctx,
+					len(names),
+					lastLaunchesByNamesConcurrency,
+					func(i int) error {
+						var err error
+						launches[i], err = lr.fetchLastLaunchByName(
+							ctx,
+							project,
+							names[i],
+							utils.FirstPage,
+							1,
+							utils.DefaultSortingForLaunches,
+						)
+						return err
+					},
+				)
+
+				launchesByName := make(
+					map[string]*openapi.ComEpamReportportalBaseReportingLaunchResource,
+					len(names),
+				)
+				for i, name := range names {
+					if errs[i] != nil {
+						return nil, nil, fmt.Errorf(
+							"failed to get last launch for %q: %w",
+							name,
+							errs[i],
+						)
+					}
+					launchesByName[name] = launches[i]
+				}
+
+				r, err := json.Marshal(launchesByName)
 				if err != nil {
 					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 				}
@@ -629,121 +1013,313 @@ func (lr *LaunchResources) toolGetLaunchById() (*mcp.Tool, ToolHandler[LaunchIDA
 		)
 }
 
-func (lr *LaunchResources) toolDeleteLaunch() (*mcp.Tool, ToolHandler[LaunchIDArgs, any]) {
+// CompareLaunchesTableArgs holds params for compare_launches_table.
+type CompareLaunchesTableArgs struct {
+	ProjectKey string   `json:"projectKey"`
+	LaunchIDs  []uint32 `json:"launch_ids"`
+}
+
+// launchStatisticsRow is one row of the compare_launches_table result
+type launchStatisticsRow struct {
+	ID        int64            `json:"id"`
+	Name      string           `json:"name"`
+	Number    int64            `json:"number"`
+	Status    string           `json:"status"`
+	StartTime time.Time        `json:"start_time"`
+	Total     int32            `json:"total"`
+	Passed    int32            `json:"passed"`
+	Failed    int32            `json:"failed"`
+	Skipped   int32            `json:"skipped"`
+	Defects   map[string]int32 `json:"defects"`   // defect type -> total count
+	PassRate  float64          `json:"pass_rate"` // percentage of passed executions
+}
+
+// newLaunchStatisticsRow extracts execution and defect counts from the launch statistics
+func newLaunchStatisticsRow(
+	launch *openapi.ComEpamReportportalBaseReportingLaunchResource,
+) launchStatisticsRow {
+	row := launchStatisticsRow{
+		ID:        launch.Id,
+		Name:      launch.Name,
+		Number:    launch.Number,
+		Status:    launch.Status,
+		StartTime: launch.StartTime,
+		Defects:   map[string]int32{},
+	}
+	if launch.Statistics == nil {
+		return row
+	}
+	if launch.Statistics.Executions != nil {
+		executions := *launch.Statistics.Executions
+		row.Total = executions["total"]
+		row.Passed = executions["passed"]
+		row.Failed = executions["failed"]
+		row.Skipped = executions["skipped"]
+	}
+	if launch.Statistics.Defects != nil {
+		for defectType, counts := range *launch.Statistics.Defects {
+			row.Defects[defectType] = counts["total"]
+		}
+	}
+	if row.Total > 0 {
+		row.PassRate = math.Round(float64(row.Passed)/float64(row.Total)*10000) / 100
+	}
+	return row
+}
+
+// toolCompareLaunchesTable creates a tool that returns a statistics table across several launches.
+// Launches are fetched in parallel (bounded by lastLaunchesByNamesConcurrency) and the rows are
+// sorted by launch number, newest first.
+func (lr *LaunchResources) toolCompareLaunchesTable() (*mcp.Tool, ToolHandler[CompareLaunchesTableArgs, any]) {
 	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
 	if err != nil {
 		slog.Error("failed to build project key schema", "error", err)
 	}
+
 	return &mcp.Tool{
-			Name:        "launch_delete",
-			Description: "Delete ReportPortal launch",
+			Name: "compare_launches_table",
+			Description: "Compare several launches side by side. Returns one row per launch with " +
+				"total/passed/failed/skipped counts, defect counts per type and pass rate (%), " +
+				"sorted by launch number descending. Useful for trends such as the last nightly runs",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					utils.ProjectKeyField: pkSchema,
-					"launch_id": {
-						Type:        "integer",
-						Description: "Launch ID",
+					"launch_ids": {
+						Type:        "array",
+						Description: "IDs of the launches to compare",
+						Items:       &jsonschema.Schema{Type: "integer"},
+						MinItems:    openapi.PtrInt(1),
+						MaxItems:    openapi.PtrInt(launchComparisonMaxLaunches),
 					},
 				},
-				Required: []string{"launch_id"},
+				Required: []string{"launch_ids"},
 			},
 		},
 		utils.WithAnalytics(
 			lr.analytics,
-			"launch_delete",
-			func(ctx context.Context, req *mcp.CallToolRequest, args LaunchIDArgs) (*mcp.CallToolResult, any, error) {
+			"compare_launches_table",
+			func(ctx context.Context, req *mcp.CallToolRequest, args CompareLaunchesTableArgs) (*mcp.CallToolResult, any, error) {
 				project, err := utils.ExtractProject(ctx, args.ProjectKey)
 				if err != nil {
 					return nil, nil, err
 				}
 
-				if args.LaunchID == 0 {
-					return nil, nil, fmt.Errorf("launch_id is required")
+				// De-duplicate IDs while preserving their order
+				launchIDs := make([]uint32, 0, len(args.LaunchIDs))
+				for _, id := range args.LaunchIDs {
+					if id != 0 && !slices.Contains(launchIDs, id) {
+						launchIDs = append(launchIDs, id)
+					}
+				}
+				if len(launchIDs) == 0 {
+					return nil, nil, fmt.Errorf("launch_ids parameter is required")
+				}
+				if len(launchIDs) > launchComparisonMaxLaunches {
+					return nil, nil, fmt.Errorf(
+						"too many launch IDs: %d (maximum is %d)",
+						len(launchIDs),
+						launchComparisonMaxLaunches,
+					)
 				}
 
-				_, _, err = lr.client.LaunchAPI.DeleteLaunch(ctx, int64(args.LaunchID), project).
-					Execute()
+				rows := make([]launchStatisticsRow, len(launchIDs))
+				errs := forEachBounded(
+					ctx,
+					len(launchIDs),
+					lastLaunchesByNamesConcurrency,
+					func(i int) error {
+						launch, response, err := lr.client.LaunchAPI.GetLaunch(
+							ctx,
+							strconv.FormatUint(uint64(launchIDs[i]), 10),
+							project,
+						).Execute()
+						if err != nil {
+							return fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+						}
+						rows[i] = newLaunchStatisticsRow(launch)
+						return nil
+					},
+				)
+				for i, id := range launchIDs {
+					if errs[i] != nil {
+						return nil, nil, fmt.Errorf("failed to get launch %d: %w", id, errs[i])
+					}
+				}
+
+				slices.SortStableFunc(rows, func(a, b launchStatisticsRow) int {
+					return cmp.Or(cmp.Compare(b.Number, a.Number), cmp.Compare(b.ID, a.ID))
+				})
+
+				r, err := json.Marshal(rows)
 				if err != nil {
-					return nil, nil, err
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 				}
 
 				return &mcp.CallToolResult{
-					Content: []mcp.Content{
-						&mcp.TextContent{
-							Text: fmt.Sprintf("Launch '%d' has been deleted", args.LaunchID),
-						},
-					},
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
 				}, nil, nil
 			},
 		)
 }
 
-// RunAutoAnalysisArgs holds params for run_auto_analysis.
-type RunAutoAnalysisArgs struct {
-	ProjectKey        string   `json:"projectKey"`
-	LaunchID          uint32   `json:"launch_id"`
-	AnalyzerMode      string   `json:"analyzer_mode"`
-	AnalyzerType      string   `json:"analyzer_type"`
-	AnalyzerItemModes []string `json:"analyzer_item_modes"`
+// DiffAgainstBaselineArgs holds params for diff_against_baseline.
+type DiffAgainstBaselineArgs struct {
+	ProjectKey         string `json:"projectKey"`
+	LaunchID           uint32 `json:"launch_id"`
+	BaselineLaunchName string `json:"baseline_launch_name"`
 }
 
-func (lr *LaunchResources) toolRunAutoAnalysis() (*mcp.Tool, ToolHandler[RunAutoAnalysisArgs, any]) {
+// baselineLaunch identifies the launch a diff_against_baseline result was computed against
+type baselineLaunch struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Number    int64     `json:"number"`
+	StartTime time.Time `json:"start_time"`
+}
+
+// newlyFailingItem is a test item that fails in the launch but passed in the baseline
+type newlyFailingItem struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	TestCaseHash   int32  `json:"test_case_hash"`
+	Path           string `json:"path,omitempty"`
+	DefectType     string `json:"defect_type,omitempty"`
+	BaselineItemID int64  `json:"baseline_item_id"`
+}
+
+// fetchBaselineLaunch returns the latest PASSED launch with the given name other than
+// excludeID, or nil if there is none.
+func (lr *LaunchResources) fetchBaselineLaunch(
+	ctx context.Context,
+	project, name string,
+	excludeID int64,
+) (*openapi.ComEpamReportportalBaseReportingLaunchResource, error) {
+	// Two launches are requested in case the launch being diffed is itself the latest passing one
+	apiRequest, err := utils.ApplyPaginationOptions(
+		lr.client.LaunchAPI.GetProjectLaunches(ctx, project).
+			FilterEqName(name).
+			FilterEqStatus("PASSED"),
+		lr.settings.Pagination,
+		utils.FirstPage,
+		2,
+		baselineLaunchSort,
+		baselineLaunchSort,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	launches, response, err := apiRequest.Execute()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+	}
+	for i := range launches.Content {
+		if launches.Content[i].Id != excludeID {
+			return &launches.Content[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// fetchLaunchItems reads the pages of leaf test items of a launch matching the extra filters
+// (e.g. filter.eq.status) and returns up to maxItems of them. The bool reports whether more
+// matching items were left unread.
+func (lr *LaunchResources) fetchLaunchItems(
+	ctx context.Context,
+	project string,
+	launchID int64,
+	filters url.Values,
+	maxItems int,
+) ([]openapi.ComEpamReportportalBaseReportingTestItemResource, bool, error) {
+	launchIDStr := strconv.FormatInt(launchID, 10)
+	queryParams := url.Values{
+		"launchId":              {launchIDStr},
+		"providerType":          {utils.DefaultProviderType},
+		"filter.eq.hasStats":    {utils.DefaultFilterEqHasStats},
+		"filter.eq.hasChildren": {utils.DefaultFilterEqHasChildren},
+		"filter.in.type":        {utils.DefaultFilterInType},
+	}
+	for key, values := range filters {
+		queryParams[key] = values
+	}
+	ctxWithParams := utils.WithQueryParams(ctx, queryParams)
+
+	var items []openapi.ComEpamReportportalBaseReportingTestItemResource
+	for page := uint(utils.FirstPage); ; page++ {
+		apiRequest, err := utils.ApplyPaginationOptions(
+			lr.client.TestItemAPI.GetTestItemsV2(ctxWithParams, project).
+				Params(map[string]string{"launchId": launchIDStr}),
+			lr.settings.Pagination,
+			page,
+			launchItemsPageSize,
+			utils.DefaultSortingForItems,
+			utils.DefaultSortingForItems,
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		itemsPage, response, err := apiRequest.Execute()
+		if err != nil {
+			return nil, false, fmt.Errorf(
+				"%s: %w",
+				utils.ExtractResponseError(err, response),
+				err,
+			)
+		}
+
+		hasNext := len(itemsPage.Content) > 0 && itemsPage.Page != nil && itemsPage.Page.GetHasNext()
+		if len(items)+len(itemsPage.Content) > maxItems {
+			return append(items, itemsPage.Content[:maxItems-len(items)]...), true, nil
+		}
+		items = append(items, itemsPage.Content...)
+		if !hasNext {
+			return items, false, nil
+		}
+		if len(items) == maxItems {
+			return items, true, nil
+		}
+	}
+}
+
+// toolDiffAgainstBaseline creates a tool that lists the test items failing in a launch that
+// passed in the latest passing launch of a baseline name. Items are matched by test case hash
+// and at most baselineDiffMaxItems items are read from each launch.
+func (lr *LaunchResources) toolDiffAgainstBaseline() (*mcp.Tool, ToolHandler[DiffAgainstBaselineArgs, any]) {
 	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
 	if err != nil {
 		slog.Error("failed to build project key schema", "error", err)
 	}
+
 	return &mcp.Tool{
-			Name:        "run_auto_analysis",
-			Description: "Run auto analysis on ReportPortal launch",
+			Name: "diff_against_baseline",
+			Description: "Find regressions of a launch against a baseline: resolves the latest PASSED launch " +
+				"named baseline_launch_name and returns the test items that fail in launch_id but passed " +
+				"in the baseline, matched by test case hash. " +
+				fmt.Sprintf("At most %d items are read from each launch; ", baselineDiffMaxItems) +
+				"truncated is true when that limit was hit",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					utils.ProjectKeyField: pkSchema,
 					"launch_id": {
 						Type:        "integer",
-						Description: "Launch ID",
-					},
-					"analyzer_mode": {
-						Type:        "string",
-						Description: "Analyzer mode, only one of the values is allowed",
-						Enum: []any{
-							"all",
-							"launch_name",
-							"current_launch",
-							"previous_launch",
-							"current_and_the_same_name",
-						},
-						Default: mustMarshalJSON("current_launch"),
+						Description: "ID of the launch to check for regressions",
+						Minimum:     openapi.PtrFloat64(1),
 					},
-					"analyzer_type": {
+					"baseline_launch_name": {
 						Type:        "string",
-						Description: "Analyzer type, only one of the values is allowed",
-						Enum:        []any{"autoAnalyzer", "patternAnalyzer"},
-						Default:     mustMarshalJSON("autoAnalyzer"),
-					},
-					"analyzer_item_modes": {
-						Type:        "array",
-						Description: "Analyze items modes, one or more of the values are allowed",
-						Items: &jsonschema.Schema{
-							Type: "string",
-							Enum: []any{"to_investigate", "auto_analyzed", "manually_analyzed"},
-						},
-						Default: mustMarshalJSON([]string{"to_investigate"}),
+						Description: "Exact name of the launches to use as the baseline",
 					},
 				},
-				Required: []string{
-					"launch_id",
-					"analyzer_mode",
-					"analyzer_type",
-					"analyzer_item_modes",
-				},
+				Required: []string{"launch_id", "baseline_launch_name"},
 			},
 		},
 		utils.WithAnalytics(
 			lr.analytics,
-			"run_auto_analysis",
-			func(ctx context.Context, req *mcp.CallToolRequest, args RunAutoAnalysisArgs) (*mcp.CallToolResult, any, error) {
+			"diff_against_baseline",
+			func(ctx context.Context, req *mcp.CallToolRequest, args DiffAgainstBaselineArgs) (*mcp.CallToolResult, any, error) {
 				project, err := utils.ExtractProject(ctx, args.ProjectKey)
 				if err != nil {
 					return nil, nil, err
@@ -752,51 +1328,2672 @@ func (lr *LaunchResources) toolRunAutoAnalysis() (*mcp.Tool, ToolHandler[RunAuto
 				if args.LaunchID == 0 {
 					return nil, nil, fmt.Errorf("launch_id is required")
 				}
+				baselineName := strings.TrimSpace(args.BaselineLaunchName)
+				if baselineName == "" {
+					return nil, nil, fmt.Errorf("baseline_launch_name is required")
+				}
+				launchID := int64(args.LaunchID)
 
-				analyzerItemModes := args.AnalyzerItemModes
-				if len(analyzerItemModes) == 0 {
+				baseline, err := lr.fetchBaselineLaunch(ctx, project, baselineName, launchID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to resolve baseline launch: %w", err)
+				}
+				if baseline == nil {
+					return nil, nil, fmt.Errorf("no passing launch named %q found", baselineName)
+				}
+
+				failedItems, failedTruncated, err := lr.fetchLaunchItems(
+					ctx,
+					project,
+					launchID,
+					url.Values{"filter.eq.status": {"FAILED"}},
+					baselineDiffMaxItems,
+				)
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"failed to get failed items of launch %d: %w",
+						launchID,
+						err,
+					)
+				}
+				var passedItems []openapi.ComEpamReportportalBaseReportingTestItemResource
+				passedTruncated := false
+				if len(failedItems) > 0 {
+					passedItems, passedTruncated, err = lr.fetchLaunchItems(
+						ctx,
+						project,
+						baseline.Id,
+						url.Values{"filter.eq.status": {"PASSED"}},
+						baselineDiffMaxItems,
+					)
+					if err != nil {
+						return nil, nil, fmt.Errorf(
+							"failed to get passed items of baseline launch %d: %w",
+							baseline.Id,
+							err,
+						)
+					}
+				}
+
+				passedByHash := make(map[int32]int64, len(passedItems))
+				for _, item := range passedItems {
+					if item.TestCaseHash != nil {
+						passedByHash[*item.TestCaseHash] = item.GetId()
+					}
+				}
+				newlyFailing := make([]newlyFailingItem, 0)
+				for _, item := range failedItems {
+					if item.TestCaseHash == nil {
+						continue
+					}
+					baselineItemID, ok := passedByHash[*item.TestCaseHash]
+					if !ok {
+						continue
+					}
+					diffItem := newlyFailingItem{
+						ID:             item.GetId(),
+						Name:           item.GetName(),
+						TestCaseHash:   *item.TestCaseHash,
+						Path:           item.GetPath(),
+						BaselineItemID: baselineItemID,
+					}
+					if item.Issue != nil {
+						diffItem.DefectType = item.Issue.IssueType
+					}
+					newlyFailing = append(newlyFailing, diffItem)
+				}
+
+				r, err := json.Marshal(map[string]any{
+					"launch_id": args.LaunchID,
+					"baseline_launch": baselineLaunch{
+						ID:        baseline.Id,
+						Name:      baseline.Name,
+						Number:    baseline.Number,
+						StartTime: baseline.StartTime,
+					},
+					"newly_failing":          newlyFailing,
+					"total":                  len(newlyFailing),
+					"failed_items_scanned":   len(failedItems),
+					"baseline_items_scanned": len(passedItems),
+					"truncated":              failedTruncated || passedTruncated,
+				})
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// linkedIssue is an external issue (e.g. a Jira ticket) linked to test items of a launch
+type linkedIssue struct {
+	TicketID   string            `json:"ticket_id"`
+	URL        string            `json:"url"`
+	BtsProject string            `json:"bts_project"`
+	BtsURL     string            `json:"bts_url"`
+	Items      []linkedIssueItem `json:"items"`
+}
+
+// linkedIssueItem is a test item that references a linkedIssue
+type linkedIssueItem struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	DefectType string `json:"defect_type"`
+}
+
+// groupLinkedIssues groups the external issues of the items by ticket, ordered by ticket ID.
+// Tickets are told apart by bug tracking system too, since IDs are only unique within one.
+func groupLinkedIssues(
+	items []openapi.ComEpamReportportalBaseReportingTestItemResource,
+) []linkedIssue {
+	type ticketKey struct{ btsURL, btsProject, ticketID string }
+	byTicket := make(map[ticketKey]*linkedIssue)
+	for _, item := range items {
+		if item.Issue == nil {
+			continue
+		}
+		for _, external := range item.Issue.ExternalSystemIssues {
+			key := ticketKey{external.BtsUrl, external.BtsProject, external.TicketId}
+			issue, ok := byTicket[key]
+			if !ok {
+				issue = &linkedIssue{
+					TicketID:   external.TicketId,
+					URL:        external.Url,
+					BtsProject: external.BtsProject,
+					BtsURL:     external.BtsUrl,
+				}
+				byTicket[key] = issue
+			}
+			// The same ticket can be linked to an item more than once
+			if slices.ContainsFunc(issue.Items, func(linked linkedIssueItem) bool {
+				return linked.ID == item.GetId()
+			}) {
+				continue
+			}
+			issue.Items = append(issue.Items, linkedIssueItem{
+				ID:         item.GetId(),
+				Name:       item.GetName(),
+				Status:     item.GetStatus(),
+				DefectType: item.Issue.IssueType,
+			})
+		}
+	}
+
+	issues := make([]linkedIssue, 0, len(byTicket))
+	for _, issue := range byTicket {
+		issues = append(issues, *issue)
+	}
+	slices.SortFunc(issues, func(a, b linkedIssue) int {
+		return cmp.Or(
+			strings.Compare(a.TicketID, b.TicketID),
+			strings.Compare(a.BtsURL, b.BtsURL),
+			strings.Compare(a.BtsProject, b.BtsProject),
+		)
+	})
+	return issues
+}
+
+// toolGetLaunchLinkedIssues creates a tool that lists the external issues linked to the test
+// items of a launch, grouped by ticket. At most launchScanMaxItems items are read.
+func (lr *LaunchResources) toolGetLaunchLinkedIssues() (*mcp.Tool, ToolHandler[LaunchIDArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_launch_linked_issues",
+			Description: "Get the external issues (e.g. Jira tickets) linked to the test items of a launch, " +
+				"e.g. for release notes. Returns each ticket once with its URL and the items referencing it. " +
+				fmt.Sprintf("At most %d items of the launch are read; ", launchScanMaxItems) +
+				"truncated is true when that limit was hit",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "ID of the launch",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_launch_linked_issues",
+			func(ctx context.Context, req *mcp.CallToolRequest, args LaunchIDArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.LaunchID == 0 {
+					return nil, nil, fmt.Errorf("launch_id is required")
+				}
+
+				items, truncated, err := lr.fetchLaunchItems(
+					ctx,
+					project,
+					int64(args.LaunchID),
+					nil,
+					launchScanMaxItems,
+				)
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"failed to get test items of launch %d: %w",
+						args.LaunchID,
+						err,
+					)
+				}
+				issues := groupLinkedIssues(items)
+
+				r, err := json.Marshal(map[string]any{
+					"launch_id":     args.LaunchID,
+					"issues":        issues,
+					"total":         len(issues),
+					"items_scanned": len(items),
+					"truncated":     truncated,
+				})
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// GetSlowestItemsArgs holds params for get_slowest_items.
+type GetSlowestItemsArgs struct {
+	ProjectKey string `json:"projectKey"`
+	LaunchID   uint32 `json:"launch_id"`
+	Limit      *int   `json:"limit"`
+}
+
+// slowItem is a finished test item with its duration, as returned by get_slowest_items
+type slowItem struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	DurationMs int64  `json:"duration_ms"`
+	Duration   string `json:"duration"`
+}
+
+// slowestItems returns the limit finished items with the longest duration, longest first.
+// The second value is the number of items skipped for lacking a start or end time.
+func slowestItems(
+	items []openapi.ComEpamReportportalBaseReportingTestItemResource,
+	limit int,
+) ([]slowItem, int) {
+	slow := make([]slowItem, 0, len(items))
+	skipped := 0
+	for _, item := range items {
+		if item.StartTime == nil || item.EndTime == nil {
+			skipped++
+			continue
+		}
+		duration := item.EndTime.Sub(*item.StartTime)
+		slow = append(slow, slowItem{
+			ID:         item.GetId(),
+			Name:       item.GetName(),
+			Status:     item.GetStatus(),
+			DurationMs: duration.Milliseconds(),
+			Duration:   duration.Round(time.Millisecond).String(),
+		})
+	}
+	slices.SortFunc(slow, func(a, b slowItem) int {
+		return cmp.Or(cmp.Compare(b.DurationMs, a.DurationMs), cmp.Compare(a.ID, b.ID))
+	})
+	return slow[:min(limit, len(slow))], skipped
+}
+
+// toolGetSlowestItems creates a tool that lists the test items of a launch that took the longest.
+// At most launchScanMaxItems items are read.
+func (lr *LaunchResources) toolGetSlowestItems() (*mcp.Tool, ToolHandler[GetSlowestItemsArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_slowest_items",
+			Description: "Get the slowest test items of a launch for performance triage: the limit items " +
+				"with the longest duration (endTime - startTime), longest first, with name, duration " +
+				"and status. Items still in progress are skipped. " +
+				fmt.Sprintf("At most %d items of the launch are read; ", launchScanMaxItems) +
+				"truncated is true when that limit was hit",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "ID of the launch",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+					"limit": {
+						Type:        "integer",
+						Description: "Number of slowest items to return",
+						Default:     mustMarshalJSON(slowestItemsDefaultLimit),
+						Minimum:     openapi.PtrFloat64(1),
+						Maximum:     openapi.PtrFloat64(slowestItemsMaxLimit),
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_slowest_items",
+			func(ctx context.Context, req *mcp.CallToolRequest, args GetSlowestItemsArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.LaunchID == 0 {
+					return nil, nil, fmt.Errorf("launch_id is required")
+				}
+				limit := slowestItemsDefaultLimit
+				if args.Limit != nil {
+					limit = *args.Limit
+				}
+				if limit < 1 || limit > slowestItemsMaxLimit {
+					return nil, nil, fmt.Errorf(
+						"limit must be between 1 and %d, got %d",
+						slowestItemsMaxLimit,
+						limit,
+					)
+				}
+
+				items, truncated, err := lr.fetchLaunchItems(
+					ctx,
+					project,
+					int64(args.LaunchID),
+					nil,
+					launchScanMaxItems,
+				)
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"failed to get test items of launch %d: %w",
+						args.LaunchID,
+						err,
+					)
+				}
+				slow, unfinished := slowestItems(items, limit)
+
+				r, err := json.Marshal(map[string]any{
+					"launch_id":        args.LaunchID,
+					"items":            slow,
+					"items_scanned":    len(items),
+					"items_unfinished": unfinished,
+					"truncated":        truncated,
+				})
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// launchTrendSort orders the launches of one name from the latest run backwards
+const launchTrendSort = "number,DESC"
+
+// GetLaunchTrendArgs holds params for get_launch_trend.
+type GetLaunchTrendArgs struct {
+	ProjectKey string `json:"projectKey"`
+	LaunchName string `json:"launch_name"`
+	Depth      *int   `json:"depth"`
+}
+
+// launchTrend is the get_launch_trend response: the latest launches of one name, oldest first
+type launchTrend struct {
+	LaunchName string                `json:"launch_name"`
+	Count      int                   `json:"count"`
+	Launches   []launchStatisticsRow `json:"launches"`
+	// PassRateChange is the pass rate of the latest launch minus that of the oldest one
+	PassRateChange float64 `json:"pass_rate_change"`
+	// AveragePassRate is the mean pass rate over the launches with executions
+	AveragePassRate float64 `json:"average_pass_rate"`
+}
+
+// newLaunchTrend orders the launch rows oldest first and summarizes their pass rates
+func newLaunchTrend(launchName string, rows []launchStatisticsRow) launchTrend {
+	slices.SortStableFunc(rows, func(a, b launchStatisticsRow) int {
+		return cmp.Or(cmp.Compare(a.Number, b.Number), cmp.Compare(a.ID, b.ID))
+	})
+	trend := launchTrend{LaunchName: launchName, Count: len(rows), Launches: rows}
+
+	var sum float64
+	var measured []launchStatisticsRow
+	for _, row := range rows {
+		if row.Total > 0 {
+			measured = append(measured, row)
+			sum += row.PassRate
+		}
+	}
+	if len(measured) > 0 {
+		trend.AveragePassRate = math.Round(sum/float64(len(measured))*100) / 100
+		trend.PassRateChange = math.Round(
+			(measured[len(measured)-1].PassRate-measured[0].PassRate)*100,
+		) / 100
+	}
+	return trend
+}
+
+// toolGetLaunchTrend creates a tool that returns the pass rate series of the latest launches with
+// a given name. Pages of launchTrendPageSize launches are fetched in parallel (bounded by
+// lastLaunchesByNamesConcurrency).
+func (lr *LaunchResources) toolGetLaunchTrend() (*mcp.Tool, ToolHandler[GetLaunchTrendArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_launch_trend",
+			Description: "Get the pass rate trend of the latest launches with an exact name: returns " +
+				"the last depth launches oldest first, each with total/passed/failed/skipped counts " +
+				"and pass rate (%), plus the average pass rate and its change from the oldest to the " +
+				"latest launch. Answers whether a suite is getting better or worse",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_name": {
+						Type:        "string",
+						Description: "Exact launch name",
+					},
+					"depth": {
+						Type:        "integer",
+						Description: "Number of latest launches to include",
+						Default:     mustMarshalJSON(launchTrendDefaultDepth),
+						Minimum:     openapi.PtrFloat64(1),
+						Maximum:     openapi.PtrFloat64(launchTrendMaxDepth),
+					},
+				},
+				Required: []string{"launch_name"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_launch_trend",
+			func(ctx context.Context, req *mcp.CallToolRequest, args GetLaunchTrendArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				launchName := strings.TrimSpace(args.LaunchName)
+				if launchName == "" {
+					return nil, nil, fmt.Errorf("launch_name is required")
+				}
+				depth := launchTrendDefaultDepth
+				if args.Depth != nil {
+					depth = *args.Depth
+				}
+				if depth < 1 || depth > launchTrendMaxDepth {
+					return nil, nil, fmt.Errorf(
+						"depth must be between 1 and %d, got %d",
+						launchTrendMaxDepth,
+						depth,
+					)
+				}
+
+				pageSize := min(depth, launchTrendPageSize)
+				pages := make(
+					[][]openapi.ComEpamReportportalBaseReportingLaunchResource,
+					(depth+pageSize-1)/pageSize,
+				)
+				errs := forEachBounded(ctx, len(pages), lastLaunchesByNamesConcurrency, func(i int) error {
+					apiRequest, err := utils.ApplyPaginationOptions(
+						lr.client.LaunchAPI.GetProjectLaunches(ctx, project).
+							FilterEqName(launchName),
+						lr.settings.Pagination,
+						uint(i+utils.FirstPage), //nolint:gosec // page indexes are non-negative
+						uint(pageSize),          //nolint:gosec // between 1 and launchTrendPageSize
+						launchTrendSort,
+						launchTrendSort,
+					)
+					if err != nil {
+						return err
+					}
+					launches, response, err := apiRequest.Execute()
+					if err != nil {
+						return fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+					}
+					pages[i] = launches.Content
+					return nil
+				})
+				if err := errors.Join(errs...); err != nil {
+					return nil, nil, err
+				}
+
+				rows := make([]launchStatisticsRow, 0, depth)
+				for _, page := range pages {
+					for i := range page {
+						if len(rows) < depth {
+							rows = append(rows, newLaunchStatisticsRow(&page[i]))
+						}
+					}
+				}
+
+				r, err := json.Marshal(newLaunchTrend(launchName, rows))
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// LaunchDefectDistributionArgs defines the input for get_launch_defect_distribution
+type LaunchDefectDistributionArgs struct {
+	ProjectKey string `json:"projectKey"`
+	LaunchID   uint32 `json:"launch_id"`
+}
+
+// launchDefectDistribution is the get_launch_defect_distribution response
+type launchDefectDistribution struct {
+	LaunchID   int64            `json:"launch_id"`
+	LaunchName string           `json:"launch_name"`
+	Number     int64            `json:"number"`
+	Total      int32            `json:"total"`
+	ByType     map[string]int32 `json:"by_type"` // defect type group (e.g. PRODUCT_BUG) -> count
+	Defects    map[string]int32 `json:"defects"` // defect subtype name -> count
+}
+
+// defectSubtypeNames maps the defect subtype locators of a project to their names
+func defectSubtypeNames(
+	project *openapi.ComEpamReportportalBaseModelProjectProjectResource,
+) map[string]string {
+	names := make(map[string]string)
+	for _, subtypes := range project.Configuration.GetSubTypes() {
+		for _, subtype := range subtypes {
+			if subtype.GetLocator() != "" && subtype.GetLongName() != "" {
+				names[subtype.GetLocator()] = subtype.GetLongName()
+			}
+		}
+	}
+	return names
+}
+
+// newLaunchDefectDistribution labels the defect statistics of a launch with subtype names.
+// Locators without a known name are reported under the locator itself; zero counts are omitted.
+func newLaunchDefectDistribution(
+	launch *openapi.ComEpamReportportalBaseReportingLaunchResource,
+	subtypeNames map[string]string,
+) launchDefectDistribution {
+	distribution := launchDefectDistribution{
+		LaunchID:   launch.Id,
+		LaunchName: launch.Name,
+		Number:     launch.Number,
+		ByType:     map[string]int32{},
+		Defects:    map[string]int32{},
+	}
+	if launch.Statistics == nil || launch.Statistics.Defects == nil {
+		return distribution
+	}
+	for group, counts := range *launch.Statistics.Defects {
+		if counts["total"] > 0 {
+			distribution.ByType[strings.ToUpper(group)] = counts["total"]
+			distribution.Total += counts["total"]
+		}
+		for locator, count := range counts {
+			if locator == "total" || count == 0 {
+				continue
+			}
+			label := cmp.Or(subtypeNames[locator], locator)
+			distribution.Defects[label] += count
+		}
+	}
+	return distribution
+}
+
+// toolGetLaunchDefectDistribution creates a tool that returns the defect breakdown of a launch,
+// labeled with the project's defect type names (the data of get_project_defect_types).
+func (lr *LaunchResources) toolGetLaunchDefectDistribution() (*mcp.Tool, ToolHandler[LaunchDefectDistributionArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_launch_defect_distribution",
+			Description: "Get the defect breakdown of a launch: the number of defects per defect type " +
+				"(e.g. Product Bug, To Investigate, custom types) labeled with the project's defect " +
+				"type names, plus totals per defect group. Answers which kinds of defects dominate a launch",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_launch_defect_distribution",
+			func(ctx context.Context, req *mcp.CallToolRequest, args LaunchDefectDistributionArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+				if args.LaunchID == 0 {
+					return nil, nil, fmt.Errorf("launch_id is required")
+				}
+
+				launch, response, err := lr.client.LaunchAPI.GetLaunch(
+					ctx,
+					strconv.FormatUint(uint64(args.LaunchID), 10),
+					project,
+				).Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				// Defect type names are configured per project (get_project_defect_types)
+				projectResource, response, err := lr.client.ProjectAPI.GetProject(ctx, project).Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				r, err := json.Marshal(
+					newLaunchDefectDistribution(launch, defectSubtypeNames(projectResource)),
+				)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// activeLaunchesSort lists the most recently started running launches first
+const activeLaunchesSort = "startTime,DESC"
+
+// GetActiveLaunchesArgs defines the input for get_active_launches
+type GetActiveLaunchesArgs struct {
+	ProjectKey string `json:"projectKey"`
+	PageSize   uint   `json:"page-size"`
+}
+
+// activeLaunchesResult is the get_active_launches response: the total number of running
+// launches and the summaries of the returned ones
+type activeLaunchesResult struct {
+	Count    int64                 `json:"count"`
+	Launches []launchStatisticsRow `json:"launches"`
+}
+
+// toolGetActiveLaunches creates a tool that lists the launches currently IN_PROGRESS,
+// most recently started first.
+func (lr *LaunchResources) toolGetActiveLaunches() (*mcp.Tool, ToolHandler[GetActiveLaunchesArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_active_launches",
+			Description: "Get launches that are currently running (IN_PROGRESS), most recently started " +
+				"first. Returns the number of running launches and a summary of each one",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"page-size": {
+						Type:        "integer",
+						Description: "Maximum number of launches to return",
+						Default:     mustMarshalJSON(lr.settings.Pagination.PageSize()),
+						Minimum:     openapi.PtrFloat64(1),
+					},
+				},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_active_launches",
+			func(ctx context.Context, req *mcp.CallToolRequest, args GetActiveLaunchesArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				urlValues := url.Values{}
+				urlValues.Add("filter.in.status", utils.StatusInProgress)
+				ctxWithParams := utils.WithQueryParams(ctx, urlValues)
+
+				apiRequest, err := utils.ApplyPaginationOptions(
+					lr.client.LaunchAPI.GetProjectLaunches(ctxWithParams, project),
+					lr.settings.Pagination,
+					utils.FirstPage,
+					args.PageSize,
+					activeLaunchesSort,
+					activeLaunchesSort,
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+				launches, response, err := apiRequest.Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				result := activeLaunchesResult{
+					Count:    int64(len(launches.Content)),
+					Launches: make([]launchStatisticsRow, 0, len(launches.Content)),
+				}
+				if launches.Page != nil && launches.Page.TotalElements != nil {
+					result.Count = *launches.Page.TotalElements
+				}
+				for i := range launches.Content {
+					result.Launches = append(result.Launches, newLaunchStatisticsRow(&launches.Content[i]))
+				}
+
+				r, err := json.Marshal(result)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// projectMembersSort orders project members by login
+const projectMembersSort = "user,ASC"
+
+// GetProjectMembersArgs defines the input for get_project_members
+type GetProjectMembersArgs struct {
+	ProjectKey string `json:"projectKey"`
+	Page       uint   `json:"page"`
+	PageSize   uint   `json:"page-size"`
+	PageSort   string `json:"page-sort"`
+}
+
+// projectMember is a project user as returned by get_project_members
+type projectMember struct {
+	Username    string `json:"username"`
+	FullName    string `json:"full_name,omitempty"`
+	ProjectRole string `json:"project_role,omitempty"`
+	UserRole    string `json:"user_role,omitempty"`
+}
+
+// projectMembersResult is the get_project_members response page
+type projectMembersResult struct {
+	Members []projectMember                                       `json:"members"`
+	Page    *openapi.ComEpamReportportalBaseModelPagePageMetadata `json:"page,omitempty"`
+}
+
+// newProjectMember extracts the login, name and roles of a user; the project role is the one
+// the user has in the given project
+func newProjectMember(
+	user *openapi.ComEpamReportportalBaseModelUserUserResource,
+	project string,
+) projectMember {
+	member := projectMember{
+		Username: user.UserId,
+		FullName: user.GetFullName(),
+		UserRole: user.GetUserRole(),
+	}
+	if assigned, ok := user.GetAssignedProjects()[project]; ok {
+		member.ProjectRole = assigned.GetProjectRole()
+	}
+	return member
+}
+
+// toolGetProjectMembers creates a tool that lists the users of a project. Their usernames are the
+// owner names accepted by the filter-in-user filter of get_launches.
+func (lr *LaunchResources) toolGetProjectMembers() (*mcp.Tool, ToolHandler[GetProjectMembersArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	properties := utils.SetPaginationProperties(
+		lr.settings.Pagination,
+		projectMembersSort,
+	)
+	properties[utils.ProjectKeyField] = pkSchema
+
+	return &mcp.Tool{
+			Name: "get_project_members",
+			Description: "List the users of a project with their username, full name and roles. " +
+				"Usernames can be used as owner names in the filter-in-user filter of get_launches",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: properties,
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_project_members",
+			func(ctx context.Context, req *mcp.CallToolRequest, args GetProjectMembersArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				apiRequest, err := utils.ApplyPaginationOptions(
+					lr.client.ProjectAPI.GetProjectUsers(ctx, project),
+					lr.settings.Pagination,
+					args.Page,
+					args.PageSize,
+					args.PageSort,
+					projectMembersSort,
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+				users, response, err := apiRequest.Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				result := projectMembersResult{
+					Members: make([]projectMember, 0, len(users.Content)),
+					Page:    users.Page,
+				}
+				for i := range users.Content {
+					result.Members = append(result.Members, newProjectMember(&users.Content[i], project))
+				}
+
+				r, err := json.Marshal(result)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// GetLaunchByIdArgs holds params for get_launch_by_id.
+type GetLaunchByIdArgs struct {
+	ProjectKey   string `json:"projectKey"`
+	LaunchID     uint32 `json:"launch_id"`
+	IncludeLinks bool   `json:"include_links"`
+}
+
+// toolGetLaunchById creates a tool to retrieve a specific launch by its ID directly.
+func (lr *LaunchResources) toolGetLaunchById() (*mcp.Tool, ToolHandler[GetLaunchByIdArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name:        "get_launch_by_id",
+			Description: "Get a specific launch by its ID directly",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+					},
+					utils.IncludeLinksField: utils.IncludeLinksSchema(),
+				},
+				Required: []string{"launch_id"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_launch_by_id",
+			func(ctx context.Context, req *mcp.CallToolRequest, args GetLaunchByIdArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.LaunchID == 0 {
+					return nil, nil, fmt.Errorf("launch_id is required")
+				}
+
+				launch, err := lr.getLaunch(ctx, project, args.LaunchID)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				r, err := json.Marshal(launch)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				r, err = lr.withLaunchLinks(r, project, args.IncludeLinks)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// getLaunch fetches a single launch by its ID
+func (lr *LaunchResources) getLaunch(
+	ctx context.Context,
+	project string,
+	launchID uint32,
+) (*openapi.ComEpamReportportalBaseReportingLaunchResource, error) {
+	launch, response, err := lr.client.LaunchAPI.GetLaunch(
+		ctx,
+		strconv.FormatUint(uint64(launchID), 10),
+		project,
+	).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+	}
+	return launch, nil
+}
+
+// withLaunchLinks adds a webUrl field to the launch, or every launch of the page, in rawBody
+// when includeLinks is set
+func (lr *LaunchResources) withLaunchLinks(
+	rawBody []byte,
+	project string,
+	includeLinks bool,
+) ([]byte, error) {
+	if !includeLinks {
+		return rawBody, nil
+	}
+	links := utils.NewWebLinks(lr.client.APIClient.GetConfig(), project, lr.settings.UIPaths)
+	return links.AddLaunchURLs(rawBody)
+}
+
+// launchMeta is the minimal launch projection returned by get_launch_meta
+type launchMeta struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Number    int64      `json:"number"`
+	Owner     string     `json:"owner,omitempty"`
+	StartTime time.Time  `json:"startTime"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+	Status    string     `json:"status"`
+	Mode      string     `json:"mode,omitempty"`
+}
+
+// newLaunchMeta projects a launch onto its identity, owner, timing, status and mode
+func newLaunchMeta(launch *openapi.ComEpamReportportalBaseReportingLaunchResource) launchMeta {
+	return launchMeta{
+		ID:        launch.Id,
+		Name:      launch.Name,
+		Number:    launch.Number,
+		Owner:     launch.GetOwner(),
+		StartTime: launch.StartTime,
+		EndTime:   launch.EndTime,
+		Status:    launch.Status,
+		Mode:      launch.GetMode(),
+	}
+}
+
+// toolGetLaunchMeta creates a tool that returns only the owner, timing and status metadata of a
+// launch. It is a token-cheap alternative to get_launch_by_id when statistics and attributes
+// are not needed.
+func (lr *LaunchResources) toolGetLaunchMeta() (*mcp.Tool, ToolHandler[LaunchIDArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name: "get_launch_meta",
+			Description: "Get only the id, name, number, owner, startTime, endTime, status and mode " +
+				"of a launch. Use get_launch_by_id for statistics, attributes and description",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_launch_meta",
+			func(ctx context.Context, req *mcp.CallToolRequest, args LaunchIDArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.LaunchID == 0 {
+					return nil, nil, fmt.Errorf("launch_id is required")
+				}
+
+				launch, err := lr.getLaunch(ctx, project, args.LaunchID)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				r, err := json.Marshal(newLaunchMeta(launch))
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// launchAttributesMap turns launch attributes into a {key: value} object. The values of a key
+// that occurs more than once are merged into an array in attribute order; attributes without a
+// key are listed under the empty key.
+func launchAttributesMap(
+	attributes []openapi.ComEpamReportportalBaseReportingItemAttributeResource,
+) map[string]any {
+	result := make(map[string]any, len(attributes))
+	for _, attribute := range attributes {
+		key := attribute.GetKey()
+		switch existing := result[key].(type) {
+		case nil:
+			result[key] = attribute.Value
+		case string:
+			result[key] = []string{existing, attribute.Value}
+		case []string:
+			result[key] = append(existing, attribute.Value)
+		}
+	}
+	return result
+}
+
+// toolGetLaunchAttributesMap creates a tool that returns the attributes of a launch as a
+// {key: value} object, for quick metadata questions such as the branch or commit of a launch.
+func (lr *LaunchResources) toolGetLaunchAttributesMap() (*mcp.Tool, ToolHandler[LaunchIDArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name: "get_launch_attributes_map",
+			Description: "Get the attributes of a launch as a {key: value} JSON object, " +
+				"e.g. to find the branch or commit a launch was run for. Values of repeated " +
+				"keys are merged into an array; attributes without a key are listed under \"\"",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_launch_attributes_map",
+			func(ctx context.Context, req *mcp.CallToolRequest, args LaunchIDArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.LaunchID == 0 {
+					return nil, nil, fmt.Errorf("launch_id is required")
+				}
+
+				launch, err := lr.getLaunch(ctx, project, args.LaunchID)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				r, err := json.Marshal(launchAttributesMap(launch.Attributes))
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// GetLaunchAttributeValuesArgs holds params for get_launch_attribute_values.
+type GetLaunchAttributeValuesArgs struct {
+	ProjectKey string `json:"projectKey"`
+	Key        string `json:"key"`
+	Contains   string `json:"contains"`
+	Limit      uint   `json:"limit"`
+	Offset     uint   `json:"offset"`
+}
+
+// toolGetLaunchAttributeValues creates a tool that lists the distinct values of an attribute key
+// across the launches of a project, using the ReportPortal attribute suggestion endpoint.
+func (lr *LaunchResources) toolGetLaunchAttributeValues() (*mcp.Tool, ToolHandler[GetLaunchAttributeValuesArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name: "get_launch_attribute_values",
+			Description: "List the distinct values of a launch attribute key across the launches " +
+				"of the project, sorted alphabetically, e.g. every branch launches were run for. " +
+				"Use the values to build precise attribute filters for other tools",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"key": {
+						Type:        "string",
+						Description: "Attribute key, e.g. branch",
+					},
+					"contains": {
+						Type:        "string",
+						Description: "Only list values containing this substring",
+					},
+					"limit":  utils.LimitSchema(utils.DefaultLimitOffset),
+					"offset": utils.OffsetSchema(),
+				},
+				Required: []string{"key"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_launch_attribute_values",
+			func(ctx context.Context, req *mcp.CallToolRequest, args GetLaunchAttributeValuesArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				key := strings.TrimSpace(args.Key)
+				if key == "" {
+					return nil, nil, fmt.Errorf("key is required")
+				}
+
+				values, response, err := lr.client.LaunchAPI.GetAttributeValues(ctx, project).
+					FilterEqAttributeKey(key).
+					FilterCntAttributeValue(args.Contains).
+					Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+				if values == nil {
+					values = []string{}
+				}
+				slices.Sort(values)
+				values = slices.Compact(values)
+
+				limit := args.Limit
+				if limit == 0 {
+					limit = utils.DefaultLimitOffset
+				}
+				start := min(int(args.Offset), len(values)) //nolint:gosec
+				end := min(start+int(limit), len(values))   //nolint:gosec
+				result := map[string]any{
+					"key":    key,
+					"values": values[start:end],
+					"total":  len(values),
+				}
+				if end < len(values) {
+					result["next_offset"] = end
+				}
+
+				r, err := json.Marshal(result)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// GetLaunchByNumberArgs holds params for get_launch_by_number.
+type GetLaunchByNumberArgs struct {
+	ProjectKey   string `json:"projectKey"`
+	LaunchName   string `json:"launch_name"`
+	Number       uint32 `json:"number"`
+	IncludeLinks bool   `json:"include_links"`
+}
+
+// toolGetLaunchByNumber creates a tool to retrieve a launch by its name and sequential number.
+func (lr *LaunchResources) toolGetLaunchByNumber() (*mcp.Tool, ToolHandler[GetLaunchByNumberArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name: "get_launch_by_number",
+			Description: "Get a specific launch by its exact name and sequential number " +
+				"(e.g. run #2840 of the 'iOS e2e' launch)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_name": {
+						Type:        "string",
+						Description: "Exact launch name",
+					},
+					"number": {
+						Type:        "integer",
+						Description: "Launch number within the launch name",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+					utils.IncludeLinksField: utils.IncludeLinksSchema(),
+				},
+				Required: []string{"launch_name", "number"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_launch_by_number",
+			func(ctx context.Context, req *mcp.CallToolRequest, args GetLaunchByNumberArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				launchName := strings.TrimSpace(args.LaunchName)
+				if launchName == "" {
+					return nil, nil, fmt.Errorf("launch_name is required")
+				}
+				if args.Number == 0 {
+					return nil, nil, fmt.Errorf("number is required")
+				}
+				if args.Number > math.MaxInt32 {
+					return nil, nil, fmt.Errorf("number %d is out of range", args.Number)
+				}
+
+				apiRequest := lr.client.LaunchAPI.GetProjectLaunches(ctx, project).
+					FilterEqName(launchName).
+					FilterEqNumber(int32(args.Number)) //nolint:gosec // bounded by the check above
+				apiRequest, err = utils.ApplyPaginationOptions(
+					apiRequest,
+					lr.settings.Pagination,
+					utils.FirstPage,
+					1,
+					"",
+					utils.DefaultSortingForLaunches,
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				launches, response, err := apiRequest.Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				if len(launches.Content) < 1 {
+					return nil, nil, fmt.Errorf(
+						"launch %q #%d not found",
+						launchName,
+						args.Number,
+					)
+				}
+
+				r, err := json.Marshal(launches.Content[0])
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				r, err = lr.withLaunchLinks(r, project, args.IncludeLinks)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// GetLaunchByUUIDArgs holds params for get_launch_by_uuid.
+type GetLaunchByUUIDArgs struct {
+	ProjectKey   string `json:"projectKey"`
+	LaunchUUID   string `json:"launch_uuid"`
+	IncludeLinks bool   `json:"include_links"`
+}
+
+// toolGetLaunchByUUID creates a tool to retrieve a launch by its UUID, as returned to the
+// reporting client that started it.
+func (lr *LaunchResources) toolGetLaunchByUUID() (*mcp.Tool, ToolHandler[GetLaunchByUUIDArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name: "get_launch_by_uuid",
+			Description: "Get a specific launch by its UUID, e.g. the one a CI agent received " +
+				"when it started the launch",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_uuid": {
+						Type:        "string",
+						Description: "Launch UUID (e.g. 2c8a3b0e-9a4f-4c1e-8f6d-0b2e5d7a1c3f)",
+					},
+					utils.IncludeLinksField: utils.IncludeLinksSchema(),
+				},
+				Required: []string{"launch_uuid"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_launch_by_uuid",
+			func(ctx context.Context, req *mcp.CallToolRequest, args GetLaunchByUUIDArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				launchUUID := strings.TrimSpace(args.LaunchUUID)
+				if launchUUID == "" {
+					return nil, nil, fmt.Errorf("launch_uuid is required")
+				}
+				if _, err := uuid.Parse(launchUUID); err != nil {
+					return nil, nil, fmt.Errorf("invalid launch_uuid %q: %w", launchUUID, err)
+				}
+
+				apiRequest := lr.client.LaunchAPI.GetProjectLaunches(ctx, project).
+					FilterEqUuid(launchUUID)
+				apiRequest, err = utils.ApplyPaginationOptions(
+					apiRequest,
+					lr.settings.Pagination,
+					utils.FirstPage,
+					1,
+					"",
+					utils.DefaultSortingForLaunches,
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				launches, response, err := apiRequest.Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				if len(launches.Content) < 1 {
+					return nil, nil, fmt.Errorf("launch with UUID %q not found", launchUUID)
+				}
+
+				r, err := json.Marshal(launches.Content[0])
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				r, err = lr.withLaunchLinks(r, project, args.IncludeLinks)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// DeleteLaunchArgs holds params for launch_delete.
+type DeleteLaunchArgs struct {
+	ProjectKey string `json:"projectKey"`
+	LaunchID   uint32 `json:"launch_id"`
+	Confirm    bool   `json:"confirm"`
+	DryRun     bool   `json:"dry_run"`
+}
+
+func (lr *LaunchResources) toolDeleteLaunch() (*mcp.Tool, ToolHandler[DeleteLaunchArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name: "launch_delete",
+			Description: "Delete ReportPortal launch. This is irreversible. " +
+				"When the server runs with RP_REQUIRE_CONFIRM enabled, the call must include confirm: true. " +
+				"Use dry_run: true to preview the launch that would be deleted",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+					},
+					"confirm": {
+						Type:        "boolean",
+						Description: "Set to true to confirm the permanent deletion of the launch",
+						Default:     mustMarshalJSON(false),
+					},
+					"dry_run": utils.DryRunSchema(),
+				},
+				Required: []string{"launch_id"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"launch_delete",
+			func(ctx context.Context, req *mcp.CallToolRequest, args DeleteLaunchArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.LaunchID == 0 {
+					return nil, nil, fmt.Errorf("launch_id is required")
+				}
+
+				if args.DryRun {
+					launch, response, err := lr.client.LaunchAPI.GetLaunch(ctx, strconv.FormatUint(uint64(args.LaunchID), 10), project).
+						Execute()
+					if err != nil {
+						return nil, nil, fmt.Errorf(
+							"%s: %w",
+							utils.ExtractResponseError(err, response),
+							err,
+						)
+					}
+					return utils.DryRunResult("launch_delete", map[string]any{
+						"operation":     "delete",
+						"project":       project,
+						"launch_id":     launch.Id,
+						"launch_name":   launch.Name,
+						"launch_number": launch.Number,
+						"launch_status": launch.Status,
+					})
+				}
+
+				if lr.requireConfirm && !args.Confirm {
+					return nil, nil, fmt.Errorf(
+						"deleting launch %d is irreversible and requires confirmation: "+
+							"call launch_delete again with confirm set to true",
+						args.LaunchID,
+					)
+				}
+
+				_, _, err = lr.client.LaunchAPI.DeleteLaunch(ctx, int64(args.LaunchID), project).
+					Execute()
+				if err != nil {
+					return nil, nil, err
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						&mcp.TextContent{
+							Text: fmt.Sprintf("Launch '%d' has been deleted", args.LaunchID),
+						},
+					},
+				}, nil, nil
+			},
+		)
+}
+
+// analyzerAttributePrefix is the prefix of the analyzer settings among the project attributes
+const analyzerAttributePrefix = "analyzer."
+
+// analyzerConfig is the analyzer section of a project's configuration, as returned by
+// get_analyzer_config. Typed fields are parsed from the well-known analyzer attributes and are
+// omitted when the project does not report them; Settings holds all analyzer attributes as-is.
+type analyzerConfig struct {
+	Project                  string            `json:"project"`
+	AutoAnalyzerEnabled      *bool             `json:"auto_analyzer_enabled,omitempty"`
+	AutoAnalyzerMode         string            `json:"auto_analyzer_mode,omitempty"`
+	MinShouldMatch           *int              `json:"min_should_match,omitempty"`
+	SearchLogsMinShouldMatch *int              `json:"search_logs_min_should_match,omitempty"`
+	NumberOfLogLines         *int              `json:"number_of_log_lines,omitempty"`
+	AllMessagesShouldMatch   *bool             `json:"all_messages_should_match,omitempty"`
+	IndexingRunning          *bool             `json:"indexing_running,omitempty"`
+	Settings                 map[string]string `json:"settings"`
+}
+
+// newAnalyzerConfig extracts the analyzer settings from the project attributes
+func newAnalyzerConfig(project string, attributes map[string]string) analyzerConfig {
+	config := analyzerConfig{Project: project, Settings: map[string]string{}}
+	for key, value := range attributes {
+		if strings.HasPrefix(key, analyzerAttributePrefix) {
+			config.Settings[key] = value
+		}
+	}
+
+	parseBool := func(key string) *bool {
+		if v, err := strconv.ParseBool(attributes[key]); err == nil {
+			return &v
+		}
+		return nil
+	}
+	parseInt := func(key string) *int {
+		if v, err := strconv.Atoi(attributes[key]); err == nil {
+			return &v
+		}
+		return nil
+	}
+	config.AutoAnalyzerEnabled = parseBool("analyzer.isAutoAnalyzerEnabled")
+	config.AutoAnalyzerMode = attributes["analyzer.autoAnalyzerMode"]
+	config.MinShouldMatch = parseInt("analyzer.minShouldMatch")
+	config.SearchLogsMinShouldMatch = parseInt("analyzer.searchLogsMinShouldMatch")
+	config.NumberOfLogLines = parseInt("analyzer.numberOfLogLines")
+	config.AllMessagesShouldMatch = parseBool("analyzer.allMessagesShouldMatch")
+	config.IndexingRunning = parseBool("analyzer.indexingRunning")
+	return config
+}
+
+// toolGetAnalyzerConfig creates a tool that returns the analyzer settings of a project, so that
+// agents can check them before running auto analysis
+func (lr *LaunchResources) toolGetAnalyzerConfig() (*mcp.Tool, ToolHandler[ProjectKeyArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_analyzer_config",
+			Description: "Get the auto analyzer configuration of a project: whether auto analysis is " +
+				"enabled, its mode (e.g. LAUNCH_NAME, CURRENT_LAUNCH), minimum should match, number of " +
+				"log lines and whether indexing is running, plus all raw analyzer settings. Check it " +
+				"before run_auto_analysis to avoid triggering a misconfigured analysis",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+				},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_analyzer_config",
+			func(ctx context.Context, req *mcp.CallToolRequest, args ProjectKeyArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				config, err := lr.getAnalyzerConfig(ctx, project)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				r, err := json.Marshal(config)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// getAnalyzerConfig reads the analyzer settings from the project configuration
+func (lr *LaunchResources) getAnalyzerConfig(
+	ctx context.Context,
+	project string,
+) (analyzerConfig, error) {
+	attributes, err := lr.getProjectAttributes(ctx, project)
+	if err != nil {
+		return analyzerConfig{}, err
+	}
+	return newAnalyzerConfig(project, attributes), nil
+}
+
+// getProjectAttributes reads the attributes (settings) of the project configuration
+func (lr *LaunchResources) getProjectAttributes(
+	ctx context.Context,
+	project string,
+) (map[string]string, error) {
+	projectResource, response, err := lr.client.ProjectAPI.GetProject(ctx, project).Execute()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%s: %w",
+			utils.ExtractResponseError(err, response),
+			err,
+		)
+	}
+	return projectResource.Configuration.Attributes, nil
+}
+
+// Project attributes holding the data retention periods, in seconds (0 = kept forever)
+const (
+	retentionKeepLaunchesAttribute    = "job.keepLaunches"
+	retentionKeepLogsAttribute        = "job.keepLogs"
+	retentionKeepScreenshotsAttribute = "job.keepScreenshots"
+)
+
+// retentionPeriod is how long one kind of data is kept, as returned by get_retention_settings.
+// Value is the raw project setting; Days and Forever are derived from it when it is a number of
+// seconds.
+type retentionPeriod struct {
+	Value   string `json:"value"`
+	Days    *int64 `json:"days,omitempty"`
+	Forever bool   `json:"forever,omitempty"`
+}
+
+// retentionSettings is the data retention policy of a project, as returned by
+// get_retention_settings. Periods the project does not report are omitted.
+type retentionSettings struct {
+	Project         string           `json:"project"`
+	KeepLaunches    *retentionPeriod `json:"keep_launches,omitempty"`
+	KeepLogs        *retentionPeriod `json:"keep_logs,omitempty"`
+	KeepScreenshots *retentionPeriod `json:"keep_screenshots,omitempty"`
+}
+
+// newRetentionSettings extracts the retention periods from the project attributes
+func newRetentionSettings(project string, attributes map[string]string) retentionSettings {
+	period := func(key string) *retentionPeriod {
+		value, ok := attributes[key]
+		if !ok {
+			return nil
+		}
+		p := &retentionPeriod{Value: value}
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+			if seconds == 0 {
+				p.Forever = true
+			} else {
+				days := seconds / int64((24 * time.Hour).Seconds())
+				p.Days = &days
+			}
+		}
+		return p
+	}
+	return retentionSettings{
+		Project:         project,
+		KeepLaunches:    period(retentionKeepLaunchesAttribute),
+		KeepLogs:        period(retentionKeepLogsAttribute),
+		KeepScreenshots: period(retentionKeepScreenshotsAttribute),
+	}
+}
+
+// toolGetRetentionSettings creates a tool that returns the data retention settings of a project
+func (lr *LaunchResources) toolGetRetentionSettings() (*mcp.Tool, ToolHandler[ProjectKeyArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_retention_settings",
+			Description: "Get the data retention policy of a project: how long launches, logs and " +
+				"screenshots (attachments) are kept before cleanup. Each period has the raw setting " +
+				"value in seconds, the number of days, or forever: true when the data is never deleted",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+				},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_retention_settings",
+			func(ctx context.Context, req *mcp.CallToolRequest, args ProjectKeyArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				attributes, err := lr.getProjectAttributes(ctx, project)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				r, err := json.Marshal(newRetentionSettings(project, attributes))
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+const (
+	// analyzerMinShouldMatchMax is the upper bound of the analyzer minimum should match percentage
+	analyzerMinShouldMatchMax = 100
+	// analyzerAllLogLines is the number_of_log_lines value that makes the analyzer use all log lines
+	analyzerAllLogLines = -1
+)
+
+// UpdateAnalyzerConfigArgs holds params for update_analyzer_config. Unset fields are left unchanged.
+type UpdateAnalyzerConfigArgs struct {
+	ProjectKey          string `json:"projectKey"`
+	MinShouldMatch      *int   `json:"min_should_match"`
+	NumberOfLogLines    *int   `json:"number_of_log_lines"`
+	AutoAnalyzerEnabled *bool  `json:"auto_analyzer_enabled"`
+	IndexingRunning     *bool  `json:"indexing_running"`
+}
+
+// analyzerAttributes validates the requested analyzer settings and converts them to project
+// attributes, the format of the project update endpoint
+func (args UpdateAnalyzerConfigArgs) analyzerAttributes() (map[string]string, error) {
+	attributes := make(map[string]string)
+	if args.MinShouldMatch != nil {
+		if *args.MinShouldMatch < 0 || *args.MinShouldMatch > analyzerMinShouldMatchMax {
+			return nil, fmt.Errorf(
+				"min_should_match must be between 0 and %d, got %d",
+				analyzerMinShouldMatchMax,
+				*args.MinShouldMatch,
+			)
+		}
+		attributes["analyzer.minShouldMatch"] = strconv.Itoa(*args.MinShouldMatch)
+	}
+	if args.NumberOfLogLines != nil {
+		if *args.NumberOfLogLines < 1 && *args.NumberOfLogLines != analyzerAllLogLines {
+			return nil, fmt.Errorf(
+				"number_of_log_lines must be %d (all lines) or a positive number, got %d",
+				analyzerAllLogLines,
+				*args.NumberOfLogLines,
+			)
+		}
+		attributes["analyzer.numberOfLogLines"] = strconv.Itoa(*args.NumberOfLogLines)
+	}
+	if args.AutoAnalyzerEnabled != nil {
+		attributes["analyzer.isAutoAnalyzerEnabled"] = strconv.FormatBool(*args.AutoAnalyzerEnabled)
+	}
+	if args.IndexingRunning != nil {
+		attributes["analyzer.indexingRunning"] = strconv.FormatBool(*args.IndexingRunning)
+	}
+	if len(attributes) == 0 {
+		return nil, fmt.Errorf(
+			"at least one of min_should_match, number_of_log_lines, auto_analyzer_enabled " +
+				"or indexing_running is required",
+		)
+	}
+	return attributes, nil
+}
+
+// toolUpdateAnalyzerConfig creates a tool that changes the analyzer settings of a project and
+// returns the resulting configuration
+func (lr *LaunchResources) toolUpdateAnalyzerConfig() (*mcp.Tool, ToolHandler[UpdateAnalyzerConfigArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "update_analyzer_config",
+			Description: "Update the auto analyzer settings of a project. Only the given settings are " +
+				"changed; returns the updated analyzer configuration (same format as get_analyzer_config)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"min_should_match": {
+						Type:        "integer",
+						Description: "Minimum percentage of matching log words for the analyzer to consider logs similar",
+						Minimum:     openapi.PtrFloat64(0),
+						Maximum:     openapi.PtrFloat64(analyzerMinShouldMatchMax),
+					},
+					"number_of_log_lines": {
+						Type:        "integer",
+						Description: "Number of first lines of each log message used by the analyzer, -1 for all lines",
+						Minimum:     openapi.PtrFloat64(analyzerAllLogLines),
+					},
+					"auto_analyzer_enabled": {
+						Type:        "boolean",
+						Description: "Whether launches are analyzed automatically when they finish",
+					},
+					"indexing_running": {
+						Type:        "boolean",
+						Description: "Whether the analyzer index of the project is being generated",
+					},
+				},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"update_analyzer_config",
+			func(ctx context.Context, req *mcp.CallToolRequest, args UpdateAnalyzerConfigArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				attributes, err := args.analyzerAttributes()
+				if err != nil {
+					return nil, nil, err
+				}
+
+				// The project update merges the given attributes into the existing ones
+				updateRQ := openapi.NewComEpamReportportalBaseModelProjectUpdateProjectRQ()
+				updateRQ.SetConfiguration(
+					*openapi.NewComEpamReportportalBaseModelProjectConfigProjectConfigurationUpdate(attributes),
+				)
+				_, response, err := lr.client.ProjectAPI.UpdateProject(ctx, project).
+					ComEpamReportportalBaseModelProjectUpdateProjectRQ(*updateRQ).
+					Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				config, err := lr.getAnalyzerConfig(ctx, project)
+				if err != nil {
+					return nil, nil, fmt.Errorf("analyzer config updated, but reading it back failed: %w", err)
+				}
+
+				r, err := json.Marshal(config)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// RunAutoAnalysisArgs holds params for run_auto_analysis.
+type RunAutoAnalysisArgs struct {
+	ProjectKey        string   `json:"projectKey"`
+	LaunchID          uint32   `json:"launch_id"`
+	AnalyzerMode      string   `json:"analyzer_mode"`
+	AnalyzerType      string   `json:"analyzer_type"`
+	AnalyzerItemModes []string `json:"analyzer_item_modes"`
+	Wait              bool     `json:"wait"`
+}
+
+// autoAnalysisWaitTimeout bounds how long run_auto_analysis with wait polls for completion
+const autoAnalysisWaitTimeout = 10 * time.Minute
+
+// autoAnalysisPollInterval is how often run_auto_analysis with wait checks the launch
+var autoAnalysisPollInterval = 5 * time.Second
+
+// requestDeadlineMargin is kept free before the request deadline so that a tool that stops
+// waiting can still return its partial result before the request is cut off
+const requestDeadlineMargin = 2 * time.Second
+
+// waitDeadline returns when a tool should stop waiting: after limit, or earlier when the request
+// context expires first (in HTTP mode it is bounded by --connection-timeout)
+func waitDeadline(ctx context.Context, limit time.Duration) time.Time {
+	deadline := time.Now().Add(limit)
+	if ctxDeadline, ok := ctx.Deadline(); ok {
+		if ctxDeadline = ctxDeadline.Add(-requestDeadlineMargin); ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+	}
+	return deadline
+}
+
+// isTimeoutError reports whether err is a request timeout: an expired context or an HTTP client
+// timeout
+func isTimeoutError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// waitForLaunchAnalysis polls the launch until no analyzer is running on it, reporting progress
+// on every poll. It stops after autoAnalysisWaitTimeout or shortly before the request deadline,
+// whichever comes first, and then reports false together with the time it waited.
+func (lr *LaunchResources) waitForLaunchAnalysis(
+	ctx context.Context,
+	project string,
+	launchID uint32,
+	progress *progressReporter,
+) (bool, time.Duration, error) {
+	start := time.Now()
+	deadline := waitDeadline(ctx, autoAnalysisWaitTimeout)
+	for {
+		wait := min(autoAnalysisPollInterval, time.Until(deadline))
+		if wait <= 0 {
+			return false, time.Since(start), nil
+		}
+		// Wait before the first check too, so that the just started analysis is registered
+		select {
+		case <-ctx.Done():
+			return false, time.Since(start), ctx.Err()
+		case <-time.After(wait):
+		}
+
+		launch, err := lr.getLaunch(ctx, project, launchID)
+		if err != nil {
+			return false, time.Since(start), err
+		}
+		running := launch.GetAnalysing()
+		if len(running) == 0 {
+			return true, time.Since(start), nil
+		}
+		progress.report(ctx, fmt.Sprintf(
+			"Analysis of launch %d is still running (%s)",
+			launchID,
+			strings.Join(running, ", "),
+		))
+	}
+}
+
+// launchAnalysisStatus is the result of get_launch_analysis_status
+type launchAnalysisStatus struct {
+	LaunchID        int64    `json:"launch_id"`
+	Status          string   `json:"status"`
+	InProgress      bool     `json:"in_progress"`
+	AnalysisRunning bool     `json:"analysis_running"`
+	Analysing       []string `json:"analysing"`
+	HasRetries      bool     `json:"has_retries"`
+	Rerun           bool     `json:"rerun"`
+}
+
+// newLaunchAnalysisStatus projects a launch onto its execution and analyzer state
+func newLaunchAnalysisStatus(
+	launch *openapi.ComEpamReportportalBaseReportingLaunchResource,
+) launchAnalysisStatus {
+	analysing := launch.GetAnalysing()
+	if analysing == nil {
+		analysing = []string{}
+	}
+	return launchAnalysisStatus{
+		LaunchID:        launch.Id,
+		Status:          launch.Status,
+		InProgress:      launch.Status == utils.StatusInProgress,
+		AnalysisRunning: len(analysing) > 0,
+		Analysing:       analysing,
+		HasRetries:      launch.GetHasRetries(),
+		Rerun:           launch.GetRerun(),
+	}
+}
+
+// toolGetLaunchAnalysisStatus creates a tool that reports whether analyzers are running on a
+// launch, for polling after run_auto_analysis or run_unique_error_analysis
+func (lr *LaunchResources) toolGetLaunchAnalysisStatus() (*mcp.Tool, ToolHandler[LaunchIDArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name: "get_launch_analysis_status",
+			Description: "Get the analysis status of a launch: its status, whether it is still in progress, " +
+				"the analyzers currently running on it (analysing, e.g. AUTO_ANALYZER, PATTERN_ANALYZER) " +
+				"and its hasRetries/rerun flags. Poll it after run_auto_analysis without wait " +
+				"until analysis_running is false",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"get_launch_analysis_status",
+			func(ctx context.Context, req *mcp.CallToolRequest, args LaunchIDArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.LaunchID == 0 {
+					return nil, nil, fmt.Errorf("launch_id is required")
+				}
+
+				launch, err := lr.getLaunch(ctx, project, args.LaunchID)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				r, err := json.Marshal(newLaunchAnalysisStatus(launch))
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+func (lr *LaunchResources) toolRunAutoAnalysis() (*mcp.Tool, ToolHandler[RunAutoAnalysisArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name:        "run_auto_analysis",
+			Description: "Run auto analysis on ReportPortal launch. Use get_analyzer_config to check the project analyzer settings first",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+					},
+					"analyzer_mode": {
+						Type:        "string",
+						Description: "Analyzer mode, only one of the values is allowed",
+						Enum: []any{
+							"all",
+							"launch_name",
+							"current_launch",
+							"previous_launch",
+							"current_and_the_same_name",
+						},
+						Default: mustMarshalJSON("current_launch"),
+					},
+					"analyzer_type": {
+						Type:        "string",
+						Description: "Analyzer type, only one of the values is allowed",
+						Enum:        []any{"autoAnalyzer", "patternAnalyzer"},
+						Default:     mustMarshalJSON("autoAnalyzer"),
+					},
+					"analyzer_item_modes": {
+						Type:        "array",
+						Description: "Analyze items modes, one or more of the values are allowed",
+						Items: &jsonschema.Schema{
+							Type: "string",
+							Enum: []any{"to_investigate", "auto_analyzed", "manually_analyzed"},
+						},
+						Default: mustMarshalJSON([]string{"to_investigate"}),
+					},
+					"wait": {
+						Type: "boolean",
+						Description: "Wait until the analysis has finished (up to " +
+							autoAnalysisWaitTimeout.String() + ", but never longer than the request " +
+							"timeout, which is 30s by default in HTTP mode), sending progress notifications " +
+							"while it runs. If the analysis is still running when the wait ends, the call " +
+							"says so instead of failing. By default the call returns as soon as the analysis " +
+							"is started; poll get_launch_analysis_status to follow it",
+						Default: mustMarshalJSON(false),
+					},
+				},
+				Required: []string{
+					"launch_id",
+					"analyzer_mode",
+					"analyzer_type",
+					"analyzer_item_modes",
+				},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"run_auto_analysis",
+			func(ctx context.Context, req *mcp.CallToolRequest, args RunAutoAnalysisArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.LaunchID == 0 {
+					return nil, nil, fmt.Errorf("launch_id is required")
+				}
+
+				analyzerItemModes := args.AnalyzerItemModes
+				if len(analyzerItemModes) == 0 {
 					analyzerItemModes = []string{"to_investigate"}
 				}
 
-				rs, response, err := lr.client.LaunchAPI.
-					StartLaunchAnalyzer(ctx, project).
-					ComEpamReportportalBaseModelLaunchAnalyzeLaunchRQ(openapi.ComEpamReportportalBaseModelLaunchAnalyzeLaunchRQ{
-						LaunchId:         int64(args.LaunchID),
-						AnalyzerMode:     strings.ToUpper(args.AnalyzerMode),
-						AnalyzerTypeName: strings.ToUpper(args.AnalyzerType),
-						AnalyzeItemsMode: analyzerItemModes,
-					}).
-					Execute()
+				rs, response, err := lr.client.LaunchAPI.
+					StartLaunchAnalyzer(ctx, project).
+					ComEpamReportportalBaseModelLaunchAnalyzeLaunchRQ(openapi.ComEpamReportportalBaseModelLaunchAnalyzeLaunchRQ{
+						LaunchId:         int64(args.LaunchID),
+						AnalyzerMode:     strings.ToUpper(args.AnalyzerMode),
+						AnalyzerTypeName: strings.ToUpper(args.AnalyzerType),
+						AnalyzeItemsMode: analyzerItemModes,
+					}).
+					Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				message := rs.GetMessage()
+				if args.Wait {
+					finished, waited, err := lr.waitForLaunchAnalysis(
+						ctx,
+						project,
+						args.LaunchID,
+						newProgressReporter(req),
+					)
+					if err != nil {
+						return nil, nil, fmt.Errorf("failed to wait for the analysis: %w", err)
+					}
+					if finished {
+						message += "\nAnalysis finished"
+					} else {
+						message += fmt.Sprintf(
+							"\nAnalysis is still running after %s; poll get_launch_analysis_status "+
+								"to follow it",
+							waited.Round(time.Second),
+						)
+					}
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: message}},
+				}, nil, nil
+			},
+		)
+}
+
+// UniqueErrorAnalysisArgs holds params for run_unique_error_analysis.
+type UniqueErrorAnalysisArgs struct {
+	ProjectKey    string `json:"projectKey"`
+	LaunchID      uint32 `json:"launch_id"`
+	RemoveNumbers bool   `json:"remove_numbers"`
+}
+
+func (lr *LaunchResources) toolUniqueErrorAnalysis() (*mcp.Tool, ToolHandler[UniqueErrorAnalysisArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name:        "run_unique_error_analysis",
+			Description: "Run unique error analysis on ReportPortal launch",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+					},
+					"remove_numbers": {
+						Type:        "boolean",
+						Description: "Remove numbers from analyzed logs",
+						Default:     mustMarshalJSON(false),
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"run_unique_error_analysis",
+			func(ctx context.Context, req *mcp.CallToolRequest, args UniqueErrorAnalysisArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.LaunchID == 0 {
+					return nil, nil, fmt.Errorf("launch_id is required")
+				}
+
+				rs, response, err := lr.client.LaunchAPI.
+					CreateClusters(ctx, project).
+					ComEpamReportportalBaseModelLaunchClusterCreateClustersRQ(openapi.ComEpamReportportalBaseModelLaunchClusterCreateClustersRQ{
+						LaunchId:      int64(args.LaunchID),
+						RemoveNumbers: openapi.PtrBool(args.RemoveNumbers),
+					}).
+					Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: rs.GetMessage()}},
+				}, nil, nil
+			},
+		)
+}
+
+// UpdateLaunchAttribute represents a single key/value attribute for a launch.
+type UpdateLaunchAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// UpdateLaunchArgs holds params for update_launch.
+type UpdateLaunchArgs struct {
+	ProjectKey  string                  `json:"projectKey"`
+	LaunchID    uint32                  `json:"launch_id"`
+	Description *string                 `json:"description,omitempty"`
+	Attributes  []UpdateLaunchAttribute `json:"attributes,omitempty"`
+}
+
+func (lr *LaunchResources) toolUpdateLaunch() (*mcp.Tool, ToolHandler[UpdateLaunchArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name:        "update_launch",
+			Description: "Update launch attributes and description in ReportPortal",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+					},
+					"description": {
+						Type:        "string",
+						Description: "New description for the launch. Replaces the existing description.",
+					},
+					"attributes": {
+						Type:        "array",
+						Description: "List of attributes to set on the launch. Each attribute has a key (optional) and a value. Replaces all existing attributes.",
+						Items: &jsonschema.Schema{
+							Type: "object",
+							Properties: map[string]*jsonschema.Schema{
+								"key": {
+									Type:        "string",
+									Description: "Attribute key (may be empty for tag-style attributes)",
+								},
+								"value": {
+									Type:        "string",
+									Description: "Attribute value",
+								},
+							},
+							Required: []string{"value"},
+						},
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"update_launch",
+			func(ctx context.Context, req *mcp.CallToolRequest, args UpdateLaunchArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.LaunchID == 0 {
+					return nil, nil, fmt.Errorf("launch_id is required")
+				}
+
+				if args.Description == nil && args.Attributes == nil {
+					return nil, nil, fmt.Errorf(
+						"at least one of description or attributes must be provided",
+					)
+				}
+
+				updateRQ := openapi.ComEpamReportportalBaseModelLaunchUpdateLaunchRQ{}
+				if args.Description != nil {
+					updateRQ.SetDescription(*args.Description)
+				}
+				if args.Attributes != nil {
+					attrs := make(
+						[]openapi.ComEpamReportportalBaseReportingItemAttributeResource,
+						0,
+						len(args.Attributes),
+					)
+					for i, a := range args.Attributes {
+						if strings.TrimSpace(a.Value) == "" {
+							if trimmedKey := strings.TrimSpace(a.Key); trimmedKey != "" {
+								return nil, nil, fmt.Errorf(
+									"attribute[%d] key=%q has empty value",
+									i,
+									trimmedKey,
+								)
+							}
+							return nil, nil, fmt.Errorf("attribute[%d] has empty value", i)
+						}
+						attr := openapi.ComEpamReportportalBaseReportingItemAttributeResource{
+							Value: a.Value,
+						}
+						if trimmedKey := strings.TrimSpace(a.Key); trimmedKey != "" {
+							attr.SetKey(trimmedKey)
+						}
+						attrs = append(attrs, attr)
+					}
+					updateRQ.SetAttributes(attrs)
+				}
+
+				rs, response, err := lr.client.LaunchAPI.
+					UpdateLaunch(ctx, int64(args.LaunchID), project).
+					ComEpamReportportalBaseModelLaunchUpdateLaunchRQ(updateRQ).
+					Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: rs.GetMessage()}},
+				}, nil, nil
+			},
+		)
+}
+
+func (lr *LaunchResources) toolForceFinishLaunch() (*mcp.Tool, ToolHandler[LaunchIDArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name:        "launch_force_finish",
+			Description: "Force finish launch",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"launch_force_finish",
+			func(ctx context.Context, req *mcp.CallToolRequest, args LaunchIDArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.LaunchID == 0 {
+					return nil, nil, fmt.Errorf("launch_id is required")
+				}
+
+				_, response, err := lr.client.LaunchAPI.ForceFinishLaunch(ctx, int64(args.LaunchID), project).
+					Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						&mcp.TextContent{
+							Text: fmt.Sprintf(
+								"Launch '%d' has been forcefully finished",
+								args.LaunchID,
+							),
+						},
+					},
+				}, nil, nil
+			},
+		)
+}
+
+// toolStopLaunch creates a tool that stops a running launch, marking it STOPPED.
+func (lr *LaunchResources) toolStopLaunch() (*mcp.Tool, ToolHandler[LaunchIDArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	return &mcp.Tool{
+			Name: "stop_launch",
+			Description: "Stop a running launch: the launch and its in-progress items are finished with status STOPPED, " +
+				"recording that the run was deliberately halted. Unlike launch_force_finish, which just finishes " +
+				"the launch with the results reported so far, the launch is explicitly marked as stopped",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_id": {
+						Type:        "integer",
+						Description: "Launch ID",
+					},
+				},
+				Required: []string{"launch_id"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"stop_launch",
+			func(ctx context.Context, req *mcp.CallToolRequest, args LaunchIDArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.LaunchID == 0 {
+					return nil, nil, fmt.Errorf("launch_id is required")
+				}
+
+				err = lr.finishLaunchWithStatus(ctx, project, args.LaunchID, stopLaunchStatus)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{
+						&mcp.TextContent{
+							Text: fmt.Sprintf("Launch '%d' has been stopped", args.LaunchID),
+						},
+					},
+				}, nil, nil
+			},
+		)
+}
+
+// finishLaunchWithStatus stops a running launch, finishing it and its in-progress items with status
+func (lr *LaunchResources) finishLaunchWithStatus(
+	ctx context.Context,
+	project string,
+	launchID uint32,
+	status string,
+) error {
+	rq := openapi.NewComEpamReportportalBaseReportingFinishExecutionRQ(time.Now())
+	rq.SetStatus(status)
+	_, response, err := lr.client.LaunchAPI.ForceFinishLaunch(ctx, int64(launchID), project).
+		ComEpamReportportalBaseReportingFinishExecutionRQ(*rq).
+		Execute()
+	if err != nil {
+		return fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+	}
+	return nil
+}
+
+// finishLaunchStatuses are the statuses stop_launch and bulk_finish_launches can finish
+// launches with. STOPPED comes first as it is the default.
+var finishLaunchStatuses = []string{"STOPPED", "INTERRUPTED", "FAILED", "PASSED", "SKIPPED"}
+
+// stopLaunchStatus is the status stop_launch finishes launches with
+var stopLaunchStatus = finishLaunchStatuses[0]
+
+// Per-launch outcomes reported by bulk_finish_launches
+const (
+	bulkLaunchFinished = "finished"
+	bulkLaunchFailed   = "failed"
+)
+
+// BulkFinishLaunchesArgs holds params for bulk_finish_launches.
+type BulkFinishLaunchesArgs struct {
+	ProjectKey string   `json:"projectKey"`
+	LaunchIDs  []uint32 `json:"launch_ids"`
+	Status     string   `json:"status"`
+	DryRun     bool     `json:"dry_run"`
+}
+
+// bulkLaunchResult is the outcome of a bulk operation for a single launch
+type bulkLaunchResult struct {
+	LaunchID uint32 `json:"launch_id"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// toolBulkFinishLaunches creates a tool that finishes several running launches at once.
+// A failure to finish one launch does not stop the others; each launch gets its own outcome.
+func (lr *LaunchResources) toolBulkFinishLaunches() (*mcp.Tool, ToolHandler[BulkFinishLaunchesArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	statusEnum := make([]any, 0, len(finishLaunchStatuses))
+	for _, status := range finishLaunchStatuses {
+		statusEnum = append(statusEnum, status)
+	}
+
+	return &mcp.Tool{
+			Name: "bulk_finish_launches",
+			Description: "Operational cleanup: finish several stuck launches at once with the given status " +
+				"(their in-progress items are finished too). Returns the outcome for each launch",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"launch_ids": {
+						Type:        "array",
+						Description: "IDs of the launches to finish",
+						Items:       &jsonschema.Schema{Type: "integer"},
+						MinItems:    openapi.PtrInt(1),
+						MaxItems:    openapi.PtrInt(bulkFinishLaunchesMaxLaunches),
+					},
+					"status": {
+						Type:        "string",
+						Description: "Status to finish the launches with",
+						Enum:        statusEnum,
+						Default:     mustMarshalJSON(string(gorp.Statuses.Stopped)),
+					},
+					"dry_run": utils.DryRunSchema(),
+				},
+				Required: []string{"launch_ids"},
+			},
+		},
+		utils.WithAnalytics(
+			lr.analytics,
+			"bulk_finish_launches",
+			func(ctx context.Context, req *mcp.CallToolRequest, args BulkFinishLaunchesArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
 				if err != nil {
+					return nil, nil, err
+				}
+
+				status := strings.ToUpper(strings.TrimSpace(args.Status))
+				if status == "" {
+					status = string(gorp.Statuses.Stopped)
+				}
+				if !slices.Contains(finishLaunchStatuses, status) {
 					return nil, nil, fmt.Errorf(
-						"%s: %w",
-						utils.ExtractResponseError(err, response),
-						err,
+						"invalid status %q: must be one of %s",
+						args.Status,
+						strings.Join(finishLaunchStatuses, ", "),
+					)
+				}
+
+				// De-duplicate IDs while preserving their order
+				launchIDs := make([]uint32, 0, len(args.LaunchIDs))
+				for _, id := range args.LaunchIDs {
+					if id != 0 && !slices.Contains(launchIDs, id) {
+						launchIDs = append(launchIDs, id)
+					}
+				}
+				if len(launchIDs) == 0 {
+					return nil, nil, fmt.Errorf("launch_ids parameter is required")
+				}
+				if len(launchIDs) > bulkFinishLaunchesMaxLaunches {
+					return nil, nil, fmt.Errorf(
+						"too many launch IDs: %d (maximum is %d)",
+						len(launchIDs),
+						bulkFinishLaunchesMaxLaunches,
 					)
 				}
 
+				if args.DryRun {
+					return utils.DryRunResult("bulk_finish_launches", map[string]any{
+						"operation":  "finish_launches",
+						"project":    project,
+						"launch_ids": launchIDs,
+						"status":     status,
+					})
+				}
+
+				errs := forEachBounded(
+					ctx,
+					len(launchIDs),
+					bulkFinishLaunchesConcurrency,
+					func(i int) error {
+						return lr.finishLaunchWithStatus(ctx, project, launchIDs[i], status)
+					},
+				)
+				if ctx.Err() != nil {
+					return nil, nil, ctx.Err()
+				}
+
+				finished := 0
+				results := make([]bulkLaunchResult, len(launchIDs))
+				for i, id := range launchIDs {
+					if errs[i] != nil {
+						results[i] = bulkLaunchResult{
+							LaunchID: id,
+							Status:   bulkLaunchFailed,
+							Error:    errs[i].Error(),
+						}
+						continue
+					}
+					results[i] = bulkLaunchResult{LaunchID: id, Status: bulkLaunchFinished}
+					finished++
+				}
+
+				r, err := json.Marshal(map[string]any{
+					"status":         status,
+					"finished_count": finished,
+					"results":        results,
+				})
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
 				return &mcp.CallToolResult{
-					Content: []mcp.Content{&mcp.TextContent{Text: rs.GetMessage()}},
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
 				}, nil, nil
 			},
 		)
 }
 
-// UniqueErrorAnalysisArgs holds params for run_unique_error_analysis.
-type UniqueErrorAnalysisArgs struct {
-	ProjectKey    string `json:"projectKey"`
-	LaunchID      uint32 `json:"launch_id"`
-	RemoveNumbers bool   `json:"remove_numbers"`
+// launchExportContentTypes maps the report formats supported by the launch export endpoint
+// to the content type used when ReportPortal does not send one.
+var launchExportContentTypes = map[string]string{
+	"html": "text/html",
+	"pdf":  "application/pdf",
+	"xls":  "application/vnd.ms-excel",
 }
 
-func (lr *LaunchResources) toolUniqueErrorAnalysis() (*mcp.Tool, ToolHandler[UniqueErrorAnalysisArgs, any]) {
+// ExportLaunchArgs holds params for export_launch.
+type ExportLaunchArgs struct {
+	ProjectKey string `json:"projectKey"`
+	LaunchID   uint32 `json:"launch_id"`
+	Format     string `json:"format"`
+}
+
+// toolExportLaunch creates a tool to export a launch report (HTML, PDF or XLS) as resource contents.
+func (lr *LaunchResources) toolExportLaunch() (*mcp.Tool, ToolHandler[ExportLaunchArgs, any]) {
 	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
 	if err != nil {
 		slog.Error("failed to build project key schema", "error", err)
 	}
 	return &mcp.Tool{
-			Name:        "run_unique_error_analysis",
-			Description: "Run unique error analysis on ReportPortal launch",
+			Name:        "export_launch",
+			Description: "Export a ReportPortal launch report in HTML, PDF or XLS format. HTML is returned as text resource contents, PDF and XLS as base64 blob resource contents",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
@@ -805,10 +4002,11 @@ func (lr *LaunchResources) toolUniqueErrorAnalysis() (*mcp.Tool, ToolHandler[Uni
 						Type:        "integer",
 						Description: "Launch ID",
 					},
-					"remove_numbers": {
-						Type:        "boolean",
-						Description: "Remove numbers from analyzed logs",
-						Default:     mustMarshalJSON(false),
+					"format": {
+						Type:        "string",
+						Description: "Report format",
+						Enum:        []any{"html", "pdf", "xls"},
+						Default:     mustMarshalJSON("html"),
 					},
 				},
 				Required: []string{"launch_id"},
@@ -816,8 +4014,8 @@ func (lr *LaunchResources) toolUniqueErrorAnalysis() (*mcp.Tool, ToolHandler[Uni
 		},
 		utils.WithAnalytics(
 			lr.analytics,
-			"run_unique_error_analysis",
-			func(ctx context.Context, req *mcp.CallToolRequest, args UniqueErrorAnalysisArgs) (*mcp.CallToolResult, any, error) {
+			"export_launch",
+			func(ctx context.Context, req *mcp.CallToolRequest, args ExportLaunchArgs) (*mcp.CallToolResult, any, error) {
 				project, err := utils.ExtractProject(ctx, args.ProjectKey)
 				if err != nil {
 					return nil, nil, err
@@ -827,12 +4025,20 @@ func (lr *LaunchResources) toolUniqueErrorAnalysis() (*mcp.Tool, ToolHandler[Uni
 					return nil, nil, fmt.Errorf("launch_id is required")
 				}
 
-				rs, response, err := lr.client.LaunchAPI.
-					CreateClusters(ctx, project).
-					ComEpamReportportalBaseModelLaunchClusterCreateClustersRQ(openapi.ComEpamReportportalBaseModelLaunchClusterCreateClustersRQ{
-						LaunchId:      int64(args.LaunchID),
-						RemoveNumbers: openapi.PtrBool(args.RemoveNumbers),
-					}).
+				format := strings.ToLower(strings.TrimSpace(args.Format))
+				if format == "" {
+					format = "html"
+				}
+				defaultContentType, ok := launchExportContentTypes[format]
+				if !ok {
+					return nil, nil, fmt.Errorf(
+						"invalid format %q: expected one of html, pdf, xls",
+						args.Format,
+					)
+				}
+
+				response, err := lr.client.LaunchAPI.GetLaunchReport(ctx, int64(args.LaunchID), project).
+					View(format).
 					Execute()
 				if err != nil {
 					return nil, nil, fmt.Errorf(
@@ -842,35 +4048,54 @@ func (lr *LaunchResources) toolUniqueErrorAnalysis() (*mcp.Tool, ToolHandler[Uni
 					)
 				}
 
-				return &mcp.CallToolResult{
-					Content: []mcp.Content{&mcp.TextContent{Text: rs.GetMessage()}},
-				}, nil, nil
+				rawBody, err := utils.ReadResponseBodyRaw(response)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to read launch report: %w", err)
+				}
+
+				contentType := response.Header.Get("Content-Type")
+				if contentType == "" || strings.HasPrefix(contentType, "application/json") {
+					contentType = defaultContentType
+				}
+
+				result, err := utils.BlobResourceResult(
+					fmt.Sprintf(
+						"reportportal://%s/launch/%d/report?view=%s",
+						project,
+						args.LaunchID,
+						format,
+					),
+					contentType,
+					rawBody,
+				)
+				if err != nil {
+					return nil, nil, fmt.Errorf("launch report is too large: %w", err)
+				}
+				return result, nil, nil
 			},
 		)
 }
 
-// UpdateLaunchAttribute represents a single key/value attribute for a launch.
-type UpdateLaunchAttribute struct {
-	Key   string `json:"key"`
-	Value string `json:"value"`
-}
-
-// UpdateLaunchArgs holds params for update_launch.
-type UpdateLaunchArgs struct {
-	ProjectKey  string                  `json:"projectKey"`
-	LaunchID    uint32                  `json:"launch_id"`
-	Description *string                 `json:"description,omitempty"`
-	Attributes  []UpdateLaunchAttribute `json:"attributes,omitempty"`
-}
+// launchLogArchivePageSize is the number of log entries requested per page while
+// assembling the launch log archive.
+const launchLogArchivePageSize = 500
 
-func (lr *LaunchResources) toolUpdateLaunch() (*mcp.Tool, ToolHandler[UpdateLaunchArgs, any]) {
+// toolGetLaunchLogArchive creates a tool that bundles all logs of a launch into a ZIP archive.
+// ReportPortal has no server-side log export endpoint, so the archive is assembled from the
+// paginated log search API: one JSON Lines file with every log entry of the launch.
+func (lr *LaunchResources) toolGetLaunchLogArchive() (*mcp.Tool, ToolHandler[LaunchIDArgs, any]) {
 	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
 	if err != nil {
 		slog.Error("failed to build project key schema", "error", err)
 	}
 	return &mcp.Tool{
-			Name:        "update_launch",
-			Description: "Update launch attributes and description in ReportPortal",
+			Name: "get_launch_log_archive",
+			Description: fmt.Sprintf(
+				"Download all logs of a launch as a ZIP archive (base64 blob resource contents) containing one JSON Lines file with every log entry. "+
+					"Attachment binaries are not included; use get_test_item_attachment_by_id for them. "+
+					"The archive can be large for big launches and is rejected above %d MiB",
+				utils.MaxBinaryContentSizeBytes/(1024*1024),
+			),
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
@@ -879,36 +4104,14 @@ func (lr *LaunchResources) toolUpdateLaunch() (*mcp.Tool, ToolHandler[UpdateLaun
 						Type:        "integer",
 						Description: "Launch ID",
 					},
-					"description": {
-						Type:        "string",
-						Description: "New description for the launch. Replaces the existing description.",
-					},
-					"attributes": {
-						Type:        "array",
-						Description: "List of attributes to set on the launch. Each attribute has a key (optional) and a value. Replaces all existing attributes.",
-						Items: &jsonschema.Schema{
-							Type: "object",
-							Properties: map[string]*jsonschema.Schema{
-								"key": {
-									Type:        "string",
-									Description: "Attribute key (may be empty for tag-style attributes)",
-								},
-								"value": {
-									Type:        "string",
-									Description: "Attribute value",
-								},
-							},
-							Required: []string{"value"},
-						},
-					},
 				},
 				Required: []string{"launch_id"},
 			},
 		},
 		utils.WithAnalytics(
 			lr.analytics,
-			"update_launch",
-			func(ctx context.Context, req *mcp.CallToolRequest, args UpdateLaunchArgs) (*mcp.CallToolResult, any, error) {
+			"get_launch_log_archive",
+			func(ctx context.Context, req *mcp.CallToolRequest, args LaunchIDArgs) (*mcp.CallToolResult, any, error) {
 				project, err := utils.ExtractProject(ctx, args.ProjectKey)
 				if err != nil {
 					return nil, nil, err
@@ -918,71 +4121,114 @@ func (lr *LaunchResources) toolUpdateLaunch() (*mcp.Tool, ToolHandler[UpdateLaun
 					return nil, nil, fmt.Errorf("launch_id is required")
 				}
 
-				if args.Description == nil && args.Attributes == nil {
-					return nil, nil, fmt.Errorf(
-						"at least one of description or attributes must be provided",
-					)
+				var archive bytes.Buffer
+				zw := zip.NewWriter(&archive)
+				fileName := fmt.Sprintf("launch-%d-logs.jsonl", args.LaunchID)
+				entry, err := zw.Create(fileName)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to create archive entry: %w", err)
 				}
+				encoder := json.NewEncoder(entry)
 
-				updateRQ := openapi.ComEpamReportportalBaseModelLaunchUpdateLaunchRQ{}
-				if args.Description != nil {
-					updateRQ.SetDescription(*args.Description)
-				}
-				if args.Attributes != nil {
-					attrs := make(
-						[]openapi.ComEpamReportportalBaseReportingItemAttributeResource,
-						0,
-						len(args.Attributes),
+				total := 0
+				for page := uint(utils.FirstPage); ; page++ {
+					apiRequest := lr.client.LogAPI.GetLogs(ctx, project).
+						FilterEqLaunchId(int32(args.LaunchID)) //nolint:gosec // launch IDs fit into int32 on the RP side
+					apiRequest, err = utils.ApplyPaginationOptions(
+						apiRequest,
+						lr.settings.Pagination,
+						page,
+						launchLogArchivePageSize,
+						utils.DefaultSortingForLogs,
+						utils.DefaultSortingForLogs,
 					)
-					for i, a := range args.Attributes {
-						if strings.TrimSpace(a.Value) == "" {
-							if trimmedKey := strings.TrimSpace(a.Key); trimmedKey != "" {
-								return nil, nil, fmt.Errorf(
-									"attribute[%d] key=%q has empty value",
-									i,
-									trimmedKey,
-								)
-							}
-							return nil, nil, fmt.Errorf("attribute[%d] has empty value", i)
-						}
-						attr := openapi.ComEpamReportportalBaseReportingItemAttributeResource{
-							Value: a.Value,
-						}
-						if trimmedKey := strings.TrimSpace(a.Key); trimmedKey != "" {
-							attr.SetKey(trimmedKey)
+					if err != nil {
+						return nil, nil, err
+					}
+					logs, response, err := apiRequest.Execute()
+					if err != nil {
+						return nil, nil, fmt.Errorf(
+							"%s: %w",
+							utils.ExtractResponseError(err, response),
+							err,
+						)
+					}
+
+					for _, logEntry := range logs.Content {
+						if err := encoder.Encode(logEntry); err != nil {
+							return nil, nil, fmt.Errorf("failed to write log entry: %w", err)
 						}
-						attrs = append(attrs, attr)
 					}
-					updateRQ.SetAttributes(attrs)
+					total += len(logs.Content)
+
+					if archive.Len() > utils.MaxBinaryContentSizeBytes {
+						return nil, nil, fmt.Errorf(
+							"launch log archive exceeds the maximum of %d bytes after %d log entries",
+							utils.MaxBinaryContentSizeBytes,
+							total,
+						)
+					}
+					if len(logs.Content) == 0 || logs.Page == nil || !logs.Page.GetHasNext() {
+						break
+					}
 				}
 
-				rs, response, err := lr.client.LaunchAPI.
-					UpdateLaunch(ctx, int64(args.LaunchID), project).
-					ComEpamReportportalBaseModelLaunchUpdateLaunchRQ(updateRQ).
-					Execute()
-				if err != nil {
-					return nil, nil, fmt.Errorf(
-						"%s: %w",
-						utils.ExtractResponseError(err, response),
-						err,
-					)
+				if err := zw.Close(); err != nil {
+					return nil, nil, fmt.Errorf("failed to finalize archive: %w", err)
 				}
 
-				return &mcp.CallToolResult{
-					Content: []mcp.Content{&mcp.TextContent{Text: rs.GetMessage()}},
-				}, nil, nil
+				slog.DebugContext(ctx, "launch log archive assembled",
+					"launch_id", args.LaunchID,
+					"log_entries", total,
+					"archive_bytes", archive.Len())
+
+				result, err := utils.BlobResourceResult(
+					fmt.Sprintf("reportportal://%s/launch/%d/logs.zip", project, args.LaunchID),
+					"application/zip",
+					archive.Bytes(),
+				)
+				if err != nil {
+					return nil, nil, fmt.Errorf("launch log archive is too large: %w", err)
+				}
+				return result, nil, nil
 			},
 		)
 }
 
-func (lr *LaunchResources) toolForceFinishLaunch() (*mcp.Tool, ToolHandler[LaunchIDArgs, any]) {
+const (
+	// launchAttachmentsPageSize is the number of log entries requested per page while collecting
+	// the attachments of a launch.
+	launchAttachmentsPageSize = 300
+	// launchAttachmentsMaxPages caps the log pages scanned by get_launch_attachments
+	launchAttachmentsMaxPages = 20
+)
+
+// launchAttachment is one attachment of a launch as listed by get_launch_attachments
+type launchAttachment struct {
+	AttachmentID string     `json:"attachment_id"`
+	ContentType  string     `json:"content_type"`
+	FileName     string     `json:"file_name,omitempty"`
+	ItemID       *int64     `json:"item_id,omitempty"` // unset for launch-level logs
+	LogID        int64      `json:"log_id"`
+	LogTime      *time.Time `json:"log_time,omitempty"`
+	LogLevel     string     `json:"log_level,omitempty"`
+	LogMessage   string     `json:"log_message,omitempty"`
+}
+
+// toolGetLaunchAttachments creates a tool that lists every attachment of a launch together with
+// the test item that owns it. Up to launchAttachmentsMaxPages pages of the launch logs with binary
+// content are read; the result is flagged as truncated beyond them.
+func (lr *LaunchResources) toolGetLaunchAttachments() (*mcp.Tool, ToolHandler[LaunchIDArgs, any]) {
 	pkSchema, err := utils.ProjectKeySchema(lr.defaultProjectKey)
 	if err != nil {
 		slog.Error("failed to build project key schema", "error", err)
 	}
 	return &mcp.Tool{
-			Name:        "launch_force_finish",
-			Description: "Force finish launch",
+			Name: "get_launch_attachments",
+			Description: "List all attachments (screenshots, files) of a launch in one call: scans every log of the launch " +
+				"with binary content and returns the attachment ID, content type and file name with the owning test item ID. " +
+				"At most " + strconv.Itoa(launchAttachmentsMaxPages*launchAttachmentsPageSize) + " logs are scanned; " +
+				"the result is flagged as truncated beyond them. Use get_test_item_attachment_by_id with an attachment_id to fetch the attachment itself",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
@@ -997,7 +4243,7 @@ func (lr *LaunchResources) toolForceFinishLaunch() (*mcp.Tool, ToolHandler[Launc
 		},
 		utils.WithAnalytics(
 			lr.analytics,
-			"launch_force_finish",
+			"get_launch_attachments",
 			func(ctx context.Context, req *mcp.CallToolRequest, args LaunchIDArgs) (*mcp.CallToolResult, any, error) {
 				project, err := utils.ExtractProject(ctx, args.ProjectKey)
 				if err != nil {
@@ -1008,25 +4254,79 @@ func (lr *LaunchResources) toolForceFinishLaunch() (*mcp.Tool, ToolHandler[Launc
 					return nil, nil, fmt.Errorf("launch_id is required")
 				}
 
-				_, response, err := lr.client.LaunchAPI.ForceFinishLaunch(ctx, int64(args.LaunchID), project).
-					Execute()
-				if err != nil {
-					return nil, nil, fmt.Errorf(
-						"%s: %w",
-						utils.ExtractResponseError(err, response),
-						err,
+				ctxWithParams := utils.WithQueryParams(ctx, url.Values{
+					"filter.ex.binaryContent": {"true"},
+				})
+				attachments := make([]launchAttachment, 0)
+				truncated := false
+				for page := uint(utils.FirstPage); ; page++ {
+					if page > launchAttachmentsMaxPages {
+						truncated = true
+						break
+					}
+					apiRequest := lr.client.LogAPI.GetLogs(ctxWithParams, project).
+						FilterEqLaunchId(int32(args.LaunchID)) //nolint:gosec // launch IDs fit into int32 on the RP side
+					apiRequest, err = utils.ApplyPaginationOptions(
+						apiRequest,
+						lr.settings.Pagination,
+						page,
+						launchAttachmentsPageSize,
+						utils.DefaultSortingForLogs,
+						utils.DefaultSortingForLogs,
+					)
+					if err != nil {
+						return nil, nil, err
+					}
+					logs, response, err := apiRequest.Execute()
+					if err != nil {
+						return nil, nil, fmt.Errorf(
+							"%s: %w",
+							utils.ExtractResponseError(err, response),
+							err,
+						)
+					}
+
+					for _, logEntry := range logs.Content {
+						// The binaryContent filter is applied server side; logs without it are skipped anyway
+						if logEntry.BinaryContent == nil || logEntry.BinaryContent.Id == "" {
+							continue
+						}
+						attachments = append(attachments, launchAttachment{
+							AttachmentID: logEntry.BinaryContent.Id,
+							ContentType:  logEntry.BinaryContent.ContentType,
+							FileName:     logEntry.BinaryContent.GetFileName(),
+							ItemID:       logEntry.ItemId,
+							LogID:        logEntry.Id,
+							LogTime:      logEntry.Time,
+							LogLevel:     logEntry.GetLevel(),
+							LogMessage:   logEntry.GetMessage(),
+						})
+					}
+					if len(logs.Content) == 0 || logs.Page == nil || !logs.Page.GetHasNext() {
+						break
+					}
+				}
+
+				result := map[string]any{
+					"launch_id":   args.LaunchID,
+					"attachments": attachments,
+					"total":       len(attachments),
+				}
+				if truncated {
+					result["truncated"] = true
+					result["message"] = fmt.Sprintf(
+						"only the first %d logs of the launch were scanned for attachments",
+						launchAttachmentsMaxPages*launchAttachmentsPageSize,
 					)
 				}
 
+				r, err := json.Marshal(result)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
 				return &mcp.CallToolResult{
-					Content: []mcp.Content{
-						&mcp.TextContent{
-							Text: fmt.Sprintf(
-								"Launch '%d' has been forcefully finished",
-								args.LaunchID,
-							),
-						},
-					},
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
 				}, nil, nil
 			},
 		)
@@ -1123,7 +4423,7 @@ func (lr *LaunchResources) toolImportLaunchFromFile() (*mcp.Tool, ToolHandler[Im
 					}
 					pluginInfo = lr.importPlugins.lookup(args.PluginName)
 					if pluginInfo == nil {
-						slog.Warn("plugin_name not found in available import plugins",
+						slog.WarnContext(ctx, "plugin_name not found in available import plugins",
 							"plugin_name", args.PluginName,
 							"available_plugins", strings.Join(lr.importPlugins.list(), ", "))
 						return nil, nil, fmt.Errorf(
@@ -1260,7 +4560,7 @@ func (lr *LaunchResources) toolImportLaunchFromFile() (*mcp.Tool, ToolHandler[Im
 				}
 				defer resp.Body.Close() //nolint:errcheck
 
-				respBody, err := io.ReadAll(resp.Body)
+				respBody, err := utils.ReadAllContext(ctx, resp.Body)
 				if err != nil {
 					return nil, nil, fmt.Errorf("failed to read import response: %w", err)
 				}
@@ -1,12 +1,21 @@
 package mcphandlers
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -17,6 +26,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yosida95/uritemplate/v3"
+
+	app_middleware "github.com/reportportal/reportportal-mcp-server/internal/reportportal/middleware"
 )
 
 func TestLaunchByIdTemplate(t *testing.T) {
@@ -70,6 +81,186 @@ func TestListLaunchesTool(t *testing.T) {
 	assert.Equal(t, string(launchesJSON), textContent.Text)
 }
 
+// TestListLaunchesTool_FinishedOnly tests that filter-finished-only excludes in-progress launches
+func TestListLaunchesTool_FinishedOnly(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	launchesJSON, _ := json.Marshal(testLaunches())
+
+	tests := []struct {
+		name           string
+		finishedOnly   bool
+		expectedFilter string
+	}{
+		{name: "default keeps in-progress launches", finishedOnly: false, expectedFilter: ""},
+		{name: "finished only", finishedOnly: true, expectedFilter: "IN_PROGRESS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedFilter string
+			mockServer := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					capturedFilter = r.URL.Query().Get("filter.ne.status")
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write(launchesJSON)
+				}),
+			)
+			defer mockServer.Close()
+
+			serverURL, _ := url.Parse(mockServer.URL)
+			launchTools := NewLaunchResources(
+				newQueryParamsClient(ctx, serverURL),
+				nil,
+				"",
+				nil,
+			)
+			_, handler := launchTools.toolGetLaunches()
+
+			_, _, err := handler(ctx, &mcp.CallToolRequest{}, GetLaunchesArgs{
+				ProjectKey:         testProject,
+				FilterFinishedOnly: tt.finishedOnly,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedFilter, capturedFilter)
+		})
+	}
+}
+
+// TestListLaunchesTool_KeysetPagination tests the before_id/after_id cursor mode of get_launches
+func TestListLaunchesTool_KeysetPagination(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	launchesJSON, _ := json.Marshal(testLaunches())
+
+	tests := []struct {
+		name           string
+		args           GetLaunchesArgs
+		expectedFilter string
+		expectedSort   string
+		expectedCursor string
+	}{
+		{
+			name:           "before_id walks back and returns the next cursor on a full page",
+			args:           GetLaunchesArgs{BeforeID: 100, PageSize: 2},
+			expectedFilter: "filter.lt.id=100",
+			expectedSort:   keysetSortBefore,
+			expectedCursor: `{"before_id":2}`,
+		},
+		{
+			name:           "after_id on a partial page has no next cursor",
+			args:           GetLaunchesArgs{AfterID: 7, PageSize: 10},
+			expectedFilter: "filter.gt.id=7",
+			expectedSort:   keysetSortAfter,
+			expectedCursor: `null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var captured url.Values
+			mockServer := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					captured = r.URL.Query()
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write(launchesJSON)
+				}),
+			)
+			defer mockServer.Close()
+
+			serverURL, _ := url.Parse(mockServer.URL)
+			launchTools := NewLaunchResources(
+				newQueryParamsClient(ctx, serverURL),
+				nil,
+				"",
+				nil,
+			)
+			_, handler := launchTools.toolGetLaunches()
+
+			tt.args.ProjectKey = testProject
+			tt.args.PageSort = "name,ASC" // ignored in keyset mode
+			result, _, err := handler(ctx, &mcp.CallToolRequest{}, tt.args)
+			require.NoError(t, err)
+
+			filter := strings.SplitN(tt.expectedFilter, "=", 2)
+			assert.Equal(t, filter[1], captured.Get(filter[0]))
+			assert.Equal(t, tt.expectedSort, captured.Get("page.sort"))
+			assert.Equal(t, "1", captured.Get("page.page"))
+
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			require.True(t, ok, "expected TextContent")
+			var page map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &page))
+			assert.Contains(t, page, "content")
+			assert.JSONEq(t, tt.expectedCursor, string(page["next_cursor"]))
+		})
+	}
+
+	t.Run("invalid combinations are rejected", func(t *testing.T) {
+		// Validation fails before any request is sent
+		serverURL, _ := url.Parse("http://127.0.0.1:0")
+		launchTools := NewLaunchResources(
+			gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+			nil,
+			"",
+			nil,
+		)
+		_, handler := launchTools.toolGetLaunches()
+
+		_, _, err := handler(ctx, &mcp.CallToolRequest{}, GetLaunchesArgs{
+			ProjectKey: testProject, BeforeID: 10, AfterID: 5,
+		})
+		require.ErrorContains(t, err, "cannot be combined")
+
+		_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetLaunchesArgs{
+			ProjectKey: testProject, BeforeID: 10, Page: 3,
+		})
+		require.ErrorContains(t, err, "next_cursor")
+	})
+}
+
+// TestListLaunchesTool_CountOnly tests that count_only queries a single-element page and returns
+// only the total number of launches
+func TestListLaunchesTool_CountOnly(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	launches := testLaunches()
+	launches.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+		TotalElements: openapi.PtrInt64(1234),
+	})
+	launchesJSON, _ := json.Marshal(launches)
+
+	var captured url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(launchesJSON)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(newQueryParamsClient(ctx, serverURL), nil, "", nil)
+	_, handler := launchTools.toolGetLaunches()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetLaunchesArgs{
+		ProjectKey:    testProject,
+		Page:          5,
+		PageSize:      100,
+		FilterCntName: "nightly",
+		IncludeLinks:  true,
+		CountOnly:     true,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+	assert.Equal(t, "1234", textContent.Text)
+
+	assert.Equal(t, "1", captured.Get("page.page"))
+	assert.Equal(t, "1", captured.Get("page.size"))
+	assert.Equal(t, "nightly", captured.Get("filter.cnt.name"))
+}
+
 // TestGetLaunchByIdTool tests the get_launch_by_id tool handler directly
 func TestGetLaunchByIdTool(t *testing.T) {
 	ctx := context.Background()
@@ -115,7 +306,7 @@ func TestGetLaunchByIdTool(t *testing.T) {
 	result, _, err := handler(
 		ctx,
 		&mcp.CallToolRequest{},
-		LaunchIDArgs{ProjectKey: testProject, LaunchID: launchID},
+		GetLaunchByIdArgs{ProjectKey: testProject, LaunchID: launchID},
 	)
 	require.NoError(t, err)
 	require.NotNil(t, result)
@@ -174,7 +365,7 @@ func TestGetLaunchByIdTool_NotFound(t *testing.T) {
 	_, _, err := handler(
 		ctx,
 		&mcp.CallToolRequest{},
-		LaunchIDArgs{ProjectKey: testProject, LaunchID: launchID},
+		GetLaunchByIdArgs{ProjectKey: testProject, LaunchID: launchID},
 	)
 
 	// Verify that an error is returned
@@ -182,6 +373,311 @@ func TestGetLaunchByIdTool_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "not found")
 }
 
+// TestGetLaunchByIdTool_IncludeLinks checks that include_links adds the launch UI URL
+func TestGetLaunchByIdTool_IncludeLinks(t *testing.T) {
+	ctx := context.Background()
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(openapi.ComEpamReportportalBaseReportingLaunchResource{
+			Id:        123,
+			Uuid:      "014b329b-a882-4c2d-9988-c2f6179a421b",
+			Name:      "Nightly",
+			Number:    7,
+			StartTime: time.Now(),
+			Status:    string(gorp.Statuses.Passed),
+		})
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	)
+	_, handler := launchTools.toolGetLaunchById()
+
+	result, _, err := handler(
+		ctx,
+		&mcp.CallToolRequest{},
+		GetLaunchByIdArgs{ProjectKey: "test-project", LaunchID: 123, IncludeLinks: true},
+	)
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var launch map[string]any
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &launch))
+	assert.Equal(t, mockServer.URL+"/ui/#test-project/launches/all/123", launch["webUrl"])
+	assert.Equal(t, "Nightly", launch["name"])
+}
+
+// TestGetLaunchMetaTool checks that get_launch_meta returns only the fixed metadata projection
+func TestGetLaunchMetaTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	launchID := uint32(123)
+	startTime := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(15 * time.Minute)
+
+	launch := openapi.ComEpamReportportalBaseReportingLaunchResource{
+		Id:          int64(launchID),
+		Uuid:        "014b329b-a882-4c2d-9988-c2f6179a421b",
+		Name:        "Nightly",
+		Number:      42,
+		Owner:       openapi.PtrString("jane"),
+		Description: openapi.PtrString("a long description"),
+		StartTime:   startTime,
+		EndTime:     &endTime,
+		Status:      string(gorp.Statuses.Failed),
+		Mode:        openapi.PtrString("DEFAULT"),
+		Attributes: []openapi.ComEpamReportportalBaseReportingItemAttributeResource{
+			{Key: openapi.PtrString("env"), Value: "staging"},
+		},
+	}
+	launchJSON, _ := json.Marshal(launch)
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, fmt.Sprintf("/api/v1/%s/launch/%d", testProject, launchID), r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(launchJSON)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	)
+	_, handler := launchTools.toolGetLaunchMeta()
+
+	result, _, err := handler(
+		ctx,
+		&mcp.CallToolRequest{},
+		LaunchIDArgs{ProjectKey: testProject, LaunchID: launchID},
+	)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	assert.JSONEq(t, `{
+		"id": 123,
+		"name": "Nightly",
+		"number": 42,
+		"owner": "jane",
+		"startTime": "2024-05-01T10:00:00Z",
+		"endTime": "2024-05-01T10:15:00Z",
+		"status": "FAILED",
+		"mode": "DEFAULT"
+	}`, textContent.Text)
+}
+
+func TestGetLaunchAttributesMapTool(t *testing.T) {
+	ctx := context.Background()
+	launch := openapi.ComEpamReportportalBaseReportingLaunchResource{
+		Id:   7,
+		Name: "Nightly",
+		Attributes: []openapi.ComEpamReportportalBaseReportingItemAttributeResource{
+			{Key: openapi.PtrString("branch"), Value: "main"},
+			{Key: openapi.PtrString("commit"), Value: "3f2a9c1"},
+			{Key: openapi.PtrString("os"), Value: "linux"},
+			{Value: "smoke"},
+			{Key: openapi.PtrString("os"), Value: "macos"},
+			{Key: openapi.PtrString("os"), Value: "windows"},
+		},
+	}
+	launchJSON, _ := json.Marshal(launch)
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/test-project/launch/7", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(launchJSON)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	).toolGetLaunchAttributesMap()
+
+	result, _, err := handler(
+		ctx,
+		&mcp.CallToolRequest{},
+		LaunchIDArgs{ProjectKey: "test-project", LaunchID: 7},
+	)
+	require.NoError(t, err)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+	assert.JSONEq(t, `{
+		"branch": "main",
+		"commit": "3f2a9c1",
+		"os": ["linux", "macos", "windows"],
+		"": "smoke"
+	}`, textContent.Text)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, LaunchIDArgs{ProjectKey: "test-project"})
+	assert.ErrorContains(t, err, "launch_id is required")
+}
+
+func TestGetLaunchAttributeValuesTool(t *testing.T) {
+	ctx := context.Background()
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/test-project/launch/attribute/values", r.URL.Path)
+		assert.Equal(t, "branch", r.URL.Query().Get("filter.eq.attributeKey"))
+		assert.True(t, r.URL.Query().Has("filter.cnt.attributeValue"))
+		values := []string{"release/2.0", "main", "feature/login", "main", "develop"}
+		if contains := r.URL.Query().Get("filter.cnt.attributeValue"); contains != "" {
+			values = slices.DeleteFunc(values, func(v string) bool {
+				return !strings.Contains(v, contains)
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(values)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	).toolGetLaunchAttributeValues()
+
+	tests := []struct {
+		name     string
+		args     GetLaunchAttributeValuesArgs
+		expected string
+	}{
+		{
+			name: "all values",
+			args: GetLaunchAttributeValuesArgs{Key: "branch"},
+			expected: `{"key":"branch","total":4,` +
+				`"values":["develop","feature/login","main","release/2.0"]}`,
+		},
+		{
+			name:     "paginated",
+			args:     GetLaunchAttributeValuesArgs{Key: " branch ", Limit: 2, Offset: 1},
+			expected: `{"key":"branch","total":4,"values":["feature/login","main"],"next_offset":3}`,
+		},
+		{
+			name:     "offset past the end",
+			args:     GetLaunchAttributeValuesArgs{Key: "branch", Offset: 10},
+			expected: `{"key":"branch","total":4,"values":[]}`,
+		},
+		{
+			name:     "contains",
+			args:     GetLaunchAttributeValuesArgs{Key: "branch", Contains: "ea"},
+			expected: `{"key":"branch","total":2,"values":["feature/login","release/2.0"]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.ProjectKey = "test-project"
+			result, _, err := handler(ctx, &mcp.CallToolRequest{}, tt.args)
+			require.NoError(t, err)
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			require.True(t, ok, "expected TextContent")
+			assert.JSONEq(t, tt.expected, textContent.Text)
+		})
+	}
+
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, GetLaunchAttributeValuesArgs{
+		ProjectKey: "test-project",
+		Key:        " ",
+	})
+	assert.ErrorContains(t, err, "key is required")
+}
+
+func TestGetLaunchAnalysisStatusTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+
+	tests := []struct {
+		name   string
+		launch openapi.ComEpamReportportalBaseReportingLaunchResource
+		want   string
+	}{
+		{
+			name: "analysis running",
+			launch: openapi.ComEpamReportportalBaseReportingLaunchResource{
+				Id:          123,
+				Name:        "Nightly",
+				Status:      string(gorp.Statuses.Failed),
+				Description: openapi.PtrString("a long description"),
+				Analysing:   []string{"AUTO_ANALYZER"},
+				HasRetries:  openapi.PtrBool(true),
+			},
+			want: `{
+				"launch_id": 123,
+				"status": "FAILED",
+				"in_progress": false,
+				"analysis_running": true,
+				"analysing": ["AUTO_ANALYZER"],
+				"has_retries": true,
+				"rerun": false
+			}`,
+		},
+		{
+			name: "launch in progress, no analysis",
+			launch: openapi.ComEpamReportportalBaseReportingLaunchResource{
+				Id:     123,
+				Name:   "Nightly",
+				Status: "IN_PROGRESS",
+				Rerun:  openapi.PtrBool(true),
+			},
+			want: `{
+				"launch_id": 123,
+				"status": "IN_PROGRESS",
+				"in_progress": true,
+				"analysis_running": false,
+				"analysing": [],
+				"has_retries": false,
+				"rerun": true
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, fmt.Sprintf("/api/v1/%s/launch/123", testProject), r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(tt.launch)
+			}))
+			defer mockServer.Close()
+
+			serverURL, _ := url.Parse(mockServer.URL)
+			_, handler := NewLaunchResources(
+				gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+				nil,
+				"",
+				nil,
+			).toolGetLaunchAnalysisStatus()
+
+			result, _, err := handler(
+				ctx,
+				&mcp.CallToolRequest{},
+				LaunchIDArgs{ProjectKey: testProject, LaunchID: 123},
+			)
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			require.True(t, ok, "expected TextContent")
+			assert.JSONEq(t, tt.want, textContent.Text)
+		})
+	}
+}
+
 // TestRunAutoAnalysisTool tests the run_auto_analysis tool to ensure:
 //  1. The tool schema correctly includes the "items" property for array parameters
 //     (critical for GitHub Copilot compatibility - fixes "array type must have items" error)
@@ -286,33 +782,1642 @@ func TestRunAutoAnalysisTool(t *testing.T) {
 	assert.Equal(t, []string{"to_investigate", "auto_analyzed"}, capturedRequest.AnalyzeItemsMode)
 }
 
-func testLaunches() *openapi.ComEpamReportportalBaseModelPageComEpamReportportalBaseReportingLaunchResource {
-	launches := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseReportingLaunchResource()
-	launches.SetContent([]openapi.ComEpamReportportalBaseReportingLaunchResource{
-		{
-			Id:        1,
-			Name:      "Test Launch 1",
-			Uuid:      "014b329b-a882-4c2d-9988-c2f6179a421b",
-			Number:    1,
-			StartTime: time.Now(),
-			Status:    string(gorp.Statuses.Passed),
-		},
-		{
-			Id:        2,
-			Name:      "Test Launch 2",
-			Uuid:      "014b329b-a882-4c2d-9988-c2f6179a421c",
-			Number:    2,
-			StartTime: time.Now(),
-			Status:    string(gorp.Statuses.Passed),
-		},
+// TestGetLastLaunchesByNamesTool tests that each name is resolved independently and
+// names without launches map to null
+func TestGetLastLaunchesByNamesTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	launches := testLaunches()
+	emptyPage := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseReportingLaunchResource()
+	emptyPage.SetContent([]openapi.ComEpamReportportalBaseReportingLaunchResource{})
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, fmt.Sprintf("/api/v1/%s/launch", testProject), r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("page.size"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("filter.cnt.name") == "Test Launch 1" {
+			page := *launches
+			page.SetContent(launches.Content[:1])
+			_ = json.NewEncoder(w).Encode(page)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(emptyPage)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		newQueryParamsClient(ctx, serverURL),
+		nil,
+		"",
+		nil,
+	)
+
+	_, handler := launchTools.toolGetLastLaunchesByNames()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetLastLaunchesByNamesArgs{
+		ProjectKey:  testProject,
+		LaunchNames: []string{"Test Launch 1", "Missing Launch", "Test Launch 1"},
 	})
-	launches.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
-		TotalPages:    openapi.PtrInt64(1),
-		HasNext:       openapi.PtrBool(false),
-		Number:        openapi.PtrInt64(1),
-		Size:          openapi.PtrInt64(int64(len(launches.Content))),
-		TotalElements: openapi.PtrInt64(int64(len(launches.Content))),
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Len(t, result.Content, 1)
+
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var byName map[string]*openapi.ComEpamReportportalBaseReportingLaunchResource
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &byName))
+	require.Len(t, byName, 2)
+	require.NotNil(t, byName["Test Launch 1"])
+	assert.Equal(t, int64(1), byName["Test Launch 1"].Id)
+	val, exists := byName["Missing Launch"]
+	assert.True(t, exists)
+	assert.Nil(t, val)
+
+	// Empty input is rejected
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetLastLaunchesByNamesArgs{
+		ProjectKey:  testProject,
+		LaunchNames: []string{" "},
 	})
+	require.Error(t, err)
+}
 
-	return launches
+// TestCompareLaunchesTableTool tests statistics extraction and ordering of compare_launches_table
+func TestCompareLaunchesTableTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	launchesByID := map[string]string{
+		"10": `{"id":10,"uuid":"a","name":"nightly","number":7,"status":"FAILED",` +
+			`"startTime":"2024-01-01T00:00:00Z","statistics":{` +
+			`"executions":{"total":10,"passed":7,"failed":2,"skipped":1},` +
+			`"defects":{"product_bug":{"total":2,"pb001":2},"to_investigate":{"total":1,"ti001":1}}}}`,
+		"11": `{"id":11,"uuid":"b","name":"nightly","number":8,"status":"PASSED",` +
+			`"startTime":"2024-01-02T00:00:00Z","statistics":{"executions":{"total":3,"passed":3}}}`,
+		"12": `{"id":12,"uuid":"c","name":"nightly","number":9,"status":"IN_PROGRESS",` +
+			`"startTime":"2024-01-03T00:00:00Z"}`,
+	}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		id := strings.TrimPrefix(r.URL.Path, fmt.Sprintf("/api/v1/%s/launch/", testProject))
+		launchJSON, ok := launchesByID[id]
+		w.Header().Set("Content-Type", "application/json")
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":4041,"message":"Launch not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(launchJSON))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	)
+	_, handler := launchTools.toolCompareLaunchesTable()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, CompareLaunchesTableArgs{
+		ProjectKey: testProject,
+		LaunchIDs:  []uint32{10, 12, 11, 10},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var rows []launchStatisticsRow
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &rows))
+	require.Len(t, rows, 3)
+
+	// Sorted by launch number descending
+	assert.Equal(t, []int64{9, 8, 7}, []int64{rows[0].Number, rows[1].Number, rows[2].Number})
+
+	assert.Equal(t, int32(0), rows[0].Total)
+	assert.Zero(t, rows[0].PassRate)
+
+	assert.Equal(t, int32(3), rows[1].Total)
+	assert.Equal(t, float64(100), rows[1].PassRate)
+
+	assert.Equal(t, int32(10), rows[2].Total)
+	assert.Equal(t, int32(7), rows[2].Passed)
+	assert.Equal(t, int32(2), rows[2].Failed)
+	assert.Equal(t, int32(1), rows[2].Skipped)
+	assert.Equal(t, map[string]int32{"product_bug": 2, "to_investigate": 1}, rows[2].Defects)
+	assert.Equal(t, 70.0, rows[2].PassRate)
+
+	// A missing launch fails the whole comparison
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, CompareLaunchesTableArgs{
+		ProjectKey: testProject,
+		LaunchIDs:  []uint32{10, 99},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get launch 99")
+
+	// Empty input is rejected
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, CompareLaunchesTableArgs{
+		ProjectKey: testProject,
+	})
+	require.Error(t, err)
+}
+
+func TestGetLaunchTrendTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	var requestedPages []string
+	var mu sync.Mutex
+
+	// 120 "nightly" launches; launch N has N*10 executions of which N*5+(N%2)*10 passed
+	launchJSON := func(number int) string {
+		total, passed := number*10, number*5+(number%2)*10
+		return fmt.Sprintf(
+			`{"id":%d,"uuid":"u%d","name":"nightly","number":%d,"status":"FAILED",`+
+				`"startTime":"2024-01-01T00:00:00Z","statistics":{"executions":`+
+				`{"total":%d,"passed":%d,"failed":%d}}}`,
+			1000+number, number, number, total, passed, total-passed,
+		)
+	}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/"+testProject+"/launch", r.URL.Path)
+		query := r.URL.Query()
+		assert.Equal(t, "nightly", query.Get("filter.eq.name"))
+		assert.Equal(t, launchTrendSort, query.Get("page.sort"))
+		mu.Lock()
+		requestedPages = append(requestedPages, query.Get("page.page")+"/"+query.Get("page.size"))
+		mu.Unlock()
+
+		page, _ := strconv.Atoi(query.Get("page.page"))
+		size, _ := strconv.Atoi(query.Get("page.size"))
+		content := make([]string, 0, size)
+		for number := 120 - (page-1)*size; number > 120-page*size && number > 0; number-- {
+			content = append(content, launchJSON(number))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"content":[` + strings.Join(content, ",") + `]}`))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	).toolGetLaunchTrend()
+
+	callTrend := func(depth *int) launchTrend {
+		t.Helper()
+		requestedPages = nil
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetLaunchTrendArgs{
+			ProjectKey: testProject,
+			LaunchName: "nightly",
+			Depth:      depth,
+		})
+		require.NoError(t, err)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok, "expected TextContent")
+		var trend launchTrend
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &trend))
+		return trend
+	}
+
+	// Default depth: the last 10 launches, oldest first
+	trend := callTrend(nil)
+	assert.Equal(t, []string{"1/10"}, requestedPages)
+	require.Equal(t, 10, trend.Count)
+	require.Len(t, trend.Launches, 10)
+	assert.Equal(t, int64(111), trend.Launches[0].Number)
+	assert.Equal(t, int64(120), trend.Launches[9].Number)
+	assert.InDelta(t, 50.9, trend.Launches[0].PassRate, 0.01) // 565 of 1110
+	assert.InDelta(t, 50.0, trend.Launches[9].PassRate, 0.01) // 600 of 1200
+	assert.InDelta(t, -0.9, trend.PassRateChange, 0.01)
+
+	// A deep trend is fetched as several pages
+	trend = callTrend(openapi.PtrInt(75))
+	slices.Sort(requestedPages)
+	assert.Equal(t, []string{"1/50", "2/50"}, requestedPages)
+	require.Equal(t, 75, trend.Count)
+	assert.Equal(t, int64(46), trend.Launches[0].Number)
+	assert.Equal(t, int64(120), trend.Launches[74].Number)
+
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, GetLaunchTrendArgs{
+		ProjectKey: testProject,
+		LaunchName: "nightly",
+		Depth:      openapi.PtrInt(launchTrendMaxDepth + 1),
+	})
+	require.ErrorContains(t, err, "depth must be between 1 and 200")
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetLaunchTrendArgs{ProjectKey: testProject})
+	require.ErrorContains(t, err, "launch_name is required")
+}
+
+// TestExportLaunchTool tests that export_launch requests the right view and returns resource contents
+func TestGetActiveLaunchesTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	pageJSON := `{"content":[` +
+		`{"id":12,"uuid":"c","name":"nightly","number":9,"status":"IN_PROGRESS",` +
+		`"startTime":"2024-01-03T00:00:00Z","statistics":{"executions":{"total":4,"passed":3}}},` +
+		`{"id":11,"uuid":"b","name":"smoke","number":2,"status":"IN_PROGRESS",` +
+		`"startTime":"2024-01-02T00:00:00Z"}],` +
+		`"page":{"number":1,"size":2,"totalElements":5,"totalPages":3}}`
+
+	var capturedQuery url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/"+testProject+"/launch", r.URL.Path)
+		capturedQuery = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(pageJSON))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(newQueryParamsClient(ctx, serverURL), nil, "", nil)
+	_, handler := launchTools.toolGetActiveLaunches()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetActiveLaunchesArgs{
+		ProjectKey: testProject,
+		PageSize:   2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "IN_PROGRESS", capturedQuery.Get("filter.in.status"))
+	assert.Equal(t, activeLaunchesSort, capturedQuery.Get("page.sort"))
+	assert.Equal(t, "2", capturedQuery.Get("page.size"))
+
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var active activeLaunchesResult
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &active))
+	assert.Equal(t, int64(5), active.Count)
+	require.Len(t, active.Launches, 2)
+	assert.Equal(t, int64(12), active.Launches[0].ID)
+	assert.Equal(t, int32(4), active.Launches[0].Total)
+	assert.Equal(t, "smoke", active.Launches[1].Name)
+}
+
+func TestGetProjectMembersTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	pageJSON := `{"content":[` +
+		`{"id":1,"userId":"jdoe","email":"jdoe@example.com","fullName":"John Doe","userRole":"USER",` +
+		`"assignedProjects":{"test-project":{"projectRole":"MEMBER"},"other":{"projectRole":"PROJECT_MANAGER"}}},` +
+		`{"id":2,"userId":"admin","email":"admin@example.com","userRole":"ADMINISTRATOR"}],` +
+		`"page":{"number":2,"size":2,"totalElements":3,"totalPages":2}}`
+
+	var capturedQuery url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/project/"+testProject+"/users", r.URL.Path)
+		capturedQuery = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(pageJSON))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	)
+	_, handler := launchTools.toolGetProjectMembers()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetProjectMembersArgs{
+		ProjectKey: testProject,
+		Page:       2,
+		PageSize:   2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2", capturedQuery.Get("page.page"))
+	assert.Equal(t, "2", capturedQuery.Get("page.size"))
+	assert.Equal(t, projectMembersSort, capturedQuery.Get("page.sort"))
+
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var members projectMembersResult
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &members))
+	assert.Equal(t, []projectMember{
+		{Username: "jdoe", FullName: "John Doe", ProjectRole: "MEMBER", UserRole: "USER"},
+		{Username: "admin", UserRole: "ADMINISTRATOR"},
+	}, members.Members)
+	require.NotNil(t, members.Page)
+	assert.Equal(t, int64(3), members.Page.GetTotalElements())
+	assert.NotContains(t, textContent.Text, "jdoe@example.com")
+}
+
+func TestGetLaunchDefectDistributionTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	launchJSON := `{"id":7,"uuid":"a","name":"nightly","number":3,"status":"FAILED",` +
+		`"startTime":"2024-01-01T00:00:00Z","statistics":{"defects":{` +
+		`"product_bug":{"total":5,"pb001":3,"pb_custom":2},` +
+		`"to_investigate":{"total":1,"ti001":1},` +
+		`"system_issue":{"total":0,"si001":0}}}}`
+	projectJSON := `{"projectId":1,"projectName":"test-project",` +
+		`"creationDate":"2024-01-01T00:00:00Z","configuration":{"attributes":{},"subTypes":{` +
+		`"PRODUCT_BUG":[{"locator":"pb001","longName":"Product Bug"},` +
+		`{"locator":"pb_custom","longName":"UI Regression"}],` +
+		`"SYSTEM_ISSUE":[{"locator":"si001","longName":"System Issue"}]}}}`
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/" + testProject + "/launch/7":
+			_, _ = w.Write([]byte(launchJSON))
+		case "/api/v1/project/" + testProject:
+			_, _ = w.Write([]byte(projectJSON))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	)
+	_, handler := launchTools.toolGetLaunchDefectDistribution()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, LaunchDefectDistributionArgs{
+		ProjectKey: testProject,
+		LaunchID:   7,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	// Unknown locators are labeled with the locator itself, zero counts are dropped
+	assert.JSONEq(t, `{
+		"launch_id": 7,
+		"launch_name": "nightly",
+		"number": 3,
+		"total": 6,
+		"by_type": {"PRODUCT_BUG": 5, "TO_INVESTIGATE": 1},
+		"defects": {"Product Bug": 3, "UI Regression": 2, "ti001": 1}
+	}`, textContent.Text)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, LaunchDefectDistributionArgs{
+		ProjectKey: testProject,
+	})
+	require.ErrorContains(t, err, "launch_id is required")
+}
+
+func TestGetAnalyzerConfigTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	projectJSON := `{"projectId":1,"projectName":"test-project",` +
+		`"creationDate":"2024-01-01T00:00:00Z","configuration":{"attributes":{` +
+		`"analyzer.isAutoAnalyzerEnabled":"true",` +
+		`"analyzer.autoAnalyzerMode":"LAUNCH_NAME",` +
+		`"analyzer.minShouldMatch":"95",` +
+		`"analyzer.numberOfLogLines":"-1",` +
+		`"analyzer.indexingRunning":"false",` +
+		`"analyzer.uniqueError.enabled":"true",` +
+		`"job.keepLaunches":"90"},"subTypes":{}}}`
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/project/"+testProject, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(projectJSON))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	).toolGetAnalyzerConfig()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, ProjectKeyArgs{ProjectKey: testProject})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	// Only analyzer attributes are returned; settings the project does not report are omitted
+	assert.JSONEq(t, `{
+		"project": "test-project",
+		"auto_analyzer_enabled": true,
+		"auto_analyzer_mode": "LAUNCH_NAME",
+		"min_should_match": 95,
+		"number_of_log_lines": -1,
+		"indexing_running": false,
+		"settings": {
+			"analyzer.isAutoAnalyzerEnabled": "true",
+			"analyzer.autoAnalyzerMode": "LAUNCH_NAME",
+			"analyzer.minShouldMatch": "95",
+			"analyzer.numberOfLogLines": "-1",
+			"analyzer.indexingRunning": "false",
+			"analyzer.uniqueError.enabled": "true"
+		}
+	}`, textContent.Text)
+}
+
+func TestGetRetentionSettingsTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	projectJSON := `{"projectId":1,"projectName":"test-project",` +
+		`"creationDate":"2024-01-01T00:00:00Z","configuration":{"attributes":{` +
+		`"analyzer.isAutoAnalyzerEnabled":"true",` +
+		`"job.keepLaunches":"7776000",` +
+		`"job.keepLogs":"0",` +
+		`"job.interruptJobTime":"86400"},"subTypes":{}}}`
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/project/"+testProject, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(projectJSON))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	).toolGetRetentionSettings()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, ProjectKeyArgs{ProjectKey: testProject})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	// Only retention settings are returned; keepScreenshots is not reported by the project
+	assert.JSONEq(t, `{
+		"project": "test-project",
+		"keep_launches": {"value": "7776000", "days": 90},
+		"keep_logs": {"value": "0", "forever": true}
+	}`, textContent.Text)
+}
+
+func TestUpdateAnalyzerConfigTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	attributes := map[string]string{
+		"analyzer.isAutoAnalyzerEnabled": "false",
+		"analyzer.minShouldMatch":        "80",
+		"analyzer.numberOfLogLines":      "-1",
+		"job.keepLaunches":               "90",
+	}
+	var updatedAttributes map[string]string
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/project/"+testProject, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPut:
+			var body openapi.ComEpamReportportalBaseModelProjectUpdateProjectRQ
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			updatedAttributes = body.GetConfiguration().Attributes
+			maps.Copy(attributes, updatedAttributes)
+			_, _ = w.Write([]byte(`{"message":"updated"}`))
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"projectId":     1,
+				"projectName":   testProject,
+				"creationDate":  "2024-01-01T00:00:00Z",
+				"configuration": map[string]any{"attributes": attributes},
+			})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	_, handler := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	).toolUpdateAnalyzerConfig()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, UpdateAnalyzerConfigArgs{
+		ProjectKey:          testProject,
+		MinShouldMatch:      openapi.PtrInt(95),
+		NumberOfLogLines:    openapi.PtrInt(3),
+		AutoAnalyzerEnabled: openapi.PtrBool(true),
+	})
+	require.NoError(t, err)
+
+	// Only the requested analyzer attributes are sent
+	assert.Equal(t, map[string]string{
+		"analyzer.minShouldMatch":        "95",
+		"analyzer.numberOfLogLines":      "3",
+		"analyzer.isAutoAnalyzerEnabled": "true",
+	}, updatedAttributes)
+
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+	var config analyzerConfig
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &config))
+	require.NotNil(t, config.MinShouldMatch)
+	assert.Equal(t, 95, *config.MinShouldMatch)
+	require.NotNil(t, config.NumberOfLogLines)
+	assert.Equal(t, 3, *config.NumberOfLogLines)
+	require.NotNil(t, config.AutoAnalyzerEnabled)
+	assert.True(t, *config.AutoAnalyzerEnabled)
+	assert.NotContains(t, config.Settings, "job.keepLaunches")
+
+	tests := []struct {
+		name    string
+		args    UpdateAnalyzerConfigArgs
+		wantErr string
+	}{
+		{name: "nothing to update", args: UpdateAnalyzerConfigArgs{}, wantErr: "at least one of"},
+		{
+			name:    "min should match above 100",
+			args:    UpdateAnalyzerConfigArgs{MinShouldMatch: openapi.PtrInt(101)},
+			wantErr: "min_should_match must be between 0 and 100",
+		},
+		{
+			name:    "negative min should match",
+			args:    UpdateAnalyzerConfigArgs{MinShouldMatch: openapi.PtrInt(-5)},
+			wantErr: "min_should_match must be between 0 and 100",
+		},
+		{
+			name:    "zero log lines",
+			args:    UpdateAnalyzerConfigArgs{NumberOfLogLines: openapi.PtrInt(0)},
+			wantErr: "number_of_log_lines must be -1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updatedAttributes = nil
+			tt.args.ProjectKey = testProject
+			_, _, err := handler(ctx, &mcp.CallToolRequest{}, tt.args)
+			require.ErrorContains(t, err, tt.wantErr)
+			assert.Nil(t, updatedAttributes, "invalid settings must not be sent")
+		})
+	}
+}
+
+func TestExportLaunchTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	launchID := uint32(123)
+	pdfBytes := []byte("%PDF-1.4 fake report")
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(
+			t,
+			fmt.Sprintf("/api/v1/%s/launch/%d/report", testProject, launchID),
+			r.URL.Path,
+		)
+		switch r.URL.Query().Get("view") {
+		case "pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write(pdfBytes)
+		case "html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html>report</html>"))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	)
+	_, handler := launchTools.toolExportLaunch()
+
+	// Binary format is returned as blob contents
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, ExportLaunchArgs{
+		ProjectKey: testProject,
+		LaunchID:   launchID,
+		Format:     "PDF",
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	embedded, ok := result.Content[0].(*mcp.EmbeddedResource)
+	require.True(t, ok, "expected EmbeddedResource")
+	assert.Equal(t, "application/pdf", embedded.Resource.MIMEType)
+	assert.Equal(t, pdfBytes, embedded.Resource.Blob)
+
+	// HTML (default format) is returned as text contents
+	result, _, err = handler(ctx, &mcp.CallToolRequest{}, ExportLaunchArgs{
+		ProjectKey: testProject,
+		LaunchID:   launchID,
+	})
+	require.NoError(t, err)
+	embedded, ok = result.Content[0].(*mcp.EmbeddedResource)
+	require.True(t, ok, "expected EmbeddedResource")
+	assert.Equal(t, "<html>report</html>", embedded.Resource.Text)
+
+	// Unsupported format is rejected before calling ReportPortal
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, ExportLaunchArgs{
+		ProjectKey: testProject,
+		LaunchID:   launchID,
+		Format:     "docx",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid format")
+}
+
+// TestGetLaunchLogArchiveTool tests that all log pages of a launch end up in the ZIP archive
+func TestGetLaunchLogArchiveTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	launchID := uint32(77)
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, fmt.Sprintf("/api/v1/%s/log", testProject), r.URL.Path)
+		assert.Equal(t, "77", r.URL.Query().Get("filter.eq.launchId"))
+
+		page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseModelLogLogResource()
+		switch r.URL.Query().Get("page.page") {
+		case "1":
+			page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{
+				{Id: 1, Uuid: "log-1", Message: openapi.PtrString("first")},
+				{Id: 2, Uuid: "log-2", Message: openapi.PtrString("second")},
+			})
+			page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+				HasNext: openapi.PtrBool(true),
+			})
+		default:
+			page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{
+				{Id: 3, Uuid: "log-3", Message: openapi.PtrString("third")},
+			})
+			page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+				HasNext: openapi.PtrBool(false),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	)
+	_, handler := launchTools.toolGetLaunchLogArchive()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, LaunchIDArgs{
+		ProjectKey: testProject,
+		LaunchID:   launchID,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	embedded, ok := result.Content[0].(*mcp.EmbeddedResource)
+	require.True(t, ok, "expected EmbeddedResource")
+	assert.Equal(t, "application/zip", embedded.Resource.MIMEType)
+
+	zr, err := zip.NewReader(
+		bytes.NewReader(embedded.Resource.Blob),
+		int64(len(embedded.Resource.Blob)),
+	)
+	require.NoError(t, err)
+	require.Len(t, zr.File, 1)
+	assert.Equal(t, "launch-77-logs.jsonl", zr.File[0].Name)
+
+	f, err := zr.File[0].Open()
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[2], "third")
+}
+
+func TestGetLaunchAttachmentsTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+
+	attachmentLog := func(id, itemID int64, attachmentID, contentType string) openapi.ComEpamReportportalBaseModelLogLogResource {
+		return openapi.ComEpamReportportalBaseModelLogLogResource{
+			Id:      id,
+			Uuid:    fmt.Sprintf("log-%d", id),
+			Message: openapi.PtrString(fmt.Sprintf("log %d", id)),
+			ItemId:  openapi.PtrInt64(itemID),
+			BinaryContent: openapi.NewComEpamReportportalBaseModelLogLogResourceBinaryContent(
+				attachmentID,
+				attachmentID+"-thumb",
+				contentType,
+			),
+		}
+	}
+
+	var pages []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, fmt.Sprintf("/api/v1/%s/log", testProject), r.URL.Path)
+		assert.Equal(t, "77", r.URL.Query().Get("filter.eq.launchId"))
+		assert.Equal(t, "true", r.URL.Query().Get("filter.ex.binaryContent"))
+		assert.Equal(t, strconv.Itoa(launchAttachmentsPageSize), r.URL.Query().Get("page.size"))
+		pages = append(pages, r.URL.Query().Get("page.page"))
+
+		page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseModelLogLogResource()
+		switch r.URL.Query().Get("page.page") {
+		case "1":
+			page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{
+				attachmentLog(1, 10, "101", "image/png"),
+				{Id: 2, Uuid: "log-2"},
+			})
+			page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+				HasNext: openapi.PtrBool(true),
+			})
+		default:
+			page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{
+				attachmentLog(3, 11, "102", "text/plain"),
+			})
+			page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+				HasNext: openapi.PtrBool(false),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(newQueryParamsClient(ctx, serverURL), nil, "", nil)
+	_, handler := launchTools.toolGetLaunchAttachments()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, LaunchIDArgs{
+		ProjectKey: testProject,
+		LaunchID:   77,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, []string{"1", "2"}, pages)
+
+	var got struct {
+		LaunchID    uint32             `json:"launch_id"`
+		Attachments []launchAttachment `json:"attachments"`
+		Total       int                `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &got))
+	assert.Equal(t, uint32(77), got.LaunchID)
+	assert.Equal(t, 2, got.Total)
+	require.Len(t, got.Attachments, 2)
+	assert.Equal(t, "101", got.Attachments[0].AttachmentID)
+	assert.Equal(t, "image/png", got.Attachments[0].ContentType)
+	require.NotNil(t, got.Attachments[0].ItemID)
+	assert.Equal(t, int64(10), *got.Attachments[0].ItemID)
+	assert.Equal(t, int64(1), got.Attachments[0].LogID)
+	assert.Equal(t, "102", got.Attachments[1].AttachmentID)
+	assert.Equal(t, int64(11), *got.Attachments[1].ItemID)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, LaunchIDArgs{ProjectKey: testProject})
+	assert.ErrorContains(t, err, "launch_id is required")
+}
+
+func TestGetLaunchAttachmentsTool_Truncated(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+
+	var requests atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		page := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseModelLogLogResource()
+		page.SetContent([]openapi.ComEpamReportportalBaseModelLogLogResource{{Id: 1, Uuid: "log-1"}})
+		page.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+			HasNext: openapi.PtrBool(true),
+		})
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(newQueryParamsClient(ctx, serverURL), nil, "", nil)
+	_, handler := launchTools.toolGetLaunchAttachments()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, LaunchIDArgs{
+		ProjectKey: testProject,
+		LaunchID:   77,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(launchAttachmentsMaxPages), requests.Load())
+
+	var got struct {
+		Truncated bool   `json:"truncated"`
+		Message   string `json:"message"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &got))
+	assert.True(t, got.Truncated)
+	assert.Contains(t, got.Message, "were scanned for attachments")
+}
+
+func TestGetLaunchByNumberTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+
+	tests := []struct {
+		name        string
+		launches    *openapi.ComEpamReportportalBaseModelPageComEpamReportportalBaseReportingLaunchResource
+		expectError string
+	}{
+		{name: "found", launches: testLaunches()},
+		{
+			name:        "not found",
+			launches:    openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseReportingLaunchResource(),
+			expectError: `launch "iOS e2e" #2840 not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			launchesJSON, _ := json.Marshal(tt.launches)
+			mockServer := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, fmt.Sprintf("/api/v1/%s/launch", testProject), r.URL.Path)
+					assert.Equal(t, "iOS e2e", r.URL.Query().Get("filter.eq.name"))
+					assert.Equal(t, "2840", r.URL.Query().Get("filter.eq.number"))
+
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write(launchesJSON)
+				}),
+			)
+			defer mockServer.Close()
+
+			serverURL, _ := url.Parse(mockServer.URL)
+			launchTools := NewLaunchResources(
+				gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+				nil,
+				"",
+				nil,
+			)
+
+			_, handler := launchTools.toolGetLaunchByNumber()
+			result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetLaunchByNumberArgs{
+				ProjectKey: testProject,
+				LaunchName: "iOS e2e",
+				Number:     2840,
+			})
+
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			require.True(t, ok, "expected TextContent")
+
+			var responseLaunch openapi.ComEpamReportportalBaseReportingLaunchResource
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &responseLaunch))
+			assert.Equal(t, tt.launches.Content[0].Id, responseLaunch.Id)
+		})
+	}
+}
+
+func TestGetLaunchByUUIDTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+	launchUUID := "2c8a3b0e-9a4f-4c1e-8f6d-0b2e5d7a1c3f"
+
+	tests := []struct {
+		name        string
+		launchUUID  string
+		launches    *openapi.ComEpamReportportalBaseModelPageComEpamReportportalBaseReportingLaunchResource
+		expectCall  bool
+		expectError string
+	}{
+		{name: "found", launchUUID: launchUUID, launches: testLaunches(), expectCall: true},
+		{
+			name:        "not found",
+			launchUUID:  launchUUID,
+			launches:    openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseReportingLaunchResource(),
+			expectCall:  true,
+			expectError: `launch with UUID "` + launchUUID + `" not found`,
+		},
+		{
+			name:        "invalid UUID",
+			launchUUID:  "not-a-uuid",
+			expectError: `invalid launch_uuid "not-a-uuid"`,
+		},
+		{name: "missing UUID", expectError: "launch_uuid is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			launchesJSON, _ := json.Marshal(tt.launches)
+			var called atomic.Bool
+			mockServer := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					called.Store(true)
+					assert.Equal(t, fmt.Sprintf("/api/v1/%s/launch", testProject), r.URL.Path)
+					assert.Equal(t, launchUUID, r.URL.Query().Get("filter.eq.uuid"))
+
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write(launchesJSON)
+				}),
+			)
+			defer mockServer.Close()
+
+			serverURL, _ := url.Parse(mockServer.URL)
+			launchTools := NewLaunchResources(
+				gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+				nil,
+				"",
+				nil,
+			)
+
+			_, handler := launchTools.toolGetLaunchByUUID()
+			result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetLaunchByUUIDArgs{
+				ProjectKey: testProject,
+				LaunchUUID: tt.launchUUID,
+			})
+
+			assert.Equal(t, tt.expectCall, called.Load())
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			require.True(t, ok, "expected TextContent")
+
+			var responseLaunch openapi.ComEpamReportportalBaseReportingLaunchResource
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &responseLaunch))
+			assert.Equal(t, tt.launches.Content[0].Id, responseLaunch.Id)
+		})
+	}
+}
+
+func TestDiffAgainstBaselineTool(t *testing.T) {
+	ctx := context.Background()
+	testProject := "test-project"
+
+	var itemQueries []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/" + testProject + "/launch":
+			assert.Equal(t, "nightly", query.Get("filter.eq.name"))
+			assert.Equal(t, "PASSED", query.Get("filter.eq.status"))
+			assert.Equal(t, baselineLaunchSort, query.Get("page.sort"))
+			// The launch being diffed is skipped even when it is the latest passing one
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"content": []openapi.ComEpamReportportalBaseReportingLaunchResource{
+					{Id: 20, Uuid: "b", Name: "nightly", Number: 8, Status: "PASSED"},
+					{Id: 10, Uuid: "a", Name: "nightly", Number: 7, Status: "PASSED"},
+				},
+			})
+		case "/api/v1/" + testProject + "/item/v2":
+			itemQueries = append(
+				itemQueries,
+				query.Get("launchId")+":"+query.Get("filter.eq.status"),
+			)
+			assert.Equal(t, strconv.Itoa(launchItemsPageSize), query.Get("page.size"))
+			switch query.Get("launchId") {
+			case "20":
+				_, _ = w.Write([]byte(`{"content":[` +
+					`{"id":201,"name":"login","testCaseHash":111,"status":"FAILED",` +
+					`"issue":{"issueType":"pb001"}},` +
+					`{"id":202,"name":"new test","testCaseHash":222,"status":"FAILED"},` +
+					`{"id":203,"name":"no hash","status":"FAILED"}` +
+					`],"page":{"hasNext":false}}`))
+			case "10":
+				_, _ = w.Write([]byte(`{"content":[` +
+					`{"id":101,"name":"login","testCaseHash":111,"status":"PASSED"},` +
+					`{"id":103,"name":"logout","testCaseHash":333,"status":"PASSED"}` +
+					`],"page":{"hasNext":false}}`))
+			default:
+				t.Errorf("unexpected launchId %s", query.Get("launchId"))
+			}
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(newQueryParamsClient(ctx, serverURL), nil, "", nil)
+	_, handler := launchTools.toolDiffAgainstBaseline()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, DiffAgainstBaselineArgs{
+		ProjectKey:         testProject,
+		LaunchID:           20,
+		BaselineLaunchName: " nightly ",
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, []string{"20:FAILED", "10:PASSED"}, itemQueries)
+
+	var got struct {
+		LaunchID       uint32             `json:"launch_id"`
+		BaselineLaunch baselineLaunch     `json:"baseline_launch"`
+		NewlyFailing   []newlyFailingItem `json:"newly_failing"`
+		Total          int                `json:"total"`
+		Truncated      bool               `json:"truncated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &got))
+	assert.Equal(t, uint32(20), got.LaunchID)
+	assert.Equal(t, int64(10), got.BaselineLaunch.ID)
+	assert.Equal(t, int64(7), got.BaselineLaunch.Number)
+	assert.Equal(t, 1, got.Total)
+	assert.False(t, got.Truncated)
+	assert.Equal(t, []newlyFailingItem{{
+		ID:             201,
+		Name:           "login",
+		TestCaseHash:   111,
+		DefectType:     "pb001",
+		BaselineItemID: 101,
+	}}, got.NewlyFailing)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, DiffAgainstBaselineArgs{
+		ProjectKey: testProject,
+		LaunchID:   20,
+	})
+	assert.ErrorContains(t, err, "baseline_launch_name is required")
+}
+
+func TestDiffAgainstBaselineTool_NoBaseline(t *testing.T) {
+	ctx := context.Background()
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/test-project/launch", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"content":[]}`))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(newQueryParamsClient(ctx, serverURL), nil, "", nil)
+	_, handler := launchTools.toolDiffAgainstBaseline()
+
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, DiffAgainstBaselineArgs{
+		ProjectKey:         "test-project",
+		LaunchID:           20,
+		BaselineLaunchName: "nightly",
+	})
+	assert.ErrorContains(t, err, `no passing launch named "nightly" found`)
+}
+
+func TestGetLaunchLinkedIssuesTool(t *testing.T) {
+	ctx := context.Background()
+
+	var pages []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/test-project/item/v2", r.URL.Path)
+		query := r.URL.Query()
+		assert.Equal(t, "42", query.Get("launchId"))
+		assert.Equal(t, strconv.Itoa(launchItemsPageSize), query.Get("page.size"))
+		pages = append(pages, query.Get("page.page"))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch query.Get("page.page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"content":[` +
+				`{"id":1,"name":"login","status":"FAILED","issue":{"issueType":"pb001",` +
+				`"externalSystemIssues":[` +
+				`{"ticketId":"QA-2","url":"https://jira/QA-2","btsUrl":"https://jira","btsProject":"QA"},` +
+				`{"ticketId":"QA-2","url":"https://jira/QA-2","btsUrl":"https://jira","btsProject":"QA"}` +
+				`]}},` +
+				`{"id":2,"name":"no ticket","status":"FAILED","issue":{"issueType":"ti001"}},` +
+				`{"id":3,"name":"passed","status":"PASSED"}` +
+				`],"page":{"hasNext":true}}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"content":[` +
+				`{"id":4,"name":"logout","status":"FAILED","issue":{"issueType":"ab001",` +
+				`"externalSystemIssues":[` +
+				`{"ticketId":"QA-2","url":"https://jira/QA-2","btsUrl":"https://jira","btsProject":"QA"},` +
+				`{"ticketId":"QA-1","url":"https://jira/QA-1","btsUrl":"https://jira","btsProject":"QA"}` +
+				`]}}` +
+				`],"page":{"hasNext":false}}`))
+		default:
+			t.Errorf("unexpected page %s", query.Get("page.page"))
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(newQueryParamsClient(ctx, serverURL), nil, "", nil)
+	_, handler := launchTools.toolGetLaunchLinkedIssues()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, LaunchIDArgs{
+		ProjectKey: "test-project",
+		LaunchID:   42,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, pages)
+
+	assert.JSONEq(t, `{
+		"launch_id": 42,
+		"issues": [
+			{
+				"ticket_id": "QA-1", "url": "https://jira/QA-1", "bts_project": "QA", "bts_url": "https://jira",
+				"items": [{"id": 4, "name": "logout", "status": "FAILED", "defect_type": "ab001"}]
+			},
+			{
+				"ticket_id": "QA-2", "url": "https://jira/QA-2", "bts_project": "QA", "bts_url": "https://jira",
+				"items": [
+					{"id": 1, "name": "login", "status": "FAILED", "defect_type": "pb001"},
+					{"id": 4, "name": "logout", "status": "FAILED", "defect_type": "ab001"}
+				]
+			}
+		],
+		"total": 2,
+		"items_scanned": 4,
+		"truncated": false
+	}`, result.Content[0].(*mcp.TextContent).Text)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, LaunchIDArgs{ProjectKey: "test-project"})
+	assert.ErrorContains(t, err, "launch_id is required")
+}
+
+func TestGetSlowestItemsTool(t *testing.T) {
+	ctx := context.Background()
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/test-project/item/v2", r.URL.Path)
+		assert.Equal(t, "42", r.URL.Query().Get("launchId"))
+		assert.Equal(t, strconv.Itoa(launchItemsPageSize), r.URL.Query().Get("page.size"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"content":[` +
+			`{"id":1,"name":"fast","status":"PASSED",` +
+			`"startTime":"2026-01-01T10:00:00Z","endTime":"2026-01-01T10:00:01.5Z"},` +
+			`{"id":2,"name":"slow","status":"FAILED",` +
+			`"startTime":"2026-01-01T10:00:00Z","endTime":"2026-01-01T10:02:00Z"},` +
+			`{"id":3,"name":"running","status":"IN_PROGRESS","startTime":"2026-01-01T10:00:00Z"},` +
+			`{"id":4,"name":"medium","status":"PASSED",` +
+			`"startTime":"2026-01-01T10:00:00Z","endTime":"2026-01-01T10:00:30Z"}` +
+			`],"page":{"hasNext":false}}`))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(newQueryParamsClient(ctx, serverURL), nil, "", nil)
+	_, handler := launchTools.toolGetSlowestItems()
+
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, GetSlowestItemsArgs{
+		ProjectKey: "test-project",
+		LaunchID:   42,
+		Limit:      openapi.PtrInt(2),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"launch_id": 42,
+		"items": [
+			{"id": 2, "name": "slow", "status": "FAILED", "duration_ms": 120000, "duration": "2m0s"},
+			{"id": 4, "name": "medium", "status": "PASSED", "duration_ms": 30000, "duration": "30s"}
+		],
+		"items_scanned": 4,
+		"items_unfinished": 1,
+		"truncated": false
+	}`, result.Content[0].(*mcp.TextContent).Text)
+
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, GetSlowestItemsArgs{
+		ProjectKey: "test-project",
+		LaunchID:   42,
+		Limit:      openapi.PtrInt(slowestItemsMaxLimit + 1),
+	})
+	assert.ErrorContains(t, err, "limit must be between 1 and")
+}
+
+func TestDeleteLaunchTool_RequireConfirm(t *testing.T) {
+	ctx := context.Background()
+	project := "test-project"
+
+	tests := []struct {
+		name           string
+		requireConfirm bool
+		confirm        bool
+		expectDeleted  bool
+	}{
+		{name: "confirmation disabled", requireConfirm: false, confirm: false, expectDeleted: true},
+		{name: "unconfirmed", requireConfirm: true, confirm: false, expectDeleted: false},
+		{name: "confirmed", requireConfirm: true, confirm: true, expectDeleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted atomic.Bool
+			mockServer := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodDelete, r.Method)
+					assert.Equal(t, "/api/v1/"+project+"/launch/42", r.URL.Path)
+					deleted.Store(true)
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"message":"deleted"}`))
+				}),
+			)
+			defer mockServer.Close()
+
+			serverURL, _ := url.Parse(mockServer.URL)
+			launchTools := NewLaunchResources(
+				gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+				nil,
+				"",
+				nil,
+			)
+			launchTools.requireConfirm = tt.requireConfirm
+
+			_, handler := launchTools.toolDeleteLaunch()
+			result, _, err := handler(ctx, &mcp.CallToolRequest{}, DeleteLaunchArgs{
+				ProjectKey: project,
+				LaunchID:   42,
+				Confirm:    tt.confirm,
+			})
+
+			assert.Equal(t, tt.expectDeleted, deleted.Load())
+			if !tt.expectDeleted {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "confirm")
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, "Launch '42' has been deleted", textContent.Text)
+		})
+	}
+}
+
+func TestDeleteLaunchTool_DryRun(t *testing.T) {
+	ctx := context.Background()
+	project := "test-project"
+
+	launchJSON, _ := json.Marshal(openapi.ComEpamReportportalBaseReportingLaunchResource{
+		Id:     42,
+		Name:   "Nightly",
+		Number: 7,
+		Status: string(gorp.Statuses.Failed),
+	})
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only the read used to resolve the target is allowed in dry-run mode
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v1/"+project+"/launch/42", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(launchJSON)
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	)
+	// Dry run does not mutate anything, so it must not require confirmation
+	launchTools.requireConfirm = true
+
+	_, handler := launchTools.toolDeleteLaunch()
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, DeleteLaunchArgs{
+		ProjectKey: project,
+		LaunchID:   42,
+		DryRun:     true,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+
+	var dryRun struct {
+		DryRun bool           `json:"dry_run"`
+		Tool   string         `json:"tool"`
+		Change map[string]any `json:"change"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &dryRun))
+	assert.True(t, dryRun.DryRun)
+	assert.Equal(t, "launch_delete", dryRun.Tool)
+	assert.Equal(t, "Nightly", dryRun.Change["launch_name"])
+	assert.EqualValues(t, 7, dryRun.Change["launch_number"])
+}
+
+func TestStopLaunchTool(t *testing.T) {
+	ctx := context.Background()
+	project := "test-project"
+
+	var stopped atomic.Bool
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/"+project+"/launch/42/stop", r.URL.Path)
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "STOPPED", body["status"])
+		stopped.Store(true)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message":"stopped"}`))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	)
+
+	_, handler := launchTools.toolStopLaunch()
+	result, _, err := handler(ctx, &mcp.CallToolRequest{}, LaunchIDArgs{
+		ProjectKey: project,
+		LaunchID:   42,
+	})
+	require.NoError(t, err)
+	assert.True(t, stopped.Load())
+	require.Len(t, result.Content, 1)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	assert.Equal(t, "Launch '42' has been stopped", textContent.Text)
+}
+
+func TestBulkFinishLaunchesTool(t *testing.T) {
+	ctx := context.Background()
+	project := "test-project"
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "INTERRUPTED", body["status"])
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/"+project+"/launch/2/stop" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":4041,"message":"Launch '2' not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"message":"finished"}`))
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	)
+	_, handler := launchTools.toolBulkFinishLaunches()
+
+	t.Run("invalid status", func(t *testing.T) {
+		_, _, err := handler(ctx, &mcp.CallToolRequest{}, BulkFinishLaunchesArgs{
+			ProjectKey: project,
+			LaunchIDs:  []uint32{1},
+			Status:     "RUNNING",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid status")
+	})
+
+	t.Run("per-launch outcome", func(t *testing.T) {
+		result, _, err := handler(ctx, &mcp.CallToolRequest{}, BulkFinishLaunchesArgs{
+			ProjectKey: project,
+			LaunchIDs:  []uint32{1, 2, 3, 1},
+			Status:     "interrupted",
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Content, 1)
+		textContent, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+
+		var got struct {
+			Status        string             `json:"status"`
+			FinishedCount int                `json:"finished_count"`
+			Results       []bulkLaunchResult `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &got))
+		assert.Equal(t, "INTERRUPTED", got.Status)
+		assert.Equal(t, 2, got.FinishedCount)
+		require.Len(t, got.Results, 3)
+		assert.Equal(t, bulkLaunchResult{LaunchID: 1, Status: bulkLaunchFinished}, got.Results[0])
+		assert.Equal(t, uint32(2), got.Results[1].LaunchID)
+		assert.Equal(t, bulkLaunchFailed, got.Results[1].Status)
+		assert.Contains(t, got.Results[1].Error, "404")
+		assert.Equal(t, bulkLaunchResult{LaunchID: 3, Status: bulkLaunchFinished}, got.Results[2])
+	})
+}
+
+func TestRunQualityGateTool_ContextCancelled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Send headers and part of the body, then stall until the client goes away
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":`))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(context.Background(), "")),
+		nil,
+		"",
+		nil,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	_, handler := launchTools.toolRunQualityGate()
+	start := time.Now()
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, LaunchIDArgs{
+		ProjectKey: "test-project",
+		LaunchID:   42,
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 5*time.Second, "handler must return promptly once cancelled")
+
+	// A context that is already done must not reach ReportPortal at all
+	_, _, err = handler(ctx, &mcp.CallToolRequest{}, LaunchIDArgs{
+		ProjectKey: "test-project",
+		LaunchID:   42,
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// newQueryParamsClient creates a RP client that applies context query params like the servers do
+func newQueryParamsClient(ctx context.Context, serverURL *url.URL) *gorp.Client {
+	client := gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, ""))
+	client.APIClient.GetConfig().Middleware = app_middleware.QueryParamsMiddleware
+	return client
+}
+
+func testLaunches() *openapi.ComEpamReportportalBaseModelPageComEpamReportportalBaseReportingLaunchResource {
+	launches := openapi.NewComEpamReportportalBaseModelPageComEpamReportportalBaseReportingLaunchResource()
+	launches.SetContent([]openapi.ComEpamReportportalBaseReportingLaunchResource{
+		{
+			Id:        1,
+			Name:      "Test Launch 1",
+			Uuid:      "014b329b-a882-4c2d-9988-c2f6179a421b",
+			Number:    1,
+			StartTime: time.Now(),
+			Status:    string(gorp.Statuses.Passed),
+		},
+		{
+			Id:        2,
+			Name:      "Test Launch 2",
+			Uuid:      "014b329b-a882-4c2d-9988-c2f6179a421c",
+			Number:    2,
+			StartTime: time.Now(),
+			Status:    string(gorp.Statuses.Passed),
+		},
+	})
+	launches.SetPage(openapi.ComEpamReportportalBaseModelPagePageMetadata{
+		TotalPages:    openapi.PtrInt64(1),
+		HasNext:       openapi.PtrBool(false),
+		Number:        openapi.PtrInt64(1),
+		Size:          openapi.PtrInt64(int64(len(launches.Content))),
+		TotalElements: openapi.PtrInt64(int64(len(launches.Content))),
+	})
+
+	return launches
+}
+
+func TestRunAutoAnalysisTool_WaitReportsProgress(t *testing.T) {
+	ctx := context.Background()
+	pollInterval := autoAnalysisPollInterval
+	autoAnalysisPollInterval = time.Millisecond
+	t.Cleanup(func() { autoAnalysisPollInterval = pollInterval })
+
+	var polls atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/test-project/launch/analyze":
+			_, _ = w.Write([]byte(`{"message":"Auto analysis started"}`))
+		case "/api/v1/test-project/launch/123":
+			launch := openapi.ComEpamReportportalBaseReportingLaunchResource{
+				Id:        123,
+				Uuid:      "launch-123",
+				Name:      "Nightly",
+				Number:    7,
+				StartTime: time.Now(),
+				Status:    "FAILED",
+			}
+			// The analyzer is reported as running on the first poll only
+			if polls.Add(1) == 1 {
+				launch.Analysing = []string{"autoAnalyzer"}
+			}
+			_ = json.NewEncoder(w).Encode(launch)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+		nil,
+	)
+	srv := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0"}, nil)
+	registerTool(srv, launchTools.toolRunAutoAnalysis)
+
+	progress := make(chan *mcp.ProgressNotificationParams, 10)
+	st, ct := mcp.NewInMemoryTransports()
+	_, err := srv.Connect(ctx, st, nil)
+	require.NoError(t, err)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "0"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			progress <- req.Params
+		},
+	})
+	session, err := client.Connect(ctx, ct, nil)
+	require.NoError(t, err)
+	defer func() { _ = session.Close() }()
+
+	params := &mcp.CallToolParams{
+		Name: "run_auto_analysis",
+		Arguments: map[string]any{
+			"projectKey":          "test-project",
+			"launch_id":           123,
+			"analyzer_mode":       "current_launch",
+			"analyzer_type":       "autoAnalyzer",
+			"analyzer_item_modes": []string{"to_investigate"},
+			"wait":                true,
+		},
+	}
+	// SetProgressToken only updates an existing Meta map
+	params.Meta = mcp.Meta{}
+	params.SetProgressToken("analysis-progress")
+	result, err := session.CallTool(ctx, params)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok, "expected TextContent")
+	assert.Contains(t, textContent.Text, "Analysis finished")
+	assert.Equal(t, int32(2), polls.Load())
+
+	select {
+	case notification := <-progress:
+		assert.Equal(t, "analysis-progress", notification.ProgressToken)
+		assert.Contains(t, notification.Message, "still running (autoAnalyzer)")
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a progress notification")
+	}
+}
+
+// TestWaitForLaunchAnalysis_StopsBeforeRequestDeadline verifies that waiting for an analysis
+// ends shortly before the request deadline with a "still running" outcome instead of an error.
+func TestWaitForLaunchAnalysis_StopsBeforeRequestDeadline(t *testing.T) {
+	pollInterval := autoAnalysisPollInterval
+	autoAnalysisPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { autoAnalysisPollInterval = pollInterval })
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(openapi.ComEpamReportportalBaseReportingLaunchResource{
+			Id:        123,
+			Uuid:      "launch-123",
+			Name:      "Nightly",
+			Number:    7,
+			StartTime: time.Now(),
+			Status:    "FAILED",
+			Analysing: []string{"autoAnalyzer"},
+		})
+	}))
+	defer mockServer.Close()
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	launchTools := NewLaunchResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(context.Background(), "")),
+		nil,
+		"",
+		nil,
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestDeadlineMargin+200*time.Millisecond)
+	defer cancel()
+	finished, waited, err := launchTools.waitForLaunchAnalysis(ctx, "test-project", 123, &progressReporter{})
+	require.NoError(t, err)
+	assert.False(t, finished)
+	assert.Less(t, waited, 200*time.Millisecond+autoAnalysisPollInterval)
+	assert.NoError(t, ctx.Err(), "the wait must end before the request deadline")
 }
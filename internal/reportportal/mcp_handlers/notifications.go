@@ -0,0 +1,352 @@
+package mcphandlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/reportportal/goRP/v5/pkg/gorp"
+	"github.com/reportportal/goRP/v5/pkg/openapi"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/analytics"
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
+)
+
+// notificationOwnerRecipient is the recipient that stands for the owner of the launch
+const notificationOwnerRecipient = "OWNER"
+
+var (
+	// notificationSendCases are the launch results a notification rule can be triggered by
+	notificationSendCases = []string{
+		"ALWAYS",
+		"FAILED",
+		"TO_INVESTIGATE",
+		"MORE_10",
+		"MORE_20",
+		"MORE_50",
+	}
+	// notificationAttributesOperators combine the attribute conditions of a notification rule
+	notificationAttributesOperators = []string{"AND", "OR"}
+)
+
+// NotificationResources encapsulates the ReportPortal client for notification-related tools.
+type NotificationResources struct {
+	client            *gorp.Client
+	defaultProjectKey string
+	analytics         *analytics.Analytics
+}
+
+// NewNotificationResources creates a new NotificationResources instance.
+func NewNotificationResources(
+	client *gorp.Client,
+	analyticsClient *analytics.Analytics,
+	projectKey string,
+) *NotificationResources {
+	return &NotificationResources{
+		client:            client,
+		defaultProjectKey: projectKey,
+		analytics:         analyticsClient,
+	}
+}
+
+// RegisterNotificationTools registers all notification-related tools with the MCP server.
+func RegisterNotificationTools(
+	s *mcp.Server,
+	rpClient *gorp.Client,
+	defaultProjectKey string,
+	analyticsClient *analytics.Analytics,
+) {
+	notifications := NewNotificationResources(rpClient, analyticsClient, defaultProjectKey)
+
+	registerTool(s, notifications.toolGetNotificationRules)
+	registerTool(s, notifications.toolUpdateNotificationRule)
+}
+
+// getNotificationRules returns the notification rules of a project
+func (nr *NotificationResources) getNotificationRules(
+	ctx context.Context,
+	project string,
+) ([]openapi.ComEpamReportportalBaseModelProjectEmailSenderCaseDTO, error) {
+	rules, response, err := nr.client.ProjectSettingsAPI.GetNotifications(ctx, project).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.ExtractResponseError(err, response), err)
+	}
+	if rules == nil {
+		rules = []openapi.ComEpamReportportalBaseModelProjectEmailSenderCaseDTO{}
+	}
+	return rules, nil
+}
+
+// toolGetNotificationRules creates a tool that lists the notification rules of a project
+func (nr *NotificationResources) toolGetNotificationRules() (*mcp.Tool, ToolHandler[ProjectKeyArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(nr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+
+	return &mcp.Tool{
+			Name: "get_notification_rules",
+			Description: "Get the notification rules of a project: for each rule its ID, name, whether it is " +
+				"enabled, recipients, the launch result that triggers it (sendCase) and the launch name " +
+				"and attribute conditions",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+				},
+			},
+		},
+		utils.WithAnalytics(
+			nr.analytics,
+			"get_notification_rules",
+			func(ctx context.Context, req *mcp.CallToolRequest, args ProjectKeyArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				rules, err := nr.getNotificationRules(ctx, project)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				r, err := json.Marshal(rules)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
+
+// UpdateNotificationRuleArgs holds params for update_notification_rule. Unset fields are left
+// unchanged.
+type UpdateNotificationRuleArgs struct {
+	ProjectKey         string   `json:"projectKey"`
+	RuleID             int64    `json:"rule_id"`
+	RuleName           *string  `json:"rule_name"`
+	Enabled            *bool    `json:"enabled"`
+	Recipients         []string `json:"recipients"`
+	SendCase           *string  `json:"send_case"`
+	LaunchNames        []string `json:"launch_names"`
+	AttributesOperator *string  `json:"attributes_operator"`
+}
+
+// isValidNotificationRecipient reports whether recipient is OWNER, a bare email
+// address or a user login. Values containing "@" must be email addresses; any
+// other non-empty value without whitespace is taken as a login.
+func isValidNotificationRecipient(recipient string) bool {
+	if recipient == notificationOwnerRecipient {
+		return true
+	}
+	if strings.Contains(recipient, "@") {
+		address, err := mail.ParseAddress(recipient)
+		return err == nil && address.Address == recipient
+	}
+	return recipient != "" && !strings.ContainsFunc(recipient, unicode.IsSpace)
+}
+
+// applyTo validates the requested changes and applies them to the rule
+func (args UpdateNotificationRuleArgs) applyTo(
+	rule *openapi.ComEpamReportportalBaseModelProjectEmailSenderCaseDTO,
+) error {
+	if args.RuleName != nil {
+		name := strings.TrimSpace(*args.RuleName)
+		if name == "" {
+			return fmt.Errorf("rule_name must not be empty or whitespace")
+		}
+		rule.RuleName = name
+	}
+	if args.Enabled != nil {
+		rule.SetEnabled(*args.Enabled)
+	}
+	if args.Recipients != nil {
+		if len(args.Recipients) == 0 {
+			return fmt.Errorf("recipients must not be empty")
+		}
+		recipients := make([]string, 0, len(args.Recipients))
+		for _, recipient := range args.Recipients {
+			recipient = strings.TrimSpace(recipient)
+			if !isValidNotificationRecipient(recipient) {
+				return fmt.Errorf(
+					"recipient %q is not a valid email address, user login or %s",
+					recipient,
+					notificationOwnerRecipient,
+				)
+			}
+			if !slices.Contains(recipients, recipient) {
+				recipients = append(recipients, recipient)
+			}
+		}
+		rule.SetRecipients(recipients)
+	}
+	if args.SendCase != nil {
+		if !slices.Contains(notificationSendCases, *args.SendCase) {
+			return fmt.Errorf(
+				"send_case %q is not valid; must be one of: %s",
+				*args.SendCase,
+				strings.Join(notificationSendCases, ", "),
+			)
+		}
+		rule.SendCase = *args.SendCase
+	}
+	if args.LaunchNames != nil {
+		launchNames := make([]string, 0, len(args.LaunchNames))
+		for _, launchName := range args.LaunchNames {
+			if launchName = strings.TrimSpace(launchName); launchName == "" {
+				return fmt.Errorf("launch_names must not contain empty names")
+			}
+			launchNames = append(launchNames, launchName)
+		}
+		rule.SetLaunchNames(launchNames)
+	}
+	if args.AttributesOperator != nil {
+		if !slices.Contains(notificationAttributesOperators, *args.AttributesOperator) {
+			return fmt.Errorf(
+				"attributes_operator %q is not valid; must be one of: %s",
+				*args.AttributesOperator,
+				strings.Join(notificationAttributesOperators, ", "),
+			)
+		}
+		rule.AttributesOperator = *args.AttributesOperator
+	}
+	return nil
+}
+
+// toolUpdateNotificationRule creates a tool that changes one notification rule of a project and
+// returns all rules of the project afterwards
+func (nr *NotificationResources) toolUpdateNotificationRule() (*mcp.Tool, ToolHandler[UpdateNotificationRuleArgs, any]) {
+	pkSchema, err := utils.ProjectKeySchema(nr.defaultProjectKey)
+	if err != nil {
+		slog.Error("failed to build project key schema", "error", err)
+	}
+	sendCaseEnum := make([]any, len(notificationSendCases))
+	for i, sendCase := range notificationSendCases {
+		sendCaseEnum[i] = sendCase
+	}
+	operatorEnum := make([]any, len(notificationAttributesOperators))
+	for i, operator := range notificationAttributesOperators {
+		operatorEnum[i] = operator
+	}
+
+	return &mcp.Tool{
+			Name: "update_notification_rule",
+			Description: "Update a notification rule of a project, e.g. who is alerted and on which launch " +
+				"results. Only the given fields are changed; use get_notification_rules to find the rule_id. " +
+				"Returns all notification rules of the project after the update. This tool mutates data.",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					utils.ProjectKeyField: pkSchema,
+					"rule_id": {
+						Type:        "integer",
+						Description: "ID of the notification rule to update",
+						Minimum:     openapi.PtrFloat64(1),
+					},
+					"rule_name": {
+						Type:        "string",
+						Description: "New rule name",
+					},
+					"enabled": {
+						Type:        "boolean",
+						Description: "Whether the rule sends notifications",
+					},
+					"recipients": {
+						Type: "array",
+						Description: "Email addresses or user logins to notify, replacing the current " +
+							"recipients; OWNER stands for the owner of the launch",
+						Items:    &jsonschema.Schema{Type: "string"},
+						MinItems: openapi.PtrInt(1),
+					},
+					"send_case": {
+						Type:        "string",
+						Description: "Launch result that triggers the notification",
+						Enum:        sendCaseEnum,
+					},
+					"launch_names": {
+						Type: "array",
+						Description: "Names of the launches the rule applies to, replacing the current " +
+							"ones; an empty array applies the rule to all launches",
+						Items: &jsonschema.Schema{Type: "string"},
+					},
+					"attributes_operator": {
+						Type:        "string",
+						Description: "How the attribute conditions of the rule are combined",
+						Enum:        operatorEnum,
+					},
+				},
+				Required: []string{"rule_id"},
+			},
+		},
+		utils.WithAnalytics(
+			nr.analytics,
+			"update_notification_rule",
+			func(ctx context.Context, req *mcp.CallToolRequest, args UpdateNotificationRuleArgs) (*mcp.CallToolResult, any, error) {
+				project, err := utils.ExtractProject(ctx, args.ProjectKey)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if args.RuleID < 1 {
+					return nil, nil, fmt.Errorf("rule_id is required")
+				}
+
+				// The update endpoint replaces the whole rule, so the changes are applied to the current one
+				rules, err := nr.getNotificationRules(ctx, project)
+				if err != nil {
+					return nil, nil, err
+				}
+				idx := slices.IndexFunc(
+					rules,
+					func(rule openapi.ComEpamReportportalBaseModelProjectEmailSenderCaseDTO) bool {
+						return rule.GetId() == args.RuleID
+					},
+				)
+				if idx < 0 {
+					return nil, nil, fmt.Errorf("notification rule %d not found", args.RuleID)
+				}
+				rule := rules[idx]
+				if err := args.applyTo(&rule); err != nil {
+					return nil, nil, err
+				}
+
+				_, response, err := nr.client.ProjectSettingsAPI.UpdateNotification(ctx, project).
+					ComEpamReportportalBaseModelProjectEmailSenderCaseDTO(rule).
+					Execute()
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"%s: %w",
+						utils.ExtractResponseError(err, response),
+						err,
+					)
+				}
+
+				rules, err = nr.getNotificationRules(ctx, project)
+				if err != nil {
+					return nil, nil, fmt.Errorf(
+						"notification rule updated, but reading the rules back failed: %w",
+						err,
+					)
+				}
+
+				r, err := json.Marshal(rules)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
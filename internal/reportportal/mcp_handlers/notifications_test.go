@@ -0,0 +1,185 @@
+package mcphandlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/reportportal/goRP/v5/pkg/gorp"
+	"github.com/reportportal/goRP/v5/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNotificationTestServer serves the notification rules of "test-project" and records updates
+func newNotificationTestServer(
+	t *testing.T,
+	rules []openapi.ComEpamReportportalBaseModelProjectEmailSenderCaseDTO,
+) (*NotificationResources, *[]openapi.ComEpamReportportalBaseModelProjectEmailSenderCaseDTO) {
+	t.Helper()
+	var updates []openapi.ComEpamReportportalBaseModelProjectEmailSenderCaseDTO
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/test-project/settings/notification", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(rules)
+		case http.MethodPut:
+			var rule openapi.ComEpamReportportalBaseModelProjectEmailSenderCaseDTO
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&rule))
+			updates = append(updates, rule)
+			for i := range rules {
+				if rules[i].GetId() == rule.GetId() {
+					rules[i] = rule
+				}
+			}
+			_, _ = w.Write([]byte(`{"message":"Notification rule updated"}`))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	t.Cleanup(mockServer.Close)
+
+	serverURL, _ := url.Parse(mockServer.URL)
+	ctx := context.Background()
+	return NewNotificationResources(
+		gorp.NewClient(serverURL, gorp.WithApiKeyAuth(ctx, "")),
+		nil,
+		"",
+	), &updates
+}
+
+func testNotificationRules() []openapi.ComEpamReportportalBaseModelProjectEmailSenderCaseDTO {
+	rule := openapi.NewComEpamReportportalBaseModelProjectEmailSenderCaseDTO(
+		"nightly",
+		"FAILED",
+		"AND",
+	)
+	rule.SetId(7)
+	rule.SetEnabled(true)
+	rule.SetRecipients([]string{"OWNER", "qa@example.com"})
+	rule.SetLaunchNames([]string{"nightly"})
+	return []openapi.ComEpamReportportalBaseModelProjectEmailSenderCaseDTO{*rule}
+}
+
+func TestGetNotificationRulesTool(t *testing.T) {
+	notifications, _ := newNotificationTestServer(t, testNotificationRules())
+	_, handler := notifications.toolGetNotificationRules()
+
+	result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, ProjectKeyArgs{
+		ProjectKey: "test-project",
+	})
+	require.NoError(t, err)
+
+	var rules []openapi.ComEpamReportportalBaseModelProjectEmailSenderCaseDTO
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &rules))
+	require.Len(t, rules, 1)
+	assert.Equal(t, int64(7), rules[0].GetId())
+	assert.Equal(t, []string{"OWNER", "qa@example.com"}, rules[0].Recipients)
+}
+
+func TestUpdateNotificationRuleTool(t *testing.T) {
+	notifications, updates := newNotificationTestServer(t, testNotificationRules())
+	_, handler := notifications.toolUpdateNotificationRule()
+
+	args := UpdateNotificationRuleArgs{
+		ProjectKey: "test-project",
+		RuleID:     7,
+		Recipients: []string{"lead@example.com", " OWNER ", "lead@example.com", "jdoe"},
+		SendCase:   openapi.PtrString("TO_INVESTIGATE"),
+	}
+	result, _, err := handler(context.Background(), &mcp.CallToolRequest{}, args)
+	require.NoError(t, err)
+
+	require.Len(t, *updates, 1)
+	updated := (*updates)[0]
+	assert.Equal(t, int64(7), updated.GetId())
+	assert.Equal(t, "nightly", updated.RuleName, "unset fields must be kept")
+	assert.Equal(t, []string{"nightly"}, updated.LaunchNames)
+	assert.Equal(t, []string{"lead@example.com", "OWNER", "jdoe"}, updated.Recipients)
+	assert.Equal(t, "TO_INVESTIGATE", updated.SendCase)
+
+	var rules []openapi.ComEpamReportportalBaseModelProjectEmailSenderCaseDTO
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &rules))
+	require.Len(t, rules, 1)
+	assert.Equal(t, "TO_INVESTIGATE", rules[0].SendCase)
+}
+
+func TestUpdateNotificationRuleTool_Validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        UpdateNotificationRuleArgs
+		expectError string
+	}{
+		{
+			name:        "unknown rule",
+			args:        UpdateNotificationRuleArgs{RuleID: 8},
+			expectError: "notification rule 8 not found",
+		},
+		{
+			name:        "invalid email",
+			args:        UpdateNotificationRuleArgs{RuleID: 7, Recipients: []string{"qa@"}},
+			expectError: `recipient "qa@" is not a valid email address`,
+		},
+		{
+			name: "email with display name",
+			args: UpdateNotificationRuleArgs{
+				RuleID:     7,
+				Recipients: []string{"QA <qa@example.com>"},
+			},
+			expectError: "is not a valid email address",
+		},
+		{
+			name:        "login with whitespace",
+			args:        UpdateNotificationRuleArgs{RuleID: 7, Recipients: []string{"j doe"}},
+			expectError: `recipient "j doe" is not a valid email address, user login or OWNER`,
+		},
+		{
+			name:        "blank recipient",
+			args:        UpdateNotificationRuleArgs{RuleID: 7, Recipients: []string{"  "}},
+			expectError: `recipient "" is not a valid email address`,
+		},
+		{
+			name:        "empty recipients",
+			args:        UpdateNotificationRuleArgs{RuleID: 7, Recipients: []string{}},
+			expectError: "recipients must not be empty",
+		},
+		{
+			name: "invalid send case",
+			args: UpdateNotificationRuleArgs{
+				RuleID:   7,
+				SendCase: openapi.PtrString("SOMETIMES"),
+			},
+			expectError: `send_case "SOMETIMES" is not valid`,
+		},
+		{
+			name: "invalid attributes operator",
+			args: UpdateNotificationRuleArgs{
+				RuleID:             7,
+				AttributesOperator: openapi.PtrString("XOR"),
+			},
+			expectError: `attributes_operator "XOR" is not valid`,
+		},
+		{
+			name:        "missing rule id",
+			args:        UpdateNotificationRuleArgs{},
+			expectError: "rule_id is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifications, updates := newNotificationTestServer(t, testNotificationRules())
+			_, handler := notifications.toolUpdateNotificationRule()
+
+			tt.args.ProjectKey = "test-project"
+			_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.args)
+			assert.ErrorContains(t, err, tt.expectError)
+			assert.Empty(t, *updates, "an invalid update must not reach ReportPortal")
+		})
+	}
+}
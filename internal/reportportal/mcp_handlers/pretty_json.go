@@ -0,0 +1,57 @@
+package mcphandlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AddPrettyJSON indents the JSON text content of every tool result, whether it was returned
+// as received from ReportPortal or marshalled by the tool itself. Minified output is the
+// default, as it costs fewer tokens; this is meant for human-readable transcripts.
+func AddPrettyJSON(s *mcp.Server) {
+	s.AddReceivingMiddleware(prettyJSONMiddleware)
+}
+
+// prettyJSONMiddleware indents the JSON text content of tools/call results
+func prettyJSONMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		res, err := next(ctx, method, req)
+		result, ok := res.(*mcp.CallToolResult)
+		if method != "tools/call" || err != nil || !ok || result == nil {
+			return res, err
+		}
+
+		// Build a new result so that results shared with other callers are not modified
+		indented := *result
+		indented.Content = make([]mcp.Content, len(result.Content))
+		for i, content := range result.Content {
+			text, ok := content.(*mcp.TextContent)
+			if !ok {
+				indented.Content[i] = content
+				continue
+			}
+			textCopy := *text
+			textCopy.Text = indentJSONText(text.Text)
+			indented.Content[i] = &textCopy
+		}
+		return &indented, nil
+	}
+}
+
+// indentJSONText indents a JSON object or array. Any other text, including text that fails to
+// parse, is returned unchanged.
+func indentJSONText(text string) string {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return text
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(trimmed), "", "  "); err != nil {
+		return text
+	}
+	return buf.String()
+}
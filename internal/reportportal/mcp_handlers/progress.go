@@ -0,0 +1,90 @@
+package mcphandlers
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// progressHeartbeatInterval is how often long-running tools report that they are still working
+const progressHeartbeatInterval = 10 * time.Second
+
+// progressReporter sends MCP progress notifications for a tool call. It is a no-op when the
+// client did not ask for progress (no progress token in the request) or there is no session
+// to notify, so tools can report progress unconditionally.
+type progressReporter struct {
+	session *mcp.ServerSession
+	token   any
+
+	mu       sync.Mutex
+	progress float64
+}
+
+// newProgressReporter creates a reporter for the given tool call
+func newProgressReporter(req *mcp.CallToolRequest) *progressReporter {
+	p := &progressReporter{}
+	if req == nil || req.Session == nil || req.Params == nil {
+		return p
+	}
+	p.session = req.Session
+	p.token = req.Params.GetProgressToken()
+	return p
+}
+
+// report sends a progress notification with the given message. Progress increases by one on
+// every call since the total duration of ReportPortal operations is unknown.
+func (p *progressReporter) report(ctx context.Context, message string) {
+	if p.session == nil || p.token == nil {
+		return
+	}
+	p.mu.Lock()
+	p.progress++
+	progress := p.progress
+	p.mu.Unlock()
+
+	err := p.session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: p.token,
+		Message:       message,
+		Progress:      progress,
+	})
+	if err != nil {
+		// Progress is informational only; the tool call itself goes on
+		slog.DebugContext(ctx, "failed to send progress notification", "error", err)
+	}
+}
+
+// heartbeat reports message every interval until the returned stop function is called.
+// It keeps clients informed while waiting on a single blocking ReportPortal request.
+func (p *progressReporter) heartbeat(
+	ctx context.Context,
+	interval time.Duration,
+	message string,
+) (stop func()) {
+	if p.session == nil || p.token == nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.report(ctx, message)
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
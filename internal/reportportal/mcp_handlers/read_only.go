@@ -0,0 +1,63 @@
+package mcphandlers
+
+import (
+	"slices"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// mutatingToolNames is the curated list of tools that create, modify or delete data in
+// ReportPortal (including analysis triggers). Any new write tool must be added here so that
+// read-only mode keeps hiding it.
+var mutatingToolNames = []string{
+	// Launches
+	"update_launch",
+	"launch_force_finish",
+	"stop_launch",
+	"bulk_finish_launches",
+	"launch_delete",
+	"run_auto_analysis",
+	"run_unique_error_analysis",
+	"run_quality_gate",
+	"import_launch_from_file",
+	"update_analyzer_config",
+
+	// Test items
+	"update_defect_type_for_test_items",
+	"finish_stuck_items",
+	"bulk_add_attribute_to_items",
+	"set_ignore_analyzer",
+
+	// Dashboards
+	"create_dashboard",
+	"add_widget_to_dashboard",
+
+	// Notifications
+	"update_notification_rule",
+
+	// TMS
+	"create_milestone",
+	"create_test_plan",
+	"add_test_cases_to_test_plan",
+	"create_folder",
+	"delete_folder",
+	"create_test_case",
+	"update_test_case",
+	"delete_test_case",
+}
+
+// IsMutatingTool reports whether the named tool changes data in ReportPortal
+func IsMutatingTool(name string) bool {
+	return slices.Contains(mutatingToolNames, name)
+}
+
+// MutatingToolNames returns the names of all tools that change data in ReportPortal
+func MutatingToolNames() []string {
+	return slices.Clone(mutatingToolNames)
+}
+
+// RemoveMutatingTools unregisters all mutating tools from the server so that only
+// read-only tools are exposed to clients. It must be called after all tools are registered.
+func RemoveMutatingTools(s *mcp.Server) {
+	s.RemoveTools(mutatingToolNames...)
+}
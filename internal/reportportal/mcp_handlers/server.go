@@ -27,18 +27,69 @@ import (
 //go:embed prompts/*.yaml
 var PromptFiles embed.FS
 
-func NewServer(
-	version string,
-	hostUrl *url.URL,
-	token,
-	userID, project, analyticsAPISecret string,
-	analyticsOn bool,
-	tlsCfg *tls.Config,
-) (*mcp.Server, *analytics.Analytics, error) {
+// ServerOptions configures the stdio MCP server created by NewServer
+type ServerOptions struct {
+	Version string   // Reported in the MCP implementation info
+	HostURL *url.URL // ReportPortal host
+	Token   string   // ReportPortal API token
+	Project string   // Default project key
+
+	UserAgent string      // Outbound User-Agent header
+	TLSConfig *tls.Config // nil uses the Go default TLS behaviour
+
+	AnalyticsOn            bool          // Send usage analytics to GA4
+	UserID                 string        // Unified user ID for analytics
+	AnalyticsAPISecret     string        // GA4 API secret
+	MetricsFile            string        // Local usage metrics file (collected even with analytics off)
+	GA4Endpoint            string        // GA4 endpoint override (proxy/collector)
+	AnalyticsFlushInterval time.Duration // Time between analytics flushes
+
+	ReadOnly       bool             // Hide mutating tools
+	RequireConfirm bool             // Destructive tools need confirm: true
+	ToolCache      *ToolResultCache // nil disables tool result caching
+	ToolSettings   ToolSettings     // Defaults of the launch and test item tools
+	PrettyJSON     bool             // Indent JSON tool results (costs more tokens)
+
+	MaxPromptOutputBytes int // Cap of one rendered prompt (0 = promptreader.DefaultMaxOutputBytes)
+}
+
+// ToolSettings holds the server-wide defaults applied by the launch and test item tools.
+// The zero value uses the built-in defaults.
+type ToolSettings struct {
+	Pagination utils.Pagination     // Page size and sort orders used when a call omits them
+	FetchAll   utils.FetchAllLimits // Caps of fetch_all pagination
+	UIPaths    utils.UIPaths        // UI path templates of the webUrl links
+}
+
+// ToolSettingsFromFlags validates the tool defaults configured by the command flags
+func ToolSettingsFromFlags(cmd *cli.Command) (ToolSettings, error) {
+	pagination, err := utils.NewPagination(utils.PaginationDefaults{
+		PageSize:           cmd.Int("default-page-size"),
+		SortingForLaunches: cmd.String("default-sort-launches"),
+		SortingForItems:    cmd.String("default-sort-items"),
+		SortingForSuites:   cmd.String("default-sort-suites"),
+		SortingForLogs:     cmd.String("default-sort-logs"),
+	})
+	if err != nil {
+		return ToolSettings{}, err
+	}
+	fetchAll, err := utils.NewFetchAllLimits(cmd.Int("max-pages"), cmd.Int("max-total-results"))
+	if err != nil {
+		return ToolSettings{}, err
+	}
+	uiPaths, err := utils.NewUIPaths(cmd.String("ui-launch-path"), cmd.String("ui-item-path"))
+	if err != nil {
+		return ToolSettings{}, err
+	}
+	return ToolSettings{Pagination: pagination, FetchAll: fetchAll, UIPaths: uiPaths}, nil
+}
+
+// NewServer creates the MCP server with all ReportPortal tools and prompts registered
+func NewServer(opts ServerOptions) (*mcp.Server, *analytics.Analytics, error) {
 	s := mcp.NewServer(
 		&mcp.Implementation{
 			Name:    "reportportal-mcp-server",
-			Version: version,
+			Version: opts.Version,
 		},
 		&mcp.ServerOptions{
 			// Add server options as needed
@@ -48,7 +99,7 @@ func NewServer(
 	// Build an HTTP client for analytics and import operations.
 	// Bearer token injection is not needed here; the oauth2 transport handles
 	// that separately for the ReportPortal API client.
-	httpClient := buildHTTPClient(tlsCfg)
+	httpClient := buildHTTPClient(opts.TLSConfig, opts.UserAgent)
 
 	// Always thread httpClient into the oauth2 context so the oauth2 transport
 	// uses it for every outbound RP call — this preserves both Bearer token
@@ -59,21 +110,29 @@ func NewServer(
 	authCtx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
 
 	// Create a new ReportPortal client
-	rpClient := gorp.NewClient(hostUrl, gorp.WithApiKeyAuth(authCtx, token))
+	rpClient := gorp.NewClient(opts.HostURL, gorp.WithApiKeyAuth(authCtx, opts.Token))
 	rpClient.APIClient.GetConfig().Middleware = middleware.QueryParamsMiddleware
 
-	// Initialize analytics (disabled if analyticsOff is true)
+	// Initialize analytics (GA4 disabled if analyticsOff is true; a metrics file still
+	// collects usage locally)
 	var analyticsInstance *analytics.Analytics
-	if analyticsOn {
+	if opts.AnalyticsOn || opts.MetricsFile != "" {
 		var err error
+		analyticsAPISecret := opts.AnalyticsAPISecret
+		if !opts.AnalyticsOn {
+			analyticsAPISecret = ""
+		}
 
 		// Pass RP API token for secure hashing as user identifier
 		analyticsInstance, err = analytics.NewAnalytics(
-			userID,
+			opts.UserID,
 			analyticsAPISecret,
-			token,
-			hostUrl.String(),
-			tlsCfg,
+			opts.Token,
+			opts.HostURL.String(),
+			opts.TLSConfig,
+			analytics.WithMetricsFile(opts.MetricsFile),
+			analytics.WithGA4Endpoint(opts.GA4Endpoint),
+			analytics.WithFlushInterval(opts.AnalyticsFlushInterval),
 		)
 		if err != nil {
 			slog.Warn("Failed to initialize analytics", "error", err)
@@ -81,15 +140,46 @@ func NewServer(
 	}
 
 	// Register all launch-related tools and resources
-	RegisterLaunchTools(s, rpClient, project, analyticsInstance, httpClient)
+	RegisterLaunchTools(
+		s,
+		rpClient,
+		opts.Project,
+		analyticsInstance,
+		httpClient,
+		opts.RequireConfirm,
+		opts.ToolSettings,
+	)
 
 	// Register all test item-related tools and resources
-	RegisterTestItemTools(s, rpClient, project, analyticsInstance)
+	RegisterTestItemTools(s, rpClient, opts.Project, analyticsInstance, opts.ToolSettings)
 
 	// Register all TMS-related tools
-	RegisterTMSTools(s, rpClient, project, analyticsInstance)
+	RegisterTMSTools(s, rpClient, opts.Project, analyticsInstance)
+
+	// Register all dashboard-related tools
+	RegisterDashboardTools(s, rpClient, opts.Project, analyticsInstance)
+
+	// Register all notification-related tools
+	RegisterNotificationTools(s, rpClient, opts.Project, analyticsInstance)
+
+	// In read-only mode hide every tool that changes data in ReportPortal
+	if opts.ReadOnly {
+		RemoveMutatingTools(s)
+	}
+
+	// Suggest the closest tool names when a client calls a tool that does not exist
+	AddUnknownToolSuggestions(s)
+
+	// Indent JSON tool results for human-readable transcripts; added before the cache so that
+	// cached results are stored indented
+	if opts.PrettyJSON {
+		AddPrettyJSON(s)
+	}
 
-	prompts, err := ReadPrompts(PromptFiles, "prompts")
+	// Serve repeated identical read tool calls from the cache (nil when caching is disabled)
+	AddToolResultCache(s, opts.ToolCache, analyticsInstance)
+
+	prompts, err := ReadPrompts(PromptFiles, "prompts", opts.MaxPromptOutputBytes)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load prompts: %w", err)
 	}
@@ -101,8 +191,16 @@ func NewServer(
 	return s, analyticsInstance, nil
 }
 
-// readPrompts reads multiple YAML files containing prompt definitions
-func ReadPrompts(files embed.FS, dir string) ([]promptreader.PromptHandlerPair, error) {
+// readPrompts reads multiple YAML files containing prompt definitions. maxOutputBytes caps the
+// messages rendered by one prompt request; 0 uses promptreader.DefaultMaxOutputBytes.
+func ReadPrompts(
+	files embed.FS,
+	dir string,
+	maxOutputBytes int,
+) ([]promptreader.PromptHandlerPair, error) {
+	if maxOutputBytes == 0 {
+		maxOutputBytes = promptreader.DefaultMaxOutputBytes
+	}
 	entries, err := fs.ReadDir(files, dir)
 	if err != nil {
 		return nil, err
@@ -116,7 +214,10 @@ func ReadPrompts(files embed.FS, dir string) ([]promptreader.PromptHandlerPair,
 		if err != nil {
 			return nil, err
 		}
-		prompts, err := promptreader.ReadPrompts(data)
+		prompts, err := promptreader.ReadPrompts(
+			data,
+			promptreader.WithMaxOutputBytes(maxOutputBytes),
+		)
 		if err != nil {
 			return nil, fmt.Errorf("error loading prompts from YAML: %w", err)
 		}
@@ -134,25 +235,38 @@ func ReadPrompts(files embed.FS, dir string) ([]promptreader.PromptHandlerPair,
 // When tlsCfg is nil the default transport is used unchanged, preserving
 // HTTP_PROXY and other default behaviours. When non-nil the default transport
 // is cloned and its TLSClientConfig replaced so proxy/dial settings are still
-// inherited.
-func buildHTTPClient(tlsCfg *tls.Config) *http.Client {
+// inherited. A non-empty userAgent is set on every outbound request.
+func buildHTTPClient(tlsCfg *tls.Config, userAgent string) *http.Client {
 	client := &http.Client{Timeout: 30 * time.Second}
 	if tlsCfg != nil {
 		t := utils.NewBaseTransport()
 		t.TLSClientConfig = tlsCfg
 		client.Transport = t
 	}
+	if userAgent != "" {
+		client.Transport = utils.WithUserAgent(client.Transport, userAgent)
+	}
 	return client
 }
 
 func newMCPServer(cmd *cli.Command) (*mcp.Server, *analytics.Analytics, error) {
 	// Retrieve required parameters from the command flags
-	token := cmd.String("token")                     // API token
-	host := cmd.String("rp-host")                    // ReportPortal host URL
-	userID := cmd.String("user-id")                  // Unified user ID for analytics
-	project := cmd.String("project")                 // ReportPortal project key
-	analyticsAPISecret := analytics.GetAnalyticArg() // Analytics API secret
-	analyticsOff := cmd.Bool("analytics-off")        // Disable analytics flag
+	token := cmd.String("token")                       // API token
+	host := cmd.String("rp-host")                      // ReportPortal host URL
+	userID := cmd.String("user-id")                    // Unified user ID for analytics
+	project := cmd.String("project")                   // ReportPortal project key
+	analyticsAPISecret := analytics.GetAnalyticArg()   // Analytics API secret
+	analyticsOff := cmd.Bool("analytics-off")          // Disable analytics flag
+	readOnly := cmd.Bool("read-only")                  // Hide mutating tools
+	requireConfirm := cmd.Bool("require-confirm")      // Destructive tools need confirm: true
+	userAgentSuffix := cmd.String("user-agent-suffix") // Appended to the outbound User-Agent
+	metricsFile := cmd.String("metrics-file")          // Local usage metrics file
+	ga4Endpoint := cmd.String("ga4-endpoint")          // GA4 endpoint override (proxy/collector)
+	flushSec := cmd.Int("analytics-flush-interval")    // Seconds between analytics flushes
+	cacheSize := cmd.Int("cache-size")                 // Tool result cache capacity (0 = disabled)
+	cacheTTL := cmd.Int("cache-ttl")                   // Tool result cache TTL in seconds
+	prettyJSON := cmd.Bool("pretty")                   // Indent JSON tool results
+	promptBytes := cmd.Int("max-prompt-output-bytes")  // Cap of one rendered prompt
 
 	// TLS settings
 	insecureTLS := cmd.Bool("insecure")
@@ -174,25 +288,54 @@ func newMCPServer(cmd *cli.Command) (*mcp.Server, *analytics.Analytics, error) {
 		return nil, nil, fmt.Errorf("build TLS config: %w", err)
 	}
 
+	toolSettings, err := ToolSettingsFromFlags(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Create a new stdio server using the ReportPortal client
-	mcpServer, analyticsInstance, err := NewServer(
-		fmt.Sprintf(
+	mcpServer, analyticsInstance, err := NewServer(ServerOptions{
+		Version: fmt.Sprintf(
 			"%s (%s) %s",
 			config.Version,
 			config.Commit,
 			config.Date,
 		),
-		hostUrl,
-		token,
-		userID,
-		project,
-		analyticsAPISecret,
-		!analyticsOff, // Convert analyticsOff to analyticsOn
-		tlsCfg,
-	)
+		HostURL:                hostUrl,
+		Token:                  token,
+		Project:                project,
+		UserAgent:              utils.BuildUserAgent(config.Version, userAgentSuffix),
+		TLSConfig:              tlsCfg,
+		AnalyticsOn:            !analyticsOff,
+		UserID:                 userID,
+		AnalyticsAPISecret:     analyticsAPISecret,
+		MetricsFile:            metricsFile,
+		GA4Endpoint:            ga4Endpoint,
+		AnalyticsFlushInterval: time.Duration(flushSec) * time.Second,
+		ReadOnly:               readOnly,
+		RequireConfirm:         requireConfirm,
+		ToolCache:              NewToolResultCache(cacheSize, time.Duration(cacheTTL)*time.Second),
+		PrettyJSON:             prettyJSON,
+		MaxPromptOutputBytes:   promptBytes,
+		ToolSettings:           toolSettings,
+	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create ReportPortal MCP server: %w", err)
 	}
+	RegisterServerConfigTool(mcpServer, ServerConfigInfo{
+		Mode:             "stdio",
+		Version:          config.Version,
+		RPHost:           RedactHostURL(hostUrl),
+		DefaultProject:   project,
+		AnalyticsEnabled: !analyticsOff && analyticsAPISecret != "",
+		MetricsFile:      metricsFile,
+		ReadOnly:         readOnly,
+		RequireConfirm:   requireConfirm,
+		InsecureTLS:      insecureTLS,
+		CacheSize:        cacheSize,
+		CacheTTL:         (time.Duration(cacheTTL) * time.Second).String(),
+		DefaultPageSize:  toolSettings.Pagination.PageSize(),
+	}, analyticsInstance)
 	return mcpServer, analyticsInstance, nil
 }
 
@@ -0,0 +1,100 @@
+package mcphandlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/analytics"
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
+)
+
+// ServerConfigInfo is the effective configuration reported by get_server_config. It only holds
+// settings that are safe to share in an issue report: API tokens, the GA4 secret and any
+// credentials in the host URL are never part of it.
+type ServerConfigInfo struct {
+	Mode             string `json:"mode"`
+	Version          string `json:"version"`
+	RPHost           string `json:"rp_host"`
+	DefaultProject   string `json:"default_project,omitempty"`
+	AnalyticsEnabled bool   `json:"analytics_enabled"`
+	MetricsFile      string `json:"metrics_file,omitempty"`
+	ReadOnly         bool   `json:"read_only"`
+	RequireConfirm   bool   `json:"require_confirm"`
+	InsecureTLS      bool   `json:"insecure_tls"`
+	CacheSize        int    `json:"cache_size"`
+	CacheTTL         string `json:"cache_ttl,omitempty"`
+	DefaultPageSize  uint   `json:"default_page_size"`
+
+	// HTTP mode only
+	MaxWorkers          int    `json:"max_workers,omitempty"`
+	PerTokenConcurrency int    `json:"per_token_concurrency,omitempty"`
+	ConnectionTimeout   string `json:"connection_timeout,omitempty"`
+	ShutdownTimeout     string `json:"shutdown_timeout,omitempty"`
+	SessionIdleTimeout  string `json:"session_idle_timeout,omitempty"`
+	MaxRequestBytes     int64  `json:"max_request_bytes,omitempty"`
+	ValidateToken       bool   `json:"validate_token,omitempty"`
+}
+
+// RedactHostURL returns the ReportPortal host URL without user credentials or query parameters
+func RedactHostURL(hostURL *url.URL) string {
+	if hostURL == nil {
+		return ""
+	}
+	redacted := *hostURL
+	redacted.User = nil
+	redacted.RawQuery = ""
+	redacted.Fragment = ""
+	return redacted.String()
+}
+
+// ServerConfigResources exposes the server configuration to MCP clients
+type ServerConfigResources struct {
+	info      ServerConfigInfo
+	analytics *analytics.Analytics
+}
+
+// RegisterServerConfigTool registers get_server_config, reporting the given configuration
+func RegisterServerConfigTool(
+	s *mcp.Server,
+	info ServerConfigInfo,
+	analyticsClient *analytics.Analytics,
+) {
+	resources := &ServerConfigResources{info: info, analytics: analyticsClient}
+	registerTool(s, resources.toolGetServerConfig)
+}
+
+// GetServerConfigArgs holds params for get_server_config (none).
+type GetServerConfigArgs struct{}
+
+// toolGetServerConfig creates a tool that returns the non-secret settings of this server, so
+// that operators can check a deployment and users can attach the config to issue reports.
+func (sr *ServerConfigResources) toolGetServerConfig() (*mcp.Tool, ToolHandler[GetServerConfigArgs, any]) {
+	return &mcp.Tool{
+			Name: "get_server_config",
+			Description: "Get the effective configuration of this MCP server (mode, ReportPortal host, " +
+				"default project, analytics, timeouts and limits). Tokens and secrets are never included",
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			},
+		},
+		utils.WithAnalytics(
+			sr.analytics,
+			"get_server_config",
+			func(ctx context.Context, req *mcp.CallToolRequest, args GetServerConfigArgs) (*mcp.CallToolResult, any, error) {
+				r, err := json.Marshal(sr.info)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+				}, nil, nil
+			},
+		)
+}
@@ -3,7 +3,6 @@ package mcphandlers
 import (
 	"context"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -161,7 +160,7 @@ func (tr *TMSResources) toolGetMilestonesByFilter() (*mcp.Tool, ToolHandler[GetM
 
 				if resp.StatusCode >= 300 {
 					defer resp.Body.Close() //nolint:errcheck
-					respBody, readErr := io.ReadAll(resp.Body)
+					respBody, readErr := utils.ReadAllContext(ctx, resp.Body)
 					if readErr != nil {
 						return nil, nil, fmt.Errorf(
 							"milestone request failed (HTTP %d)",
@@ -319,7 +318,7 @@ func (tr *TMSResources) toolGetTestCasesForTestPlan() (*mcp.Tool, ToolHandler[Ge
 
 				if resp.StatusCode >= 300 {
 					defer resp.Body.Close() //nolint:errcheck
-					respBody, readErr := io.ReadAll(resp.Body)
+					respBody, readErr := utils.ReadAllContext(ctx, resp.Body)
 					if readErr != nil {
 						return nil, nil, fmt.Errorf(
 							"test cases for test plan request failed (HTTP %d)",
@@ -450,7 +449,7 @@ func (tr *TMSResources) toolGetTestFoldersByFilter() (*mcp.Tool, ToolHandler[Get
 
 				if resp.StatusCode >= 300 {
 					defer resp.Body.Close() //nolint:errcheck
-					respBody, readErr := io.ReadAll(resp.Body)
+					respBody, readErr := utils.ReadAllContext(ctx, resp.Body)
 					if readErr != nil {
 						return nil, nil, fmt.Errorf(
 							"folder request failed (HTTP %d)",
@@ -603,7 +602,7 @@ func (tr *TMSResources) toolGetTestCasesByFilter() (*mcp.Tool, ToolHandler[GetTe
 
 				if resp.StatusCode >= 300 {
 					defer resp.Body.Close() //nolint:errcheck
-					respBody, readErr := io.ReadAll(resp.Body)
+					respBody, readErr := utils.ReadAllContext(ctx, resp.Body)
 					if readErr != nil {
 						return nil, nil, fmt.Errorf(
 							"test case request failed (HTTP %d)",
@@ -1559,7 +1558,7 @@ func (tr *TMSResources) toolGetManualLaunches() (*mcp.Tool, ToolHandler[GetManua
 
 				if resp.StatusCode >= 300 {
 					defer resp.Body.Close() //nolint:errcheck
-					respBody, readErr := io.ReadAll(resp.Body)
+					respBody, readErr := utils.ReadAllContext(ctx, resp.Body)
 					if readErr != nil {
 						return nil, nil, fmt.Errorf(
 							"manual launches request failed (HTTP %d)",
@@ -1696,7 +1695,7 @@ func (tr *TMSResources) toolGetManualLaunchExecutions() (*mcp.Tool, ToolHandler[
 
 				if resp.StatusCode >= 300 {
 					defer resp.Body.Close() //nolint:errcheck
-					respBody, readErr := io.ReadAll(resp.Body)
+					respBody, readErr := utils.ReadAllContext(ctx, resp.Body)
 					if readErr != nil {
 						return nil, nil, fmt.Errorf(
 							"manual launch executions request failed (HTTP %d)",
@@ -0,0 +1,324 @@
+package mcphandlers
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
+)
+
+// DefaultToolCacheTTL is how long a cached tool result is served when no TTL is configured
+const DefaultToolCacheTTL = time.Minute
+
+// cacheableToolNames is the curated list of read tools whose results may be cached. Tools not
+// listed here are never cached, which keeps new tools uncached by default. Deliberately absent:
+// mutating tools, tools meant for polling (get_active_launches, get_launch_analysis_status),
+// large binary exports (export_launch, get_launch_log_archive, get_test_item_attachment_by_id)
+// and get_server_config, which reports the cache statistics itself.
+var cacheableToolNames = []string{
+	// Launches
+	"get_launches",
+	"get_last_launch_by_name",
+	"get_last_launches_by_names",
+	"get_launch_by_id",
+	"get_launch_by_number",
+	"get_launch_by_uuid",
+	"get_launch_meta",
+	"get_launch_trend",
+	"get_launch_attachments",
+	"get_launch_attribute_values",
+	"get_launch_attributes_map",
+	"get_launch_defect_distribution",
+	"get_launch_linked_issues",
+	"compare_launches_table",
+	"diff_against_baseline",
+	"get_analyzer_config",
+	"get_retention_settings",
+	"get_project_members",
+	"get_slowest_items",
+	"get_flaky_items",
+
+	// Test items
+	"get_test_items_by_filter",
+	"get_test_item_by_id",
+	"get_test_item_parameters",
+	"get_test_item_issue",
+	"get_items_by_code_ref",
+	"list_test_item_attachments",
+	"get_test_item_logs_by_filter",
+	"get_item_logs_text",
+	"get_test_suites_by_filter",
+	"get_project_defect_types",
+	"get_bts_integrations",
+	"get_test_items_history",
+	"get_test_case_history_by_hash",
+	"get_failure_context_logs",
+	"get_launch_failure_summary",
+	"get_logs_grouped_by_item",
+	"get_unique_failure_messages",
+	"get_nested_steps",
+
+	// Notifications
+	"get_notification_rules",
+
+	// TMS
+	"get_manual_launches",
+	"get_manual_launch_executions",
+	"get_milestones_by_filter",
+	"get_test_plan_by_id",
+	"get_test_cases_for_test_plan",
+	"get_test_folders_by_filter",
+	"get_test_cases_by_filter",
+}
+
+// ToolResultCache is an LRU cache of read tool results. Entries are keyed by tool name,
+// normalized arguments, project and token hash, and expire after the configured TTL.
+type ToolResultCache struct {
+	capacity int
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // most recently used at the front
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// toolCacheEntry is a cached tool result with its expiry. The project is kept so that a
+// successful mutating call can purge the entries it may have made stale.
+type toolCacheEntry struct {
+	key       string
+	scope     toolCacheScope
+	result    *mcp.CallToolResult
+	expiresAt time.Time
+}
+
+// toolCacheScope identifies the project and token a cached result belongs to
+type toolCacheScope struct {
+	project   string
+	tokenHash string
+}
+
+// ToolCacheStats reports the cache usage counters
+type ToolCacheStats struct {
+	Capacity int    `json:"capacity"`
+	Size     int    `json:"size"`
+	TTL      string `json:"ttl"`
+	Hits     int64  `json:"hits"`
+	Misses   int64  `json:"misses"`
+}
+
+// NewToolResultCache creates a cache holding up to capacity results. It returns nil (caching
+// disabled) for a non-positive capacity; a non-positive ttl uses DefaultToolCacheTTL.
+func NewToolResultCache(capacity int, ttl time.Duration) *ToolResultCache {
+	if capacity <= 0 {
+		return nil
+	}
+	if ttl <= 0 {
+		ttl = DefaultToolCacheTTL
+	}
+	return &ToolResultCache{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// AddToolResultCache serves repeated identical read tool calls from the cache. Only the tools
+// in cacheableToolNames are cached; a successful mutating tool call purges the cached results of
+// its project for every token. Calls served from the cache are reported to the tracker like any
+// other tool call. A nil cache leaves the server unchanged.
+func AddToolResultCache(s *mcp.Server, cache *ToolResultCache, tracker utils.EventTracker) {
+	if cache == nil {
+		return
+	}
+	s.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
+		return cache.middleware(next, tracker)
+	})
+}
+
+// Stats returns a snapshot of the cache usage counters
+func (c *ToolResultCache) Stats() ToolCacheStats {
+	c.mu.Lock()
+	size := c.order.Len()
+	c.mu.Unlock()
+	return ToolCacheStats{
+		Capacity: c.capacity,
+		Size:     size,
+		TTL:      c.ttl.String(),
+		Hits:     c.hits.Load(),
+		Misses:   c.misses.Load(),
+	}
+}
+
+// middleware intercepts tools/call requests of cacheable tools. Cache hits never reach the tool
+// handler and its analytics wrapper, so they are tracked here.
+func (c *ToolResultCache) middleware(
+	next mcp.MethodHandler,
+	tracker utils.EventTracker,
+) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		callReq, ok := req.(*mcp.CallToolRequest)
+		if method != "tools/call" || !ok || callReq.Params == nil {
+			return next(ctx, method, req)
+		}
+		if IsMutatingTool(callReq.Params.Name) {
+			res, err := next(ctx, method, req)
+			if result, ok := res.(*mcp.CallToolResult); ok && err == nil && !result.IsError {
+				scope := toolCallScope(ctx, callReq.Params.Arguments)
+				if n := c.purge(scope); n > 0 {
+					slog.DebugContext(ctx, "Cached tool results purged after a mutating call",
+						"tool", callReq.Params.Name, "entries", n)
+				}
+			}
+			return res, err
+		}
+		if !isCacheableTool(callReq.Params.Name) {
+			return next(ctx, method, req)
+		}
+
+		scope := toolCallScope(ctx, callReq.Params.Arguments)
+		key, err := toolCacheKey(scope, callReq.Params.Name, callReq.Params.Arguments)
+		if err != nil {
+			// Arguments that cannot be normalized are passed to the tool, which reports the problem
+			return next(ctx, method, req)
+		}
+		if cached, ok := c.get(key); ok {
+			c.hits.Add(1)
+			slog.DebugContext(ctx, "Tool result served from cache", "tool", callReq.Params.Name)
+			if tracker != nil {
+				tracker.TrackMCPEvent(ctx, callReq.Params.Name)
+			}
+			// Hand out a copy so that callers cannot modify the cached result
+			result := *cached
+			return &result, nil
+		}
+		c.misses.Add(1)
+
+		res, err := next(ctx, method, req)
+		if result, ok := res.(*mcp.CallToolResult); ok && err == nil && !result.IsError {
+			c.add(key, scope, result)
+		}
+		return res, err
+	}
+}
+
+// get returns a non-expired cached result and marks it as recently used
+func (c *ToolResultCache) get(key string) (*mcp.CallToolResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*toolCacheEntry) //nolint:forcetypeassert // only entries are stored
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.result, true
+}
+
+// add stores a result, evicting the least recently used entries beyond capacity
+func (c *ToolResultCache) add(key string, scope toolCacheScope, result *mcp.CallToolResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &toolCacheEntry{
+		key:       key,
+		scope:     scope,
+		result:    result,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*toolCacheEntry).key) //nolint:forcetypeassert
+	}
+}
+
+// purge removes the entries of the given project for all tokens and returns how many were
+// removed. An empty project (not resolvable from the call) purges every entry.
+func (c *ToolResultCache) purge(scope toolCacheScope) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for elem := c.order.Front(); elem != nil; {
+		next := elem.Next()
+		entry := elem.Value.(*toolCacheEntry) //nolint:forcetypeassert // only entries are stored
+		if scope.project == "" || entry.scope.project == scope.project {
+			c.order.Remove(elem)
+			delete(c.entries, entry.key)
+			removed++
+		}
+		elem = next
+	}
+	return removed
+}
+
+// isCacheableTool reports whether results of the named tool may be cached
+func isCacheableTool(name string) bool {
+	return slices.Contains(cacheableToolNames, name)
+}
+
+// toolCallScope resolves the project and token hash of a call. The project follows the
+// precedence of utils.ExtractProject: the request context first, then the projectKey argument.
+func toolCallScope(ctx context.Context, rawArgs json.RawMessage) toolCacheScope {
+	project, _ := utils.GetProjectFromContext(ctx)
+	if project == "" && len(rawArgs) > 0 {
+		var args struct {
+			ProjectKey string `json:"projectKey"`
+		}
+		if json.Unmarshal(rawArgs, &args) == nil {
+			project = strings.TrimSpace(args.ProjectKey)
+		}
+	}
+	token, _ := utils.GetTokenFromContext(ctx)
+	return toolCacheScope{project: project, tokenHash: utils.HashToken(token)}
+}
+
+// toolCacheKey hashes the tool name, normalized arguments, project and token of a call.
+// Arguments are re-encoded so that key order and whitespace do not affect the key.
+func toolCacheKey(scope toolCacheScope, toolName string, rawArgs json.RawMessage) (string, error) {
+	var args any
+	if len(rawArgs) > 0 {
+		if err := json.Unmarshal(rawArgs, &args); err != nil {
+			return "", err
+		}
+	}
+
+	keyData, err := json.Marshal(struct {
+		Tool      string `json:"tool"`
+		Args      any    `json:"args"`
+		Project   string `json:"project"`
+		TokenHash string `json:"token_hash"`
+	}{toolName, args, scope.project, scope.tokenHash})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(keyData)
+	return hex.EncodeToString(sum[:]), nil
+}
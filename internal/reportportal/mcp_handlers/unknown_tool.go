@@ -0,0 +1,141 @@
+package mcphandlers
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// maxToolSuggestions caps how many registered tool names are offered for an unknown tool
+	maxToolSuggestions = 3
+	// minToolSuggestionDistance is the edit distance always accepted as a near miss
+	minToolSuggestionDistance = 2
+)
+
+// AddUnknownToolSuggestions makes calls to an unregistered tool fail with an error listing the
+// closest registered tool names, so agents can recover from typos without a tools/list round trip.
+func AddUnknownToolSuggestions(s *mcp.Server) {
+	s.AddReceivingMiddleware(unknownToolMiddleware)
+}
+
+// unknownToolMiddleware rewrites the SDK "unknown tool" error of tools/call requests
+func unknownToolMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		result, err := next(ctx, method, req)
+		if err == nil || method != "tools/call" {
+			return result, err
+		}
+
+		callReq, ok := req.(*mcp.CallToolRequest)
+		var wireErr *jsonrpc.Error
+		if !ok || callReq.Params == nil || !errors.As(err, &wireErr) ||
+			wireErr.Code != jsonrpc.CodeInvalidParams ||
+			!strings.HasPrefix(wireErr.Message, "unknown tool") {
+			return result, err
+		}
+
+		names, listErr := registeredToolNames(ctx, next, callReq.Session)
+		if listErr != nil {
+			slog.DebugContext(ctx, "Failed to list tools for suggestions", "error", listErr)
+			return result, err
+		}
+
+		message := fmt.Sprintf("unknown tool %q", callReq.Params.Name)
+		suggestions := closestToolNames(callReq.Params.Name, names, maxToolSuggestions)
+		if len(suggestions) > 0 {
+			message += "; did you mean: " + strings.Join(suggestions, ", ") + "?"
+		} else {
+			message += "; call tools/list to see the available tools"
+		}
+		return nil, &jsonrpc.Error{Code: wireErr.Code, Message: message}
+	}
+}
+
+// registeredToolNames lists the tools the session can see, following pagination cursors
+func registeredToolNames(
+	ctx context.Context,
+	next mcp.MethodHandler,
+	session *mcp.ServerSession,
+) ([]string, error) {
+	var names []string
+	params := &mcp.ListToolsParams{}
+	for {
+		res, err := next(ctx, "tools/list", &mcp.ListToolsRequest{Session: session, Params: params})
+		if err != nil {
+			return nil, err
+		}
+		page, ok := res.(*mcp.ListToolsResult)
+		if !ok {
+			return nil, fmt.Errorf("unexpected tools/list result %T", res)
+		}
+		for _, tool := range page.Tools {
+			names = append(names, tool.Name)
+		}
+		if page.NextCursor == "" {
+			return names, nil
+		}
+		params = &mcp.ListToolsParams{Cursor: page.NextCursor}
+	}
+}
+
+// closestToolNames returns up to limit names closest to the requested one by edit distance.
+// Names containing the requested one (or contained in it) are always considered near misses.
+func closestToolNames(requested string, names []string, limit int) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	requested = strings.ToLower(strings.TrimSpace(requested))
+	maxDistance := max(minToolSuggestionDistance, len(requested)/3)
+
+	var candidates []candidate
+	for _, name := range names {
+		lower := strings.ToLower(name)
+		distance := levenshtein(requested, lower)
+		related := requested != "" &&
+			(strings.Contains(lower, requested) || strings.Contains(requested, lower))
+		if distance <= maxDistance || related {
+			candidates = append(candidates, candidate{name: name, distance: distance})
+		}
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		return cmp.Or(cmp.Compare(a.distance, b.distance), strings.Compare(a.name, b.name))
+	})
+
+	suggestions := make([]string, 0, min(limit, len(candidates)))
+	for _, c := range candidates[:min(limit, len(candidates))] {
+		suggestions = append(suggestions, c.name)
+	}
+	return suggestions
+}
+
+// levenshtein computes the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"strings"
+	"time"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// redactedHeaderValue replaces the value of sensitive headers in access log entries
+const redactedHeaderValue = "[REDACTED]"
+
+// sensitiveHeaders are request headers whose values are never written to the access log
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"X-Api-Key":           true,
+}
+
+// AccessLogMiddleware logs one entry per HTTP request at the given level with the method, path,
+// status, latency, response size, the MCP tool name for tools/call requests and the request
+// headers. Values of sensitive headers such as Authorization are redacted and request bodies are
+// never logged; the body is only parsed for the tool name. extraSensitive names further headers to
+// redact, such as a custom token header. The middleware must run after MaxRequestBodyMiddleware so
+// that the body it reads is bounded.
+func AccessLogMiddleware(
+	level slog.Level,
+	extraSensitive ...string,
+) func(http.Handler) http.Handler {
+	sensitive := sensitiveHeaders
+	if len(extraSensitive) > 0 {
+		sensitive = maps.Clone(sensitiveHeaders)
+		for _, name := range extraSensitive {
+			sensitive[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if !slog.Default().Enabled(ctx, level) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			toolName := extractToolName(r)
+			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			defer func() {
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+				attrs := []slog.Attr{
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.Int("status", status),
+					slog.Duration("latency", time.Since(start)),
+					slog.Int("bytes", ww.BytesWritten()),
+					slog.String("remote_addr", r.RemoteAddr),
+				}
+				if toolName != "" {
+					attrs = append(attrs, slog.String("tool", toolName))
+				}
+				attrs = append(attrs, redactedHeaders(r.Header, sensitive))
+				slog.LogAttrs(ctx, level, "HTTP request", attrs...)
+			}()
+
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
+
+// redactedHeaders returns the request headers as a log group with sensitive values redacted.
+// The auth scheme of the Authorization header is kept to tell bearer from other credentials.
+func redactedHeaders(header http.Header, sensitive map[string]bool) slog.Attr {
+	attrs := make([]any, 0, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		if sensitive[http.CanonicalHeaderKey(name)] {
+			value = redactedHeaderValue
+			if scheme, _, ok := strings.Cut(strings.TrimSpace(values[0]), " "); ok {
+				value = scheme + " " + redactedHeaderValue
+			}
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.Group("headers", attrs...)
+}
+
+// extractToolName returns the tool name of a JSON-RPC tools/call request, or empty string.
+// The body is read and restored so that the next handler sees it unchanged.
+func extractToolName(r *http.Request) string {
+	if r.Method != http.MethodPost || r.Body == nil || r.Body == http.NoBody {
+		return ""
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		Method string `json:"method"`
+		Params struct {
+			Name string `json:"name"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Method != "tools/call" {
+		return ""
+	}
+	return payload.Params.Name
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureDefaultLogger routes the default slog logger into a buffer for the duration of the test
+func captureDefaultLogger(t *testing.T, level slog.Level) *bytes.Buffer {
+	t.Helper()
+	var logBuf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logBuf, &slog.HandlerOptions{Level: level})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &logBuf
+}
+
+func TestAccessLogMiddleware(t *testing.T) {
+	logBuf := captureDefaultLogger(t, slog.LevelInfo)
+
+	const token = "secret-token-value"
+	body := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"get_launches","arguments":{"token":"` + token + `"}}}`
+
+	var forwardedBody string
+	handler := AccessLogMiddleware(slog.LevelInfo)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			forwardedBody = string(data)
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte("ok"))
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+	assert.Equal(t, body, forwardedBody, "body must reach the next handler unchanged")
+
+	logged := logBuf.String()
+	assert.Contains(t, logged, "headers.Authorization=\"Bearer "+redactedHeaderValue+"\"")
+	assert.NotContains(t, logged, token)
+	assert.Contains(t, logged, "method=POST")
+	assert.Contains(t, logged, "path=/mcp")
+	assert.Contains(t, logged, "status=202")
+	assert.Contains(t, logged, "bytes=2")
+	assert.Contains(t, logged, "tool=get_launches")
+	assert.Contains(t, logged, "latency=")
+	assert.Contains(t, logged, "headers.Content-Type=application/json")
+}
+
+func TestAccessLogMiddleware_ExtraSensitiveHeader(t *testing.T) {
+	logBuf := captureDefaultLogger(t, slog.LevelInfo)
+
+	handler := AccessLogMiddleware(slog.LevelInfo, "x-rp-token")(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/mcp", nil)
+	req.Header.Set("X-RP-Token", "secret-token-value")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	logged := logBuf.String()
+	assert.Contains(t, logged, "headers.X-Rp-Token="+redactedHeaderValue)
+	assert.NotContains(t, logged, "secret-token-value")
+}
+
+func TestAccessLogMiddleware_LevelBelowLogger(t *testing.T) {
+	logBuf := captureDefaultLogger(t, slog.LevelInfo)
+
+	handler := AccessLogMiddleware(slog.LevelDebug)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	assert.Empty(t, logBuf.String())
+}
+
+func TestExtractToolName(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   string
+	}{
+		{
+			name:   "tools/call request",
+			method: http.MethodPost,
+			body:   `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"get_launch_by_id"}}`,
+			want:   "get_launch_by_id",
+		},
+		{
+			name:   "other JSON-RPC method",
+			method: http.MethodPost,
+			body:   `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+		},
+		{
+			name:   "invalid JSON",
+			method: http.MethodPost,
+			body:   `not json`,
+		},
+		{
+			name:   "GET request",
+			method: http.MethodGet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/mcp", strings.NewReader(tt.body))
+			assert.Equal(t, tt.want, extractToolName(req))
+
+			rest, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, string(rest))
+		})
+	}
+}
+
+func TestRedactedHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer abc")
+	header.Set("Cookie", "session=xyz")
+	header.Set("X-Project", "my_project")
+
+	var logBuf bytes.Buffer
+	slog.New(slog.NewTextHandler(&logBuf, nil)).
+		Info("test", redactedHeaders(header, sensitiveHeaders))
+
+	logged := logBuf.String()
+	assert.Contains(t, logged, "headers.Authorization=\"Bearer [REDACTED]\"")
+	assert.Contains(t, logged, "headers.Cookie=[REDACTED]")
+	assert.Contains(t, logged, "headers.X-Project=my_project")
+	assert.NotContains(t, logged, "abc")
+	assert.NotContains(t, logged, "xyz")
+}
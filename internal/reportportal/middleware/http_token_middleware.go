@@ -3,22 +3,48 @@ package middleware
 import (
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
 )
 
+// projectQueryParam is the query parameter read as a fallback when the X-Project header is absent,
+// for MCP proxies that cannot set custom headers
+const projectQueryParam = "project"
+
+// DefaultTokenHeader is the request header carrying the RP API token as "Bearer <token>"
+const DefaultTokenHeader = "Authorization"
+
+// AnalyticsOptOutHeader lets a client opt out of analytics for its own requests ("true" to opt out)
+const AnalyticsOptOutHeader = "X-Analytics-Opt-Out"
+
 // HTTPTokenMiddleware returns an HTTP middleware function that extracts RP API tokens and project parameters
 func HTTPTokenMiddleware(next http.Handler) http.Handler {
+	return TokenHeaderMiddleware(DefaultTokenHeader)(next)
+}
+
+// TokenHeaderMiddleware works like HTTPTokenMiddleware but reads the RP API token from the given
+// header, for gateways that strip Authorization. The "Bearer " prefix is expected only in the
+// Authorization header; any other header carries the bare token.
+func TokenHeaderMiddleware(tokenHeader string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return tokenMiddleware(tokenHeader, next)
+	}
+}
+
+// tokenMiddleware extracts the RP API token from tokenHeader and the project parameter
+func tokenMiddleware(tokenHeader string, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Extract RP API token from request headers
-		rpToken := extractRPTokenFromRequest(r)
+		rpToken := extractRPTokenFromRequest(r, tokenHeader)
 
 		if rpToken != "" {
 			// Add token to request context for use by MCP handlers
 			r = r.WithContext(utils.WithTokenInContext(r.Context(), rpToken))
 
-			slog.Debug( //nolint:gosec // structured log with literal message string; r.Method/r.URL.Path are value args only
+			slog.DebugContext( //nolint:gosec // structured log with literal message string; r.Method/r.URL.Path are value args only
+				r.Context(),
 				"Extracted RP API token from HTTP request",
 				"source",
 				"http_header",
@@ -28,25 +54,27 @@ func HTTPTokenMiddleware(next http.Handler) http.Handler {
 				r.URL.Path,
 			)
 		} else {
-			slog.Debug( //nolint:gosec // structured log with literal message string; r.Method/r.URL.Path are value args only
+			slog.DebugContext( //nolint:gosec // structured log with literal message string; r.Method/r.URL.Path are value args only
+				r.Context(),
 				"No RP API token found in HTTP request headers",
 				"method",
 				r.Method,
 				"path",
 				r.URL.Path,
 				"checked_headers",
-				[]string{"Authorization"},
+				[]string{tokenHeader},
 			)
 		}
 
-		// Extract project parameter from request headers
+		// Extract project parameter from request headers (or the project query parameter as fallback)
 		rpProject := extractRPProjectFromRequest(r)
 
 		if rpProject != "" {
 			// Add project to request context for use by MCP handlers
 			r = r.WithContext(utils.WithProjectInContext(r.Context(), rpProject))
 
-			slog.Debug( //nolint:gosec // structured log with literal message; r.Method/r.URL.Path are value args only
+			slog.DebugContext( //nolint:gosec // structured log with literal message; r.Method/r.URL.Path are value args only
+				r.Context(),
 				"Extracted RP project parameter from HTTP request",
 				"source",
 				"http_header",
@@ -58,7 +86,8 @@ func HTTPTokenMiddleware(next http.Handler) http.Handler {
 				rpProject,
 			)
 		} else {
-			slog.Debug( //nolint:gosec // structured log with literal message; r.Method/r.URL.Path are value args only
+			slog.DebugContext( //nolint:gosec // structured log with literal message; r.Method/r.URL.Path are value args only
+				r.Context(),
 				"No RP project parameter found in HTTP request headers",
 				"method",
 				r.Method,
@@ -66,18 +95,44 @@ func HTTPTokenMiddleware(next http.Handler) http.Handler {
 				r.URL.Path,
 				"checked_headers",
 				[]string{"X-Project"},
+				"checked_query_params",
+				[]string{projectQueryParam},
 			)
 		}
 
+		// Honour the client's analytics opt-out regardless of server configuration
+		if analyticsOptOutRequested(r) {
+			r = r.WithContext(utils.WithAnalyticsOptOutInContext(r.Context()))
+			slog.DebugContext(r.Context(), "Client opted out of analytics for this request")
+		}
+
 		// Continue to next handler
 		next.ServeHTTP(w, r)
 	})
 }
 
 // extractRPTokenFromRequest extracts RP API token from HTTP request headers
-// Only supports Authorization Bearer tokens
-func extractRPTokenFromRequest(r *http.Request) string {
-	auth := r.Header.Get("Authorization")
+// Supports Authorization Bearer tokens and bare tokens in a custom header
+func extractRPTokenFromRequest(r *http.Request, tokenHeader string) string {
+	if http.CanonicalHeaderKey(tokenHeader) != DefaultTokenHeader {
+		token := strings.TrimSpace(r.Header.Get(tokenHeader))
+		if token == "" {
+			return ""
+		}
+		// A custom header carries the bare token; an auth scheme such as "Bearer " is not expected
+		if strings.ContainsAny(token, " \t") || !utils.ValidateRPToken(token) {
+			slog.DebugContext(r.Context(), "Invalid RP API token rejected",
+				"source", tokenHeader,
+				"validation", "failed")
+			return ""
+		}
+		slog.DebugContext(r.Context(), "Valid RP API token extracted from request header",
+			"source", tokenHeader,
+			"validation", "passed")
+		return token
+	}
+
+	auth := r.Header.Get(DefaultTokenHeader)
 	if auth != "" {
 		parts := strings.SplitN(auth, " ", 2)
 		if len(parts) == 2 && strings.EqualFold(parts[0], "Bearer") {
@@ -85,12 +140,12 @@ func extractRPTokenFromRequest(r *http.Request) string {
 
 			// Validate the extracted token before processing
 			if !utils.ValidateRPToken(token) {
-				slog.Debug("Invalid RP API token rejected",
+				slog.DebugContext(r.Context(), "Invalid RP API token rejected",
 					"source", "Authorization Bearer",
 					"validation", "failed")
 				return ""
 			}
-			slog.Debug("Valid RP API token extracted from request header",
+			slog.DebugContext(r.Context(), "Valid RP API token extracted from request header",
 				"source", "Authorization Bearer",
 				"validation", "passed")
 			return token
@@ -100,11 +155,12 @@ func extractRPTokenFromRequest(r *http.Request) string {
 }
 
 // extractRPProjectFromRequest extracts RP project parameter from HTTP request headers
-// Supports X-Project header
+// Supports X-Project header, falling back to the project query parameter when the header is absent
 func extractRPProjectFromRequest(r *http.Request) string {
 	project := strings.TrimSpace(r.Header.Get("X-Project"))
 	if project != "" {
-		slog.Debug( //nolint:gosec // structured log with literal message; project is a value arg only
+		slog.DebugContext( //nolint:gosec // structured log with literal message; project is a value arg only
+			r.Context(),
 			"Valid RP project parameter extracted from request header",
 			"source",
 			"X-Project",
@@ -113,5 +169,23 @@ func extractRPProjectFromRequest(r *http.Request) string {
 		)
 		return project
 	}
+	project = strings.TrimSpace(r.URL.Query().Get(projectQueryParam))
+	if project != "" {
+		slog.DebugContext( //nolint:gosec // structured log with literal message; project is a value arg only
+			r.Context(),
+			"Valid RP project parameter extracted from request query",
+			"source",
+			projectQueryParam,
+			"project",
+			project,
+		)
+		return project
+	}
 	return ""
 }
+
+// analyticsOptOutRequested reports whether the X-Analytics-Opt-Out header holds a true value
+func analyticsOptOutRequested(r *http.Request) bool {
+	optOut, err := strconv.ParseBool(strings.TrimSpace(r.Header.Get(AnalyticsOptOutHeader)))
+	return err == nil && optOut
+}
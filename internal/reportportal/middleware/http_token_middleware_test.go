@@ -216,6 +216,78 @@ func TestHTTPTokenMiddleware_ProjectExtraction(t *testing.T) {
 	}
 }
 
+func TestHTTPTokenMiddleware_ProjectQueryParamFallback(t *testing.T) {
+	tests := []struct {
+		name            string
+		headers         map[string]string
+		target          string
+		expectProject   bool
+		expectedProject string
+	}{
+		{
+			name:            "project query parameter present",
+			headers:         map[string]string{},
+			target:          "/mcp?project=query-project",
+			expectProject:   true,
+			expectedProject: "query-project",
+		},
+		{
+			name:            "X-Project header takes precedence over query parameter",
+			headers:         map[string]string{"X-Project": "header-project"},
+			target:          "/mcp?project=query-project",
+			expectProject:   true,
+			expectedProject: "header-project",
+		},
+		{
+			name:            "empty X-Project header falls back to query parameter",
+			headers:         map[string]string{"X-Project": "  "},
+			target:          "/mcp?project=query-project",
+			expectProject:   true,
+			expectedProject: "query-project",
+		},
+		{
+			name:            "project query parameter empty",
+			headers:         map[string]string{},
+			target:          "/mcp?project=",
+			expectProject:   false,
+			expectedProject: "",
+		},
+		{
+			name:            "project query parameter with whitespace",
+			headers:         map[string]string{},
+			target:          "/mcp?project=%20query-project%20",
+			expectProject:   true,
+			expectedProject: "query-project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedProject string
+			var projectFound bool
+
+			testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				capturedProject, projectFound = utils.GetProjectFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest("POST", tt.target, nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			rr := httptest.NewRecorder()
+
+			HTTPTokenMiddleware(testHandler).ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, tt.expectProject, projectFound)
+			if tt.expectProject {
+				assert.Equal(t, tt.expectedProject, capturedProject)
+			}
+		})
+	}
+}
+
 func TestHTTPTokenMiddleware_CombinedTokenAndProject(t *testing.T) {
 	// Test that both token and project can be extracted simultaneously
 	req := httptest.NewRequest("GET", "/test", nil)
@@ -272,3 +344,72 @@ func TestHTTPTokenMiddleware_NoHeaders(t *testing.T) {
 	assert.False(t, tokenFound)
 	assert.False(t, projectFound)
 }
+
+func TestTokenHeaderMiddleware_CustomHeader(t *testing.T) {
+	tests := []struct {
+		name          string
+		headers       map[string]string
+		expectedToken string
+	}{
+		{
+			name:          "bare token in custom header",
+			headers:       map[string]string{"X-RP-Token": "1234567890123456"},
+			expectedToken: "1234567890123456",
+		},
+		{
+			name:          "custom header name is case insensitive",
+			headers:       map[string]string{"x-rp-token": "  1234567890123456  "},
+			expectedToken: "1234567890123456",
+		},
+		{
+			name:          "Bearer prefix is not stripped from custom header",
+			headers:       map[string]string{"X-RP-Token": "Bearer 1234567890123456"},
+			expectedToken: "",
+		},
+		{
+			name:          "Authorization header is ignored",
+			headers:       map[string]string{"Authorization": "Bearer 1234567890123456"},
+			expectedToken: "",
+		},
+		{
+			name:          "invalid token in custom header",
+			headers:       map[string]string{"X-RP-Token": "short"},
+			expectedToken: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/test", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			var capturedToken string
+			testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				capturedToken, _ = utils.GetTokenFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			rr := httptest.NewRecorder()
+			TokenHeaderMiddleware("X-RP-Token")(testHandler).ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, tt.expectedToken, capturedToken)
+		})
+	}
+}
+
+func TestTokenHeaderMiddleware_AuthorizationHeader(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer 1234567890123456")
+
+	var capturedToken string
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedToken, _ = utils.GetTokenFromContext(r.Context())
+	})
+
+	TokenHeaderMiddleware("authorization")(testHandler).ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "1234567890123456", capturedToken)
+}
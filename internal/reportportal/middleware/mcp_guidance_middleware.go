@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// mcpRequestGuidance is the JSON body returned for malformed MCP requests. It spells out what a
+// well-formed request looks like, since a bare "Bad Request" leaves client authors guessing.
+type mcpRequestGuidance struct {
+	Error           string            `json:"error"`
+	RequiredHeaders map[string]string `json:"required_headers"`
+	Flow            []string          `json:"flow"`
+}
+
+// mcpRequiredHeaders describes the headers an MCP client must (or may) send
+var mcpRequiredHeaders = map[string]string{
+	"Content-Type":   "application/json (for POST requests)",
+	"Accept":         "application/json, text/event-stream",
+	"Authorization":  "Bearer <ReportPortal API token>",
+	"X-Project":      "<ReportPortal project key> (optional, or the project query parameter)",
+	"Mcp-Session-Id": "<session ID returned by initialize> (every request after initialize)",
+}
+
+// mcpRequestFlow describes the MCP handshake a client has to follow
+var mcpRequestFlow = []string{
+	"POST an 'initialize' JSON-RPC request without an Mcp-Session-Id header",
+	"Read the Mcp-Session-Id response header and send it with every following request",
+	"POST the 'notifications/initialized' notification",
+	"Call 'tools/list' and 'tools/call' as needed",
+}
+
+// WriteMCPRequestGuidance replies with the given status and a JSON body explaining the reason
+// together with the required MCP headers and the initialize-first flow
+func WriteMCPRequestGuidance(w http.ResponseWriter, status int, reason string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(mcpRequestGuidance{
+		Error:           reason,
+		RequiredHeaders: mcpRequiredHeaders,
+		Flow:            mcpRequestFlow,
+	})
+}
+
+// MCPRequestGuidanceMiddleware replaces the plain-text body of 400 Bad Request responses
+// (handshake and validation failures reported by the MCP handler) with the JSON guidance
+// of WriteMCPRequestGuidance. JSON-RPC error responses and all other responses, including
+// SSE streams, pass through unchanged.
+func MCPRequestGuidanceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gw := &guidanceResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(gw, r)
+
+		if gw.badRequest {
+			if isJSONRPCMessage(gw.body.Bytes()) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write(gw.body.Bytes())
+				return
+			}
+			reason := strings.TrimSpace(gw.body.String())
+			if reason == "" {
+				reason = http.StatusText(http.StatusBadRequest)
+			}
+			WriteMCPRequestGuidance(w, http.StatusBadRequest, reason)
+		}
+	})
+}
+
+// isJSONRPCMessage reports whether body is a JSON-RPC message, which clients parse
+// themselves and must receive as sent
+func isJSONRPCMessage(body []byte) bool {
+	var message struct {
+		JSONRPC string `json:"jsonrpc"`
+	}
+	return json.Unmarshal(body, &message) == nil && message.JSONRPC != ""
+}
+
+// guidanceResponseWriter holds back plain 400 responses so that their body can be replaced
+type guidanceResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+	badRequest  bool
+	body        bytes.Buffer
+}
+
+func (w *guidanceResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	// JSON bodies are already machine-readable (e.g. JSON-RPC errors) and are kept
+	if status == http.StatusBadRequest && !isJSONContentType(w.Header().Get("Content-Type")) {
+		w.badRequest = true
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *guidanceResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.badRequest {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush keeps SSE streaming working through the wrapper
+func (w *guidanceResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.badRequest {
+		return
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *guidanceResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// isJSONContentType reports whether contentType is application/json
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
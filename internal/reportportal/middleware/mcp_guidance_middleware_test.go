@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPRequestGuidanceMiddleware(t *testing.T) {
+	t.Run("bad request body is replaced with guidance", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Bad Request: session ID required", http.StatusBadRequest)
+		})
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		rr := httptest.NewRecorder()
+		MCPRequestGuidanceMiddleware(next).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+
+		var body mcpRequestGuidance
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, "Bad Request: session ID required", body.Error)
+		assert.Contains(t, body.RequiredHeaders, "Content-Type")
+		assert.Contains(t, body.RequiredHeaders, "Authorization")
+		assert.Contains(t, body.RequiredHeaders, "X-Project")
+		require.NotEmpty(t, body.Flow)
+		assert.Contains(t, body.Flow[0], "initialize")
+	})
+
+	t.Run("JSON-RPC error responses pass through", func(t *testing.T) {
+		const rpcError = `{"jsonrpc":"2.0","id":1,"error":{"code":-32600,"message":"invalid request"}}`
+		tests := []struct {
+			name        string
+			contentType string
+		}{
+			{name: "JSON content type", contentType: "application/json; charset=utf-8"},
+			{name: "plain text content type", contentType: "text/plain; charset=utf-8"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", tt.contentType)
+					w.Header().Set("X-Content-Type-Options", "nosniff")
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(rpcError))
+				})
+				req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+				rr := httptest.NewRecorder()
+				MCPRequestGuidanceMiddleware(next).ServeHTTP(rr, req)
+
+				assert.Equal(t, http.StatusBadRequest, rr.Code)
+				assert.Equal(t, tt.contentType, rr.Header().Get("Content-Type"))
+				assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+				assert.Equal(t, rpcError, rr.Body.String())
+			})
+		}
+	})
+
+	t.Run("other responses pass through", func(t *testing.T) {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte("ok"))
+		})
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		rr := httptest.NewRecorder()
+		MCPRequestGuidanceMiddleware(next).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		assert.Equal(t, "ok", rr.Body.String())
+	})
+}
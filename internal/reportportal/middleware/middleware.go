@@ -13,6 +13,11 @@ func QueryParamsMiddleware(rq *http.Request) {
 		rq.Header.Set("Authorization", "Bearer "+token)
 	}
 
+	// Propagate the incoming request correlation ID to ReportPortal
+	if requestID, ok := utils.GetRequestIDFromContext(rq.Context()); ok {
+		rq.Header.Set(RequestIDHeader, requestID)
+	}
+
 	// Handle query parameters from context
 	paramsFromContext, ok := utils.QueryParamsFromContext(rq.Context())
 	if ok && paramsFromContext != nil {
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
+)
+
+// RequestIDHeader is the HTTP header used to correlate requests across the gateway and the MCP server
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength caps client-supplied request IDs to keep logs and headers bounded
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware reads the X-Request-ID header from the incoming request (generating a UUID if absent),
+// stores it in the request context, and echoes it back in the response header.
+// The ID is also stored under Chi's request ID key so that Chi's Logger prints the same value.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := extractRequestIDFromRequest(r)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		ctx := utils.WithRequestIDInContext(r.Context(), requestID)
+		ctx = context.WithValue(ctx, chimiddleware.RequestIDKey, requestID)
+		r = r.WithContext(ctx)
+
+		w.Header().Set(RequestIDHeader, requestID)
+
+		slog.DebugContext( //nolint:gosec // structured log with literal message; r.Method/r.URL.Path are value args only
+			ctx,
+			"Handling HTTP request",
+			"method",
+			r.Method,
+			"path",
+			r.URL.Path,
+		)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// extractRequestIDFromRequest returns the client-supplied request ID if it is usable, or empty string
+func extractRequestIDFromRequest(r *http.Request) string {
+	requestID := strings.TrimSpace(r.Header.Get(RequestIDHeader))
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return ""
+	}
+	// Reject control characters to avoid header/log injection
+	for _, c := range requestID {
+		if c < 0x20 || c == 0x7f {
+			return ""
+		}
+	}
+	return requestID
+}
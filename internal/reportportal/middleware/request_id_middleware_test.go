@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name         string
+		headerValue  string
+		expectedEcho string // empty means a generated UUID is expected
+	}{
+		{
+			name:         "incoming request ID is propagated and echoed",
+			headerValue:  "gateway-req-42",
+			expectedEcho: "gateway-req-42",
+		},
+		{
+			name:         "incoming request ID is trimmed",
+			headerValue:  "  gateway-req-43  ",
+			expectedEcho: "gateway-req-43",
+		},
+		{
+			name:        "missing request ID is generated",
+			headerValue: "",
+		},
+		{
+			name:        "oversized request ID is replaced",
+			headerValue: strings.Repeat("a", maxRequestIDLength+1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/mcp", nil)
+			if tt.headerValue != "" {
+				req.Header.Set(RequestIDHeader, tt.headerValue)
+			}
+
+			var capturedID string
+			var found bool
+			testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				capturedID, found = utils.GetRequestIDFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			rr := httptest.NewRecorder()
+			RequestIDMiddleware(testHandler).ServeHTTP(rr, req)
+
+			echoed := rr.Header().Get(RequestIDHeader)
+			assert.True(t, found)
+			assert.Equal(t, capturedID, echoed)
+			if tt.expectedEcho != "" {
+				assert.Equal(t, tt.expectedEcho, echoed)
+			} else {
+				assert.NoError(t, uuid.Validate(echoed))
+			}
+		})
+	}
+}
+
+func TestQueryParamsMiddleware_PropagatesRequestID(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://example.com/path", nil)
+	req = req.WithContext(utils.WithRequestIDInContext(req.Context(), "gateway-req-42"))
+
+	QueryParamsMiddleware(req)
+
+	assert.Equal(t, "gateway-req-42", req.Header.Get(RequestIDHeader))
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// DefaultMaxRequestBytes is the request body limit used when none is configured (4 MiB).
+// It also bounds import_launch_from_file in HTTP mode: base64 file content grows by a third, so
+// the default admits files of about 3 MiB, well below the 50 MiB import limit.
+const DefaultMaxRequestBytes int64 = 4 << 20
+
+// MaxRequestBodyMiddleware rejects requests whose body exceeds maxBytes with 413 Request Entity Too Large.
+// The body is read through http.MaxBytesReader before the request is dispatched, so an oversized
+// JSON-RPC payload never reaches the MCP handler. A non-positive maxBytes uses DefaultMaxRequestBytes.
+func MaxRequestBodyMiddleware(maxBytes int64) func(http.Handler) http.Handler {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxRequestBytes
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Reject early when the client announces an oversized body
+			if r.ContentLength > maxBytes {
+				rejectOversizedRequest(w, r, maxBytes)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBytes))
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					rejectOversizedRequest(w, r, maxBytes)
+					return
+				}
+				http.Error(w, "Failed to read request body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rejectOversizedRequest replies 413 and logs the rejected request
+func rejectOversizedRequest(w http.ResponseWriter, r *http.Request, maxBytes int64) {
+	slog.WarnContext(r.Context(), "Rejected oversized request body",
+		"path", r.URL.Path,
+		"content_length", r.ContentLength,
+		"max_bytes", maxBytes,
+	)
+	http.Error(
+		w,
+		fmt.Sprintf(
+			"Request body too large: the limit is %d bytes "+
+				"(raise it with --max-request-bytes / RP_MAX_REQUEST_BYTES)",
+			maxBytes,
+		),
+		http.StatusRequestEntityTooLarge,
+	)
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxRequestBodyMiddleware(t *testing.T) {
+	const maxBytes = 16
+
+	tests := []struct {
+		name          string
+		body          string
+		chunked       bool // hide the length so the limit is enforced while reading
+		expectedCode  int
+		expectForward bool
+	}{
+		{
+			name:          "body within limit is forwarded intact",
+			body:          `{"jsonrpc":"2"}`,
+			expectedCode:  http.StatusOK,
+			expectForward: true,
+		},
+		{
+			name:         "oversized body with content length is rejected",
+			body:         strings.Repeat("a", maxBytes+1),
+			expectedCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:         "oversized chunked body is rejected",
+			body:         strings.Repeat("a", maxBytes+1),
+			chunked:      true,
+			expectedCode: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var forwarded string
+			nextCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				forwarded = string(body)
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rr := httptest.NewRecorder()
+			MaxRequestBodyMiddleware(maxBytes)(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+			assert.Equal(t, tt.expectForward, nextCalled)
+			if tt.expectForward {
+				assert.Equal(t, tt.body, forwarded)
+			} else {
+				assert.Contains(t, rr.Body.String(), "max-request-bytes")
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
+)
+
+// tokenConcurrencyRetryAfter is the Retry-After value, in seconds, sent with 429 responses
+const tokenConcurrencyRetryAfter = 1
+
+// TokenConcurrencyLimiter bounds the number of in-flight requests per API token, so that a single
+// client cannot take all the slots of the global concurrency limit. Tokens are tracked by hash;
+// a token's entry is dropped as soon as it has no requests in flight.
+type TokenConcurrencyLimiter struct {
+	limit int
+
+	mu       sync.Mutex
+	inFlight map[string]int // token hash -> number of requests in flight
+}
+
+// NewTokenConcurrencyLimiter creates a limiter allowing up to limit in-flight requests per token.
+// It returns nil (no limit) for a non-positive limit.
+func NewTokenConcurrencyLimiter(limit int) *TokenConcurrencyLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return &TokenConcurrencyLimiter{
+		limit:    limit,
+		inFlight: make(map[string]int),
+	}
+}
+
+// Middleware replies 429 Too Many Requests when the token of the request (extracted by
+// HTTPTokenMiddleware) already has limit requests in flight. Requests without a token are passed
+// through unchanged. A nil limiter passes every request through.
+func (tl *TokenConcurrencyLimiter) Middleware(next http.Handler) http.Handler {
+	if tl == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := utils.GetTokenFromContext(r.Context())
+		if !ok || token == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		tokenHash := utils.HashToken(token)
+		if !tl.acquire(tokenHash) {
+			slog.WarnContext(r.Context(), "Rejected request over the per-token concurrency limit",
+				"path", r.URL.Path,
+				"limit", tl.limit,
+			)
+			w.Header().Set("Retry-After", strconv.Itoa(tokenConcurrencyRetryAfter))
+			http.Error(
+				w,
+				"Too many concurrent requests for this API token",
+				http.StatusTooManyRequests,
+			)
+			return
+		}
+		defer tl.release(tokenHash)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// acquire takes an in-flight slot of the token hash, reporting false if none is free
+func (tl *TokenConcurrencyLimiter) acquire(tokenHash string) bool {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	if tl.inFlight[tokenHash] >= tl.limit {
+		return false
+	}
+	tl.inFlight[tokenHash]++
+	return true
+}
+
+// release frees an in-flight slot of the token hash, dropping the entry of an idle token
+func (tl *TokenConcurrencyLimiter) release(tokenHash string) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	if tl.inFlight[tokenHash] <= 1 {
+		delete(tl.inFlight, tokenHash)
+		return
+	}
+	tl.inFlight[tokenHash]--
+}
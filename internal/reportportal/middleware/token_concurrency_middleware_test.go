@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
+)
+
+// trackedTokens returns the number of tokens with requests in flight
+func (tl *TokenConcurrencyLimiter) trackedTokens() int {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	return len(tl.inFlight)
+}
+
+// newTokenRequest builds an MCP request carrying the given token in its context
+func newTokenRequest(token string) *http.Request {
+	req := httptest.NewRequest("POST", "/mcp", nil)
+	if token != "" {
+		req = req.WithContext(utils.WithTokenInContext(req.Context(), token))
+	}
+	return req
+}
+
+func TestTokenConcurrencyLimiter_LimitsConcurrentRequestsPerToken(t *testing.T) {
+	const limit = 2
+	limiter := NewTokenConcurrencyLimiter(limit)
+
+	// Requests of the busy token block until released, so that they stay in flight
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	var served atomic.Int32
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served.Add(1)
+		if token, _ := utils.GetTokenFromContext(r.Context()); token == "busy-token" {
+			entered <- struct{}{}
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	var wg sync.WaitGroup
+	codes := make([]int, limit)
+	for i := range limit {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, newTokenRequest("busy-token"))
+			codes[i] = rr.Code
+		}()
+	}
+	for range limit {
+		<-entered
+	}
+
+	// The busy token is at its limit
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, newTokenRequest("busy-token"))
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+
+	// Other tokens and requests without a token are not affected
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, newTokenRequest("other-token"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, newTokenRequest(""))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK}, codes)
+	assert.Equal(t, int32(limit+2), served.Load())
+
+	// Idle tokens are dropped, and the freed slots can be taken again
+	assert.Equal(t, 0, limiter.trackedTokens())
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, newTokenRequest("other-token"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestTokenConcurrencyLimiter_ConcurrentLoad(t *testing.T) {
+	const (
+		limit    = 3
+		requests = 50
+	)
+	limiter := NewTokenConcurrencyLimiter(limit)
+
+	var current, peak atomic.Int32
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		current.Add(-1)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	var wg sync.WaitGroup
+	var ok, rejected atomic.Int32
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, newTokenRequest("shared-token"))
+			switch rr.Code {
+			case http.StatusOK:
+				ok.Add(1)
+			case http.StatusTooManyRequests:
+				rejected.Add(1)
+			default:
+				t.Errorf("unexpected status %d", rr.Code)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(requests), ok.Load()+rejected.Load())
+	assert.LessOrEqual(t, peak.Load(), int32(limit), "more requests in flight than the limit")
+	assert.Equal(t, 0, limiter.trackedTokens())
+}
+
+func TestTokenConcurrencyLimiter_Disabled(t *testing.T) {
+	limiter := NewTokenConcurrencyLimiter(0)
+	assert.Nil(t, limiter)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	rr := httptest.NewRecorder()
+	limiter.Middleware(next).ServeHTTP(rr, newTokenRequest("any-token"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
+)
+
+const (
+	// DefaultTokenValidationTTL is how long a successfully validated token is trusted
+	DefaultTokenValidationTTL = 5 * time.Minute
+	// tokenValidationTimeout bounds the validation call to ReportPortal
+	tokenValidationTimeout = 5 * time.Second
+	// tokenValidationPath is a cheap authenticated endpoint returning the current user
+	tokenValidationPath = "/api/v1/users"
+)
+
+// errTokenRejected is returned when ReportPortal rejects the token
+var errTokenRejected = errors.New("token rejected by ReportPortal")
+
+// TokenValidator checks bearer tokens against ReportPortal before MCP requests are dispatched.
+// Successful validations are cached by token hash for the configured TTL.
+type TokenValidator struct {
+	hostURL    *url.URL
+	httpClient *http.Client
+	ttl        time.Duration
+
+	mu    sync.Mutex
+	valid map[string]time.Time // token hash -> expiry of the positive result
+}
+
+// NewTokenValidator creates a TokenValidator for the given ReportPortal host.
+// A nil httpClient falls back to http.DefaultClient; a non-positive ttl uses DefaultTokenValidationTTL.
+func NewTokenValidator(hostURL *url.URL, httpClient *http.Client, ttl time.Duration) *TokenValidator {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if ttl <= 0 {
+		ttl = DefaultTokenValidationTTL
+	}
+	return &TokenValidator{
+		hostURL:    hostURL,
+		httpClient: httpClient,
+		ttl:        ttl,
+		valid:      make(map[string]time.Time),
+	}
+}
+
+// Middleware returns 401 for requests whose token (extracted by HTTPTokenMiddleware) is rejected
+// by ReportPortal. Requests without a token are passed through unchanged. If ReportPortal cannot be
+// reached the request is also passed through, so that the tool call reports the actual error.
+func (tv *TokenValidator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := utils.GetTokenFromContext(r.Context())
+		if !ok || token == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		tokenHash := utils.HashToken(token)
+		if tv.isCached(tokenHash) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		err := tv.validate(r.Context(), token)
+		switch {
+		case err == nil:
+			tv.cache(tokenHash)
+		case errors.Is(err, errTokenRejected):
+			slog.DebugContext(r.Context(), "Rejected request with invalid RP API token")
+			http.Error(
+				w,
+				"Invalid ReportPortal API token: check the Authorization header",
+				http.StatusUnauthorized,
+			)
+			return
+		default:
+			slog.WarnContext(r.Context(), "Failed to validate RP API token", "error", err)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isCached reports whether the token hash has a non-expired positive validation result
+func (tv *TokenValidator) isCached(tokenHash string) bool {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	expiry, ok := tv.valid[tokenHash]
+	if !ok {
+		return false
+	}
+	if time.Now().After(expiry) {
+		delete(tv.valid, tokenHash)
+		return false
+	}
+	return true
+}
+
+// cache stores a positive validation result for the token hash
+func (tv *TokenValidator) cache(tokenHash string) {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	// Drop expired entries so the cache does not grow with abandoned tokens
+	now := time.Now()
+	for hash, expiry := range tv.valid {
+		if now.After(expiry) {
+			delete(tv.valid, hash)
+		}
+	}
+	tv.valid[tokenHash] = now.Add(tv.ttl)
+}
+
+// validate performs an authenticated call to ReportPortal with the token.
+// It returns errTokenRejected when ReportPortal responds with 401 or 403.
+func (tv *TokenValidator) validate(ctx context.Context, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, tokenValidationTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		tv.hostURL.JoinPath(tokenValidationPath).String(),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := tv.httpClient.Do(req) //nolint:gosec // URL is built from the configured RP host
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			slog.WarnContext(ctx, "Failed to close response body", "error", closeErr)
+		}
+	}()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return errTokenRejected
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status from ReportPortal: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/reportportal/reportportal-mcp-server/internal/reportportal/utils"
+)
+
+const validTestToken = "good-token"
+
+// newMockRP starts a mock ReportPortal that accepts only validTestToken and counts validation calls
+func newMockRP(t *testing.T, calls *atomic.Int32, status int) *url.URL {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, tokenValidationPath, r.URL.Path)
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+validTestToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	hostURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return hostURL
+}
+
+// serveWithToken runs the validator middleware for a request carrying the given token
+func serveWithToken(tv *TokenValidator, token string) (*httptest.ResponseRecorder, bool) {
+	nextCalled := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nextCalled = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("POST", "/mcp", nil)
+	if token != "" {
+		req = req.WithContext(utils.WithTokenInContext(req.Context(), token))
+	}
+	rr := httptest.NewRecorder()
+	tv.Middleware(next).ServeHTTP(rr, req)
+	return rr, nextCalled
+}
+
+func TestTokenValidator_ValidTokenIsCached(t *testing.T) {
+	var calls atomic.Int32
+	tv := NewTokenValidator(newMockRP(t, &calls, 0), nil, time.Minute)
+
+	for range 3 {
+		rr, nextCalled := serveWithToken(tv, validTestToken)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, nextCalled)
+	}
+	assert.Equal(t, int32(1), calls.Load(), "valid token should be validated once")
+}
+
+func TestTokenValidator_InvalidTokenRejected(t *testing.T) {
+	var calls atomic.Int32
+	tv := NewTokenValidator(newMockRP(t, &calls, 0), nil, time.Minute)
+
+	for range 2 {
+		rr, nextCalled := serveWithToken(tv, "bad-token")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, nextCalled)
+	}
+	assert.Equal(t, int32(2), calls.Load(), "rejected tokens must not be cached")
+}
+
+func TestTokenValidator_ExpiredCacheRevalidates(t *testing.T) {
+	var calls atomic.Int32
+	tv := NewTokenValidator(newMockRP(t, &calls, 0), nil, time.Nanosecond)
+
+	serveWithToken(tv, validTestToken)
+	time.Sleep(time.Millisecond)
+	serveWithToken(tv, validTestToken)
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestTokenValidator_PassThrough(t *testing.T) {
+	t.Run("no token", func(t *testing.T) {
+		var calls atomic.Int32
+		tv := NewTokenValidator(newMockRP(t, &calls, 0), nil, time.Minute)
+
+		rr, nextCalled := serveWithToken(tv, "")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, nextCalled)
+		assert.Equal(t, int32(0), calls.Load())
+	})
+
+	t.Run("ReportPortal unavailable", func(t *testing.T) {
+		var calls atomic.Int32
+		tv := NewTokenValidator(
+			newMockRP(t, &calls, http.StatusServiceUnavailable),
+			nil,
+			time.Minute,
+		)
+
+		rr, nextCalled := serveWithToken(tv, validTestToken)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, nextCalled)
+
+		// Inconclusive results are not cached
+		serveWithToken(tv, validTestToken)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"net/url"
 	"strings"
 
@@ -19,6 +21,10 @@ const (
 	RPProjectContextKey ContextKey = "rp_project" //nolint:gosec // This is a context key, not a credential
 	// Key for storing query parameters in the context
 	ContextKeyQueryParams ContextKey = "queryParams" //nolint:gosec // This is a context key, not a credential
+	// RequestIDContextKey is used to store the X-Request-ID correlation ID in request context
+	RequestIDContextKey ContextKey = "request_id"
+	// AnalyticsOptOutContextKey marks requests whose client opted out of analytics
+	AnalyticsOptOutContextKey ContextKey = "analytics_opt_out"
 )
 
 func WithQueryParams(ctx context.Context, queryParams url.Values) context.Context {
@@ -49,6 +55,19 @@ func ValidateRPToken(token string) bool {
 	return len(token) >= 16
 }
 
+// HashToken creates a secure hash of the token, so it can be used as a key without being kept
+func HashToken(token string) string {
+	if token == "" {
+		return ""
+	}
+
+	// Create full SHA256 hash of the token
+	hash := sha256.Sum256([]byte(token))
+
+	// Return full hash
+	return hex.EncodeToString(hash[:])
+}
+
 // WithProjectInContext adds RP project parameter to request context
 func WithProjectInContext(ctx context.Context, project string) context.Context {
 	// Trim whitespace from project parameter
@@ -75,3 +94,27 @@ func GetTokenFromContext(ctx context.Context) (string, bool) {
 	token, ok := ctx.Value(RPTokenContextKey).(string)
 	return token, ok && token != ""
 }
+
+// WithRequestIDInContext adds the HTTP request correlation ID to request context
+func WithRequestIDInContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDContextKey, strings.TrimSpace(requestID))
+}
+
+// GetRequestIDFromContext extracts the HTTP request correlation ID from request context.
+// Tool handlers can use it to correlate their own logs or outgoing calls with the
+// originating HTTP request. Returns false in stdio mode or when no ID was set.
+func GetRequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(RequestIDContextKey).(string)
+	return requestID, ok && requestID != ""
+}
+
+// WithAnalyticsOptOutInContext marks the request context as opted out of analytics tracking
+func WithAnalyticsOptOutInContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, AnalyticsOptOutContextKey, true)
+}
+
+// IsAnalyticsOptOut reports whether the client opted out of analytics for this request
+func IsAnalyticsOptOut(ctx context.Context) bool {
+	optOut, ok := ctx.Value(AnalyticsOptOutContextKey).(bool)
+	return ok && optOut
+}
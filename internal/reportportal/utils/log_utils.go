@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"context"
+	"log/slog"
+)
+
+// RequestIDLogAttr is the slog attribute name used for the request correlation ID
+const RequestIDLogAttr = "request_id"
+
+// requestIDLogHandler decorates a slog.Handler so that every record logged with a
+// context carrying a request ID (see WithRequestIDInContext) includes it as an attribute.
+type requestIDLogHandler struct {
+	slog.Handler
+}
+
+// NewRequestIDLogHandler wraps the given handler with request ID enrichment.
+// Log calls must use the *Context variants (slog.InfoContext etc.) for the ID to be picked up.
+func NewRequestIDLogHandler(h slog.Handler) slog.Handler {
+	return &requestIDLogHandler{Handler: h}
+}
+
+// Handle adds the request ID attribute (if present in ctx) before delegating
+func (h *requestIDLogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if requestID, ok := GetRequestIDFromContext(ctx); ok {
+		r.AddAttrs(slog.String(RequestIDLogAttr, requestID))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs keeps the request ID enrichment on derived handlers
+func (h *requestIDLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &requestIDLogHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup keeps the request ID enrichment on derived handlers
+func (h *requestIDLogHandler) WithGroup(name string) slog.Handler {
+	return &requestIDLogHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -49,13 +51,169 @@ func ProjectKeySchema(defaultProjectKey string) (*jsonschema.Schema, error) {
 	return s, nil
 }
 
+// pageSortFieldPattern matches a ReportPortal sort field such as startTime or statistics$executions$total
+var pageSortFieldPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.$]*$`)
+
+// pageSortDirectionPattern matches tokens meant as a sort direction: RP field names are camelCase
+var pageSortDirectionPattern = regexp.MustCompile(`^[A-Z]+$`)
+
+// validatePageSort checks that pageSort has the field[,field...][,ASC|DESC] format; the direction
+// is case-insensitive, as in ReportPortal. ReportPortal silently falls back to its default
+// ordering for malformed values, so they are rejected here.
+func validatePageSort(pageSort string) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf(
+			"invalid page-sort %q: %s; expected field[,field...][,ASC|DESC], e.g. %q",
+			pageSort,
+			reason,
+			DefaultSortingForLaunches,
+		)
+	}
+
+	tokens := strings.Split(pageSort, ",")
+	for i := range tokens {
+		tokens[i] = strings.TrimSpace(tokens[i])
+	}
+	last := tokens[len(tokens)-1]
+	isDirection := strings.EqualFold(last, "ASC") || strings.EqualFold(last, "DESC")
+	if len(tokens) > 1 && (isDirection || pageSortDirectionPattern.MatchString(last)) {
+		if !isDirection {
+			return invalid(fmt.Sprintf("direction %q must be ASC or DESC", last))
+		}
+		tokens = tokens[:len(tokens)-1]
+	}
+	for _, token := range tokens {
+		switch {
+		case token == "":
+			return invalid("empty field")
+		case strings.EqualFold(token, "ASC") || strings.EqualFold(token, "DESC"):
+			return invalid(fmt.Sprintf("direction %q must come after the fields", token))
+		case !pageSortFieldPattern.MatchString(token):
+			return invalid(fmt.Sprintf("field %q is not a valid field name", token))
+		}
+	}
+	return nil
+}
+
+// MaxDefaultPageSize is the largest page size accepted as the configured default page size
+const MaxDefaultPageSize = 300
+
+// PaginationDefaults holds the default page size and sort orders configured for the server.
+// Empty sort orders keep the built-in defaults.
+type PaginationDefaults struct {
+	PageSize           int
+	SortingForLaunches string
+	SortingForItems    string
+	SortingForSuites   string
+	SortingForLogs     string
+}
+
+// Pagination holds the page size and sort orders applied when a tool call omits page-size or
+// page-sort. The zero value uses the built-in DefaultPageSize and DefaultSortingFor* values.
+type Pagination struct {
+	pageSize   uint
+	sortOrders map[string]string // Keyed by the built-in default sort order they replace
+}
+
+// NewPagination validates the pagination defaults configured for the server
+func NewPagination(defaults PaginationDefaults) (Pagination, error) {
+	if defaults.PageSize < 1 || defaults.PageSize > MaxDefaultPageSize {
+		return Pagination{}, fmt.Errorf(
+			"invalid default page size %d: must be between 1 and %d",
+			defaults.PageSize,
+			MaxDefaultPageSize,
+		)
+	}
+
+	sortOrders := map[string]string{}
+	for builtin, sortOrder := range map[string]string{
+		DefaultSortingForLaunches: defaults.SortingForLaunches,
+		DefaultSortingForItems:    defaults.SortingForItems,
+		DefaultSortingForSuites:   defaults.SortingForSuites,
+		DefaultSortingForLogs:     defaults.SortingForLogs,
+	} {
+		sortOrder = strings.TrimSpace(sortOrder)
+		if sortOrder == "" {
+			continue
+		}
+		if err := validatePageSort(sortOrder); err != nil {
+			return Pagination{}, fmt.Errorf("invalid default sort order: %w", err)
+		}
+		sortOrders[builtin] = sortOrder
+	}
+
+	return Pagination{pageSize: uint(defaults.PageSize), sortOrders: sortOrders}, nil
+}
+
+// PageSize returns the page size used when a tool call does not specify one
+func (p Pagination) PageSize() uint {
+	if p.pageSize == 0 {
+		return DefaultPageSize
+	}
+	return p.pageSize
+}
+
+// Sort returns the sort order used in place of the given built-in default sort order
+func (p Pagination) Sort(builtinSort string) string {
+	if sortOrder, ok := p.sortOrders[builtinSort]; ok {
+		return sortOrder
+	}
+	return builtinSort
+}
+
+// Built-in caps of fetch_all pagination (see FetchAllLimits)
+const (
+	DefaultFetchAllMaxPages        = 20
+	DefaultFetchAllMaxTotalResults = 5000
+)
+
+// FetchAllLimits caps fetch_all pagination: the number of pages read and the number of results
+// collected by one tool call. The zero value uses the built-in DefaultFetchAll* caps.
+type FetchAllLimits struct {
+	maxPages        int
+	maxTotalResults int
+}
+
+// NewFetchAllLimits validates the fetch_all caps configured for the server
+func NewFetchAllLimits(maxPages, maxTotalResults int) (FetchAllLimits, error) {
+	if maxPages < 1 {
+		return FetchAllLimits{}, fmt.Errorf("invalid max pages %d: must be at least 1", maxPages)
+	}
+	if maxTotalResults < 1 {
+		return FetchAllLimits{}, fmt.Errorf(
+			"invalid max total results %d: must be at least 1",
+			maxTotalResults,
+		)
+	}
+	return FetchAllLimits{maxPages: maxPages, maxTotalResults: maxTotalResults}, nil
+}
+
+// MaxPages returns the maximum number of pages read by fetch_all
+func (l FetchAllLimits) MaxPages() int {
+	if l.maxPages == 0 {
+		return DefaultFetchAllMaxPages
+	}
+	return l.maxPages
+}
+
+// MaxTotalResults returns the maximum number of results collected by fetch_all
+func (l FetchAllLimits) MaxTotalResults() int {
+	if l.maxTotalResults == 0 {
+		return DefaultFetchAllMaxTotalResults
+	}
+	return l.maxTotalResults
+}
+
 // ApplyPaginationOptions applies pagination to an API request from typed values.
-// Zero values for page and pageSize fall back to defaults.
+// Zero values for page and pageSize fall back to the defaults of pagination, as does
+// an empty pageSort. A malformed pageSort is reported as an error instead of being
+// passed on to ReportPortal.
 func ApplyPaginationOptions[T PaginatedRequest[T]](
 	apiRequest T,
+	pagination Pagination,
 	page, pageSize uint,
 	pageSort, defaultSort string,
-) T {
+) (T, error) {
 	if page < FirstPage {
 		page = FirstPage
 	} else if page > math.MaxInt32 {
@@ -63,19 +221,22 @@ func ApplyPaginationOptions[T PaginatedRequest[T]](
 	}
 
 	if pageSize <= 0 {
-		pageSize = DefaultPageSize
+		pageSize = pagination.PageSize()
 	} else if pageSize > math.MaxInt32 {
 		pageSize = math.MaxInt32
 	}
 
 	if pageSort == "" {
-		pageSort = defaultSort
+		pageSort = pagination.Sort(defaultSort)
+	}
+	if err := validatePageSort(pageSort); err != nil {
+		return apiRequest, err
 	}
 
 	return apiRequest.
 		PagePage(int32(page)).     //nolint:gosec
 		PageSize(int32(pageSize)). //nolint:gosec
-		PageSort(pageSort)
+		PageSort(pageSort), nil
 }
 
 // LimitSchema returns the JSON schema for the "limit" pagination parameter.
@@ -92,6 +253,23 @@ func LimitSchema(defaultLimit uint) *jsonschema.Schema {
 	}
 }
 
+// SetTimeWindowProperties adds the "last_hours" and "last_days" relative start time
+// window parameters to the given schema properties.
+func SetTimeWindowProperties(properties map[string]*jsonschema.Schema) {
+	properties["last_hours"] = &jsonschema.Schema{
+		Type:        "integer",
+		Description: "Only include results started within the last N hours. Cannot be combined with last_days or explicit start time bounds",
+		Minimum:     openapi.PtrFloat64(1),
+		Maximum:     openapi.PtrFloat64(MaxTimeWindowHours),
+	}
+	properties["last_days"] = &jsonschema.Schema{
+		Type:        "integer",
+		Description: "Only include results started within the last N days. Cannot be combined with last_hours or explicit start time bounds",
+		Minimum:     openapi.PtrFloat64(1),
+		Maximum:     openapi.PtrFloat64(MaxTimeWindowDays),
+	}
+}
+
 // OffsetSchema returns the JSON schema for the "offset" pagination parameter.
 func OffsetSchema() *jsonschema.Schema {
 	return &jsonschema.Schema{
@@ -143,6 +321,32 @@ func ExtractProject(ctx context.Context, projectArg string) (string, error) {
 	)
 }
 
+// DryRunSchema returns the JSON schema for the "dry_run" parameter of write tools.
+func DryRunSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "boolean",
+		Description: "Validate the input and describe the intended change without applying it. " +
+			"Default: false",
+		Default: json.RawMessage("false"),
+	}
+}
+
+// DryRunResult builds the result returned by a write tool called with dry_run: true.
+// change describes the targets and the operation the tool would have performed.
+func DryRunResult(toolName string, change any) (*mcp.CallToolResult, any, error) {
+	r, err := json.Marshal(map[string]any{
+		"dry_run": true,
+		"tool":    toolName,
+		"change":  change,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal dry run result: %w", err)
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(r)}},
+	}, nil, nil
+}
+
 // EventTracker interface for analytics tracking
 type EventTracker interface {
 	TrackMCPEvent(ctx context.Context, toolName string)
@@ -155,13 +359,24 @@ func WithAnalytics[In any](
 	handler func(context.Context, *mcp.CallToolRequest, In) (*mcp.CallToolResult, any, error),
 ) func(context.Context, *mcp.CallToolRequest, In) (*mcp.CallToolResult, any, error) {
 	return func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
+		// Do not start work for a client that has already gone away
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
 		// Track the event before executing the tool (synchronous since it's just incrementing a counter)
 		if tracker != nil {
 			tracker.TrackMCPEvent(ctx, toolName)
 		}
 
 		// Execute the original handler
-		return handler(ctx, req, args)
+		result, out, err := handler(ctx, req, args)
+
+		// Surface cancellation as a context error even if the handler wrapped it away
+		if ctxErr := ctx.Err(); err != nil && ctxErr != nil && !errors.Is(err, ctxErr) {
+			err = fmt.Errorf("%w: %w", ctxErr, err)
+		}
+		return result, out, err
 	}
 }
 
@@ -63,3 +63,179 @@ func TestApplyLimitOffset_DefaultLimitOffsetConstant(t *testing.T) {
 	ApplyLimitOffset(q, 0, 0, DefaultLimitOffset)
 	require.Equal(t, "50", q.Get("limit"))
 }
+
+// fakePaginatedRequest records the pagination options applied to it
+type fakePaginatedRequest struct {
+	page, size int32
+	sort       string
+}
+
+func (r fakePaginatedRequest) PagePage(page int32) fakePaginatedRequest {
+	r.page = page
+	return r
+}
+
+func (r fakePaginatedRequest) PageSize(size int32) fakePaginatedRequest {
+	r.size = size
+	return r
+}
+
+func (r fakePaginatedRequest) PageSort(sort string) fakePaginatedRequest {
+	r.sort = sort
+	return r
+}
+
+func TestApplyPaginationOptions_ValidSort(t *testing.T) {
+	for _, sort := range []string{
+		"startTime,number,DESC",
+		"name",
+		"name,ASC",
+		"user, ASC",
+		"statistics$executions$total,DESC",
+		"startTime,desc",
+		"name,asc",
+		"number, Desc",
+		"name,aSc",
+	} {
+		t.Run(sort, func(t *testing.T) {
+			req, err := ApplyPaginationOptions(
+				fakePaginatedRequest{}, Pagination{}, 2, 10, sort, DefaultSortingForItems,
+			)
+			require.NoError(t, err)
+			require.Equal(t, fakePaginatedRequest{page: 2, size: 10, sort: sort}, req)
+		})
+	}
+}
+
+func TestApplyPaginationOptions_DefaultSort(t *testing.T) {
+	req, err := ApplyPaginationOptions(
+		fakePaginatedRequest{}, Pagination{}, 0, 0, "", DefaultSortingForLaunches,
+	)
+	require.NoError(t, err)
+	require.Equal(t, fakePaginatedRequest{
+		page: FirstPage,
+		size: DefaultPageSize,
+		sort: DefaultSortingForLaunches,
+	}, req)
+}
+
+func TestApplyPaginationOptions_InvalidSort(t *testing.T) {
+	tests := []struct {
+		sort    string
+		wantErr string
+	}{
+		{sort: "startTime,DSC", wantErr: `direction "DSC" must be ASC or DESC`},
+		{sort: "DESC,startTime", wantErr: `direction "DESC" must come after the fields`},
+		{sort: "desc,startTime", wantErr: `direction "desc" must come after the fields`},
+		{sort: "startTime,,DESC", wantErr: "empty field"},
+		{sort: ",", wantErr: "empty field"},
+		{sort: "start time,DESC", wantErr: `field "start time" is not a valid field name`},
+		{sort: "startTime;DESC", wantErr: `field "startTime;DESC" is not a valid field name`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			_, err := ApplyPaginationOptions(
+				fakePaginatedRequest{}, Pagination{}, 1, 10, tt.sort, DefaultSortingForItems,
+			)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+			require.Contains(t, err.Error(), "field[,field...][,ASC|DESC]")
+		})
+	}
+}
+
+func TestApplyPaginationOptions_ConfiguredDefaults(t *testing.T) {
+	pagination, err := NewPagination(PaginationDefaults{
+		PageSize:           20,
+		SortingForLaunches: "number,DESC",
+	})
+	require.NoError(t, err)
+
+	t.Run("configured defaults apply when omitted", func(t *testing.T) {
+		req, err := ApplyPaginationOptions(
+			fakePaginatedRequest{}, pagination, 0, 0, "", DefaultSortingForLaunches,
+		)
+		require.NoError(t, err)
+		require.Equal(t, fakePaginatedRequest{page: FirstPage, size: 20, sort: "number,DESC"}, req)
+	})
+
+	t.Run("explicit values take precedence", func(t *testing.T) {
+		req, err := ApplyPaginationOptions(
+			fakePaginatedRequest{}, pagination, 1, 5, "name,ASC", DefaultSortingForLaunches,
+		)
+		require.NoError(t, err)
+		require.Equal(t, fakePaginatedRequest{page: 1, size: 5, sort: "name,ASC"}, req)
+	})
+
+	t.Run("unset sort orders keep built-in defaults", func(t *testing.T) {
+		req, err := ApplyPaginationOptions(
+			fakePaginatedRequest{}, pagination, 0, 0, "", DefaultSortingForLogs,
+		)
+		require.NoError(t, err)
+		require.Equal(t, DefaultSortingForLogs, req.sort)
+	})
+
+	t.Run("schema defaults follow configuration", func(t *testing.T) {
+		props := SetPaginationProperties(pagination, DefaultSortingForLaunches)
+		require.JSONEq(t, "20", string(props["page-size"].Default))
+		require.JSONEq(t, `"number,DESC"`, string(props["page-sort"].Default))
+	})
+}
+
+func TestNewPagination_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults PaginationDefaults
+		wantErr  string
+	}{
+		{
+			name:     "zero page size",
+			defaults: PaginationDefaults{PageSize: 0},
+			wantErr:  "must be between 1 and 300",
+		},
+		{
+			name:     "page size above max",
+			defaults: PaginationDefaults{PageSize: MaxDefaultPageSize + 1},
+			wantErr:  "must be between 1 and 300",
+		},
+		{
+			name:     "malformed sort",
+			defaults: PaginationDefaults{PageSize: 10, SortingForItems: "startTime,DSC"},
+			wantErr:  `direction "DSC" must be ASC or DESC`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPagination(tt.defaults)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNewPagination_Bounds(t *testing.T) {
+	for _, size := range []int{1, MaxDefaultPageSize} {
+		pagination, err := NewPagination(PaginationDefaults{PageSize: size})
+		require.NoError(t, err)
+		require.Equal(t, uint(size), pagination.PageSize())
+	}
+
+	// The zero value keeps the built-in defaults
+	require.Equal(t, uint(DefaultPageSize), Pagination{}.PageSize())
+	require.Equal(t, DefaultSortingForItems, Pagination{}.Sort(DefaultSortingForItems))
+}
+
+func TestNewFetchAllLimits(t *testing.T) {
+	limits, err := NewFetchAllLimits(3, 100)
+	require.NoError(t, err)
+	require.Equal(t, 3, limits.MaxPages())
+	require.Equal(t, 100, limits.MaxTotalResults())
+
+	_, err = NewFetchAllLimits(0, 100)
+	require.ErrorContains(t, err, "invalid max pages 0")
+	_, err = NewFetchAllLimits(3, -1)
+	require.ErrorContains(t, err, "invalid max total results -1")
+
+	// The zero value keeps the built-in caps
+	require.Equal(t, DefaultFetchAllMaxPages, FetchAllLimits{}.MaxPages())
+	require.Equal(t, DefaultFetchAllMaxTotalResults, FetchAllLimits{}.MaxTotalResults())
+}
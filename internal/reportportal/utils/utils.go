@@ -1,15 +1,20 @@
 package utils
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
+	"mime"
 	"net"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -30,7 +35,8 @@ const (
 	DefaultFilterInType        = "STEP"
 	DefaultFilterInTypeSuites  = "SUITE,TEST"
 	AllFilterInTypes           = "BEFORE_SUITE,BEFORE_GROUPS,BEFORE_CLASS,BEFORE_TEST,TEST,BEFORE_METHOD,STEP,AFTER_METHOD,AFTER_TEST,AFTER_CLASS,AFTER_GROUPS,AFTER_SUITE"
-	DefaultItemLogLevel        = "TRACE" // Default log level for test item logs
+	DefaultItemLogLevel        = "TRACE"       // Default log level for test item logs
+	StatusInProgress           = "IN_PROGRESS" // status of launches and items that are still running
 	// defaultLaunchesLimitForFilterProvider is the launchesLimit query value when providerType is filter
 	// and launches-limit is omitted or zero (matches JSON schema default).
 	DefaultLaunchesLimitForFilterProvider uint32 = 600
@@ -44,9 +50,13 @@ type PaginatedRequest[T any] interface {
 }
 
 // SetPaginationProperties returns the standard pagination properties for JSON Schema.
-func SetPaginationProperties(sortingParams string) map[string]*jsonschema.Schema {
+// The defaults of page-size and page-sort follow the given pagination defaults.
+func SetPaginationProperties(
+	pagination Pagination,
+	sortingParams string,
+) map[string]*jsonschema.Schema {
 	// Helper to create JSON default values
-	intDefault := func(v int) json.RawMessage {
+	intDefault := func(v uint) json.RawMessage {
 		b, _ := json.Marshal(v)
 		return b
 	}
@@ -64,16 +74,38 @@ func SetPaginationProperties(sortingParams string) map[string]*jsonschema.Schema
 		"page-size": {
 			Type:        "integer",
 			Description: "Page size",
-			Default:     intDefault(DefaultPageSize),
+			Default:     intDefault(pagination.PageSize()),
 		},
 		"page-sort": {
 			Type:        "string",
-			Description: "Sorting fields and direction",
-			Default:     stringDefault(sortingParams),
+			Description: "Sorting fields and direction: field[,field...][,ASC|DESC]",
+			Default:     stringDefault(pagination.Sort(sortingParams)),
 		},
 	}
 }
 
+// isHTMLResponse reports whether a response body is an HTML page rather than a ReportPortal API
+// response, judging by its content type or, when that is missing, by the body itself
+func isHTMLResponse(contentType string, body []byte) bool {
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			mediaType = strings.TrimSpace(strings.ToLower(contentType))
+		}
+		return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+	}
+	start := strings.ToLower(string(bytes.TrimSpace(body[:min(len(body), 64)])))
+	return strings.HasPrefix(start, "<!doctype html") || strings.HasPrefix(start, "<html")
+}
+
+// nonJSONResponseMessage describes an HTML page returned instead of an API response, typically
+// by a reverse proxy or gateway in front of ReportPortal (e.g. on 502 or 504)
+func nonJSONResponseMessage(statusCode int, kind string) string {
+	return fmt.Sprintf("ReportPortal gateway returned a non-JSON %s (status %d)", kind, statusCode)
+}
+
+// ExtractResponseError describes a failed ReportPortal request: the client error followed by the
+// response body. HTML error pages are replaced by a short message.
 func ExtractResponseError(err error, rs *http.Response) (errText string) {
 	errText = err.Error()
 	if rs != nil && rs.Body != nil {
@@ -93,6 +125,10 @@ func ExtractResponseError(err error, rs *http.Response) (errText string) {
 		}()
 
 		if errContent, rErr := io.ReadAll(rs.Body); rErr == nil {
+			if isHTMLResponse(rs.Header.Get("Content-Type"), errContent) {
+				// Error pages of proxies in front of ReportPortal are of no use to the client
+				return nonJSONResponseMessage(rs.StatusCode, "error")
+			}
 			errText = errText + ": " + string(errContent)
 		} else {
 			errText = errText + " (read error: " + rErr.Error() + ")"
@@ -101,30 +137,56 @@ func ExtractResponseError(err error, rs *http.Response) (errText string) {
 	return errText
 }
 
+// Thresholds used to tell Unix epoch seconds from milliseconds when no unit suffix is given
+const (
+	maxEpochSeconds = 10000000000    // year 2286 in seconds; larger values are treated as milliseconds
+	maxEpochMillis  = 32503680000000 // roughly year 3000 in milliseconds
+)
+
 // Helper function to parse timestamp to Unix epoch
+//
+// Accepted inputs:
+//   - Unix epoch seconds or milliseconds, disambiguated by magnitude (see maxEpochSeconds)
+//   - Unix epoch with an explicit unit suffix, e.g. "1704110400s" or "1704110400000ms"
+//   - RFC3339 with optional sub-second precision and any offset, e.g. "2024-01-01T12:00:00.123+05:30"
+//   - ISO8601 with a colon-less offset, e.g. "2024-01-01T12:00:00-0500"
+//   - Timezone-less date/time formats (assumed UTC)
 func parseTimestampToEpoch(timestampStr string) (int64, error) {
+	timestampStr = strings.TrimSpace(timestampStr)
 	if timestampStr == "" {
 		return 0, fmt.Errorf("empty timestamp")
 	}
+	// Explicit unit suffixes remove the seconds/milliseconds ambiguity
+	if digits, ok := strings.CutSuffix(timestampStr, "ms"); ok {
+		epoch, err := strconv.ParseInt(digits, 10, 64)
+		if err != nil || epoch <= 0 || epoch >= maxEpochMillis {
+			return 0, fmt.Errorf("invalid millisecond epoch: %s", timestampStr)
+		}
+		return epoch, nil
+	}
+	if digits, ok := strings.CutSuffix(timestampStr, "s"); ok {
+		epoch, err := strconv.ParseInt(digits, 10, 64)
+		if err != nil || epoch <= 0 || epoch >= maxEpochMillis/1000 {
+			return 0, fmt.Errorf("invalid second epoch: %s", timestampStr)
+		}
+		return epoch * 1000, nil
+	}
 	// Try parsing as Unix epoch first (if it's all digits)
 	if epoch, err := strconv.ParseInt(timestampStr, 10, 64); err == nil {
 		// If it's a valid Unix timestamp (positive value)
 		if epoch > 0 {
 			// If it looks like seconds, convert to milliseconds
-			if epoch < 10000000000 { // less than year 2286 in seconds
+			if epoch < maxEpochSeconds {
 				return epoch * 1000, nil
 			}
 			// Assume milliseconds for larger values
-			if epoch < 32503680000000 { // roughly year 3000 in milliseconds
+			if epoch < maxEpochMillis {
 				return epoch, nil
 			}
 		}
 	}
-	// Try parsing as RFC3339 format (offset with colon, e.g. +00:00 or Z)
-	if t, err := time.Parse(time.RFC3339, timestampStr); err == nil {
-		return t.UnixMilli(), nil
-	}
-	// Try parsing as RFC3339Nano (RFC3339 with sub-second precision)
+	// Try parsing as RFC3339Nano: RFC3339 with optional sub-second precision and an offset
+	// with colon (e.g. +05:30) or Z. It also accepts plain RFC3339 values.
 	if t, err := time.Parse(time.RFC3339Nano, timestampStr); err == nil {
 		return t.UnixMilli(), nil
 	}
@@ -132,10 +194,13 @@ func parseTimestampToEpoch(timestampStr string) (int64, error) {
 	formats := []string{
 		// ISO8601 with numeric timezone offset but no colon (e.g. +0000, -0500)
 		// Go's RFC3339 requires a colon; these handle the colon-less variant.
-		"2006-01-02T15:04:05.999-0700",
+		"2006-01-02T15:04:05.999999999-0700",
 		"2006-01-02T15:04:05-0700",
+		// Space-separated date and time with an offset
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05-0700",
 		// Timezone-less formats (assumed UTC)
-		"2006-01-02T15:04:05",
+		"2006-01-02T15:04:05.999999999",
 		"2006-01-02 15:04:05",
 		"2006-01-02",
 	}
@@ -178,6 +243,48 @@ func ProcessStartTimeFilter(filterStartTimeFrom, filterStartTimeTo string) (stri
 	return filterStartTime, nil
 }
 
+// Upper bounds of the relative start time window; larger values would overflow time.Duration
+const (
+	MaxTimeWindowDays  = 3650
+	MaxTimeWindowHours = MaxTimeWindowDays * 24
+)
+
+// ProcessStartTimeWindow returns the start time filter built either from the explicit
+// from/to interval or from a relative window ending now (lastHours or lastDays).
+// Combining the explicit interval with a relative window, or both windows, is rejected.
+func ProcessStartTimeWindow(
+	filterStartTimeFrom, filterStartTimeTo string,
+	lastHours, lastDays uint,
+) (string, error) {
+	if lastHours == 0 && lastDays == 0 {
+		return ProcessStartTimeFilter(filterStartTimeFrom, filterStartTimeTo)
+	}
+	if lastHours > 0 && lastDays > 0 {
+		return "", fmt.Errorf("last_hours and last_days cannot be used together")
+	}
+	if filterStartTimeFrom != "" || filterStartTimeTo != "" {
+		return "", fmt.Errorf(
+			"last_hours/last_days cannot be combined with explicit start time from/to timestamps",
+		)
+	}
+	if lastHours > MaxTimeWindowHours {
+		return "", fmt.Errorf("last_hours must not exceed %d", MaxTimeWindowHours)
+	}
+	if lastDays > MaxTimeWindowDays {
+		return "", fmt.Errorf("last_days must not exceed %d", MaxTimeWindowDays)
+	}
+
+	window := time.Duration(lastHours) * time.Hour
+	if lastDays > 0 {
+		window = time.Duration(lastDays) * 24 * time.Hour
+	}
+	now := time.Now()
+	return ProcessStartTimeFilter(
+		strconv.FormatInt(now.Add(-window).UnixMilli(), 10),
+		strconv.FormatInt(now.UnixMilli(), 10),
+	)
+}
+
 // ProcessAttributeKeys processes attribute keys by adding ":" suffix where needed
 // and combines them with existing attributes.
 func ProcessAttributeKeys(filterAttributes, filterAttributeKeys string) string {
@@ -210,6 +317,41 @@ func ProcessAttributeKeys(filterAttributes, filterAttributeKeys string) string {
 	return result
 }
 
+// compositeAttributeFormat describes the expected composite attribute filter format in errors
+const compositeAttributeFormat = "comma-separated key:value, key: or value segments without spaces, " +
+	"e.g. demo,platform:ios,build:"
+
+// ValidateCompositeAttribute checks a composite attribute filter value (e.g. the
+// filter-has-compositeAttribute parameter) before it is sent to ReportPortal. Each comma-separated
+// segment must be a value, a key:value pair or a key followed by ":" (any attribute value);
+// spaces, empty segments and segments with an empty key or more than one ":" are rejected.
+// field names the parameter in the returned error.
+func ValidateCompositeAttribute(field, value string) error {
+	if value == "" {
+		return nil
+	}
+	for i, segment := range strings.Split(value, ",") {
+		var problem string
+		switch {
+		case segment == "":
+			problem = "is empty"
+		case strings.ContainsFunc(segment, unicode.IsSpace):
+			problem = "contains whitespace"
+		case strings.Count(segment, ":") > 1:
+			problem = "contains more than one ':'"
+		case strings.HasPrefix(segment, ":"):
+			problem = "has an empty key"
+		}
+		if problem != "" {
+			return fmt.Errorf(
+				"invalid %s %q: segment %d (%q) %s; expected %s",
+				field, value, i+1, segment, problem, compositeAttributeFormat,
+			)
+		}
+	}
+	return nil
+}
+
 func IsTextContent(mediaType string) bool {
 	lowerType := strings.ToLower(mediaType)
 
@@ -232,6 +374,42 @@ func IsTextContent(mediaType string) bool {
 	return false
 }
 
+// MaxBinaryContentSizeBytes is the upper bound on binary payloads (reports, archives)
+// returned inline to the MCP client. Larger payloads are rejected instead of being
+// base64-encoded into the tool result.
+const MaxBinaryContentSizeBytes = 50 * 1024 * 1024 // 50 MiB
+
+// BlobResourceResult wraps downloaded content into an MCP tool result carrying an embedded
+// resource. Text-based media types (see IsTextContent) are returned as text resource contents,
+// everything else as blob resource contents. Content above MaxBinaryContentSizeBytes is rejected.
+func BlobResourceResult(uri, contentType string, body []byte) (*mcp.CallToolResult, error) {
+	if len(body) > MaxBinaryContentSizeBytes {
+		return nil, fmt.Errorf(
+			"content size %d bytes exceeds the maximum of %d bytes",
+			len(body),
+			MaxBinaryContentSizeBytes,
+		)
+	}
+
+	resource := &mcp.ResourceContents{
+		URI:      uri,
+		MIMEType: contentType,
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+	if IsTextContent(mediaType) {
+		resource.Text = string(body)
+	} else {
+		resource.Blob = body
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.EmbeddedResource{Resource: resource}},
+	}, nil
+}
+
 // isAlreadyClosedError checks if the error indicates that the response body is already closed.
 // This helps avoid unnecessary error logging when closing an already-closed body.
 func isAlreadyClosedError(err error) bool {
@@ -247,6 +425,23 @@ func isAlreadyClosedError(err error) bool {
 		strings.Contains(errStr, "connection closed")
 }
 
+// ReadAllContext reads r until EOF like io.ReadAll, but returns ctx.Err() as soon as ctx is done.
+// r is closed on cancellation, which unblocks a read stuck on the network; the read itself runs on
+// the calling goroutine, so nothing outlives the call.
+func ReadAllContext(ctx context.Context, r io.ReadCloser) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	stop := context.AfterFunc(ctx, func() { _ = r.Close() })
+	data, err := io.ReadAll(r)
+	if !stop() {
+		// The body was closed because ctx is done; the read error only reflects that
+		return nil, ctx.Err()
+	}
+	return data, err
+}
+
 // readResponseBodyRaw safely reads an HTTP response body and ensures proper cleanup.
 // It returns the raw body bytes along with any error, suitable for custom content type handling.
 func ReadResponseBodyRaw(response *http.Response) ([]byte, error) {
@@ -264,15 +459,45 @@ func ReadResponseBodyRaw(response *http.Response) ([]byte, error) {
 		}
 	}()
 
-	// Read the response body
+	// Read the response body. The generated API client has already buffered it, and the context of
+	// response.Request is cancelled by http.Client once that happened when a client timeout is set,
+	// so it must not be used to abort this read.
 	rawBody, err := io.ReadAll(response.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %v", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// http.Transport transparently decompresses gzip only when it requested it itself.
+	// Decode bodies that are still gzip-encoded (e.g. compressed by a proxy unasked).
+	if !response.Uncompressed &&
+		strings.EqualFold(strings.TrimSpace(response.Header.Get("Content-Encoding")), "gzip") {
+		return decompressGzip(rawBody)
 	}
 
 	return rawBody, nil
 }
 
+// decompressGzip decodes a gzip-encoded body, bounded by MaxBinaryContentSizeBytes
+func decompressGzip(body []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode gzip response body: %w", err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	decoded, err := io.ReadAll(io.LimitReader(zr, MaxBinaryContentSizeBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode gzip response body: %w", err)
+	}
+	if len(decoded) > MaxBinaryContentSizeBytes {
+		return nil, fmt.Errorf(
+			"decompressed response body exceeds the %d bytes limit",
+			MaxBinaryContentSizeBytes,
+		)
+	}
+	return decoded, nil
+}
+
 // ReadResponseBody safely reads an HTTP response body and returns the result as an MCP tool result.
 //
 // IMPORTANT CONTRACT: This function encodes all read/processing failures in the returned
@@ -296,6 +521,15 @@ func ReadResponseBody(response *http.Response) (*mcp.CallToolResult, any, error)
 		}, nil, nil
 	}
 
+	if isHTMLResponse(response.Header.Get("Content-Type"), rawBody) {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: nonJSONResponseMessage(response.StatusCode, "response")},
+			},
+			IsError: true,
+		}, nil, nil
+	}
+
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{&mcp.TextContent{Text: string(rawBody)}},
 	}, nil, nil
@@ -380,3 +614,41 @@ func NewBaseTransport() *http.Transport {
 		ExpectContinueTimeout: 1 * time.Second,
 	}
 }
+
+// UserAgentProduct is the product token sent in the User-Agent header of outbound ReportPortal requests
+const UserAgentProduct = "reportportal-mcp-server"
+
+// BuildUserAgent returns the User-Agent for outbound ReportPortal requests in the form
+// "reportportal-mcp-server/<version>", followed by suffix (separated by a space) when set.
+func BuildUserAgent(version, suffix string) string {
+	userAgent := UserAgentProduct + "/" + version
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		userAgent += " " + suffix
+	}
+	return userAgent
+}
+
+// userAgentTransport sets a fixed User-Agent header on every outbound request
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the caller's request
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
+
+// WithUserAgent wraps base so that every request carries the given User-Agent header.
+// A nil base uses http.DefaultTransport; an empty userAgent returns base unchanged.
+func WithUserAgent(base http.RoundTripper, userAgent string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if userAgent == "" {
+		return base
+	}
+	return &userAgentTransport{base: base, userAgent: userAgent}
+}
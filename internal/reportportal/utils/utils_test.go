@@ -1,9 +1,19 @@
 package utils
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 func ms(layout, value string) int64 {
@@ -88,7 +98,75 @@ func TestParseTimestampToEpoch(t *testing.T) {
 			input:  "2024-01-01",
 			wantMs: ms("2006-01-02", "2024-01-01"),
 		},
+		// Boundary between seconds and milliseconds (year 2286 in seconds)
+		{
+			name:   "largest value treated as seconds",
+			input:  "9999999999",
+			wantMs: 9999999999 * 1000,
+		},
+		{
+			name:   "smallest value treated as milliseconds",
+			input:  "10000000000",
+			wantMs: 10000000000,
+		},
+		{
+			name:   "largest accepted milliseconds",
+			input:  "32503679999999",
+			wantMs: 32503679999999,
+		},
+		// Explicit epoch units
+		{
+			name:   "explicit milliseconds below seconds threshold",
+			input:  "1704110400ms",
+			wantMs: 1704110400,
+		},
+		{
+			name:   "explicit milliseconds",
+			input:  "1704110400000ms",
+			wantMs: 1704110400000,
+		},
+		{
+			name:   "explicit seconds above seconds threshold",
+			input:  "10000000000s",
+			wantMs: 10000000000 * 1000,
+		},
+		// Timezone-aware formats
+		{
+			name:   "RFC3339 nanoseconds with offset",
+			input:  "2024-01-01T12:00:00.123456789+02:00",
+			wantMs: ms(time.RFC3339Nano, "2024-01-01T12:00:00.123456789+02:00"),
+		},
+		{
+			name:   "RFC3339 negative offset",
+			input:  "2024-01-01T12:00:00-08:00",
+			wantMs: ms("2006-01-02T15:04:05Z07:00", "2024-01-01T12:00:00-08:00"),
+		},
+		{
+			name:   "space separator with offset",
+			input:  "2024-01-01 12:00:00+02:00",
+			wantMs: ms(time.RFC3339, "2024-01-01T12:00:00+02:00"),
+		},
+		{
+			name:   "surrounding whitespace",
+			input:  " 2024-01-01T12:00:00Z ",
+			wantMs: ms(time.RFC3339, "2024-01-01T12:00:00Z"),
+		},
 		// Error cases
+		{
+			name:      "milliseconds beyond year 3000",
+			input:     "32503680000000",
+			wantError: true,
+		},
+		{
+			name:      "invalid explicit milliseconds",
+			input:     "abcms",
+			wantError: true,
+		},
+		{
+			name:      "zero explicit seconds",
+			input:     "0s",
+			wantError: true,
+		},
 		{
 			name:      "empty string",
 			input:     "",
@@ -126,6 +204,213 @@ func TestParseTimestampToEpoch(t *testing.T) {
 	}
 }
 
+func TestProcessStartTimeWindow(t *testing.T) {
+	tests := []struct {
+		name       string
+		from, to   string
+		lastHours  uint
+		lastDays   uint
+		wantWindow time.Duration
+		wantFilter string
+		wantError  bool
+	}{
+		{
+			name:       "explicit interval",
+			from:       "1704110400",
+			to:         "1704196800",
+			wantFilter: "1704110400000,1704196800000",
+		},
+		{name: "no filter"},
+		{name: "last hours", lastHours: 6, wantWindow: 6 * time.Hour},
+		{name: "last days", lastDays: 2, wantWindow: 48 * time.Hour},
+		{name: "hours and days", lastHours: 1, lastDays: 1, wantError: true},
+		{name: "window with from", from: "1704110400", lastHours: 1, wantError: true},
+		{name: "window with to", to: "1704110400", lastDays: 1, wantError: true},
+		{name: "max days", lastDays: MaxTimeWindowDays, wantWindow: MaxTimeWindowDays * 24 * time.Hour},
+		{name: "too many days", lastDays: MaxTimeWindowDays + 1, wantError: true},
+		{name: "overflowing days", lastDays: math.MaxUint32, wantError: true},
+		{name: "too many hours", lastHours: MaxTimeWindowHours + 1, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := time.Now().UnixMilli()
+			got, err := ProcessStartTimeWindow(tt.from, tt.to, tt.lastHours, tt.lastDays)
+			after := time.Now().UnixMilli()
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("expected error, got nil (result=%q)", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantWindow == 0 {
+				if got != tt.wantFilter {
+					t.Errorf("got %q, want %q", got, tt.wantFilter)
+				}
+				return
+			}
+
+			parts := strings.Split(got, ",")
+			if len(parts) != 2 {
+				t.Fatalf("expected from,to pair, got %q", got)
+			}
+			from, err := strconv.ParseInt(parts[0], 10, 64)
+			if err != nil {
+				t.Fatalf("invalid from value %q: %v", parts[0], err)
+			}
+			to, err := strconv.ParseInt(parts[1], 10, 64)
+			if err != nil {
+				t.Fatalf("invalid to value %q: %v", parts[1], err)
+			}
+			if to < before || to > after {
+				t.Errorf("to=%d is outside of [%d, %d]", to, before, after)
+			}
+			if to-from != tt.wantWindow.Milliseconds() {
+				t.Errorf("window = %d ms, want %d ms", to-from, tt.wantWindow.Milliseconds())
+			}
+		})
+	}
+}
+
+func TestReadResponseBodyRaw_Gzip(t *testing.T) {
+	const body = `{"status":"ok"}`
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	_, _ = zw.Write([]byte(body))
+	_ = zw.Close()
+
+	tests := []struct {
+		name     string
+		raw      []byte
+		encoding string
+		// uncompressed mirrors http.Response.Uncompressed set by the transport
+		uncompressed bool
+	}{
+		{name: "plain body", raw: []byte(body)},
+		{name: "gzip body", raw: compressed.Bytes(), encoding: "gzip"},
+		{name: "gzip body with mixed case encoding", raw: compressed.Bytes(), encoding: "GZIP"},
+		{name: "already decoded by transport", raw: []byte(body), encoding: "gzip", uncompressed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				Header:       http.Header{},
+				Body:         io.NopCloser(bytes.NewReader(tt.raw)),
+				Uncompressed: tt.uncompressed,
+			}
+			if tt.encoding != "" {
+				resp.Header.Set("Content-Encoding", tt.encoding)
+			}
+
+			got, err := ReadResponseBodyRaw(resp)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != body {
+				t.Errorf("got %q, want %q", got, body)
+			}
+		})
+	}
+
+	t.Run("corrupt gzip body", func(t *testing.T) {
+		resp := &http.Response{
+			Header: http.Header{"Content-Encoding": []string{"gzip"}},
+			Body:   io.NopCloser(strings.NewReader("not gzip")),
+		}
+		if _, err := ReadResponseBodyRaw(resp); err == nil {
+			t.Error("expected error for corrupt gzip body")
+		}
+	})
+}
+
+const gatewayErrorPage = "<html>\r\n<head><title>504 Gateway Time-out</title></head>\r\n" +
+	"<body><center><h1>504 Gateway Time-out</h1></center><hr><center>nginx</center></body>\r\n</html>"
+
+func TestExtractResponseError_HTMLErrorPage(t *testing.T) {
+	newResponse := func(status int, contentType, body string) *http.Response {
+		resp := &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+		if contentType != "" {
+			resp.Header.Set("Content-Type", contentType)
+		}
+		return resp
+	}
+	clientErr := errors.New("504 Gateway Timeout")
+
+	got := ExtractResponseError(clientErr, newResponse(504, "text/html; charset=utf-8", gatewayErrorPage))
+	if want := "ReportPortal gateway returned a non-JSON error (status 504)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Without a content type the page is recognized by its markup
+	got = ExtractResponseError(clientErr, newResponse(502, "", "<!DOCTYPE html><html><body>Bad Gateway</body></html>"))
+	if want := "ReportPortal gateway returned a non-JSON error (status 502)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// ReportPortal JSON errors are still passed through
+	const jsonBody = `{"errorCode":4041,"message":"Launch '1' not found"}`
+	got = ExtractResponseError(errors.New("404 Not Found"), newResponse(404, "application/json", jsonBody))
+	if want := "404 Not Found: " + jsonBody; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReadResponseBody_HTMLPage(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: 200,
+		Header:     http.Header{"Content-Type": []string{"text/html"}},
+		Body:       io.NopCloser(strings.NewReader(gatewayErrorPage)),
+	}
+	result, _, err := ReadResponseBody(resp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected an error result for an HTML page")
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if want := "ReportPortal gateway returned a non-JSON response (status 200)"; text != want {
+		t.Errorf("got %q, want %q", text, want)
+	}
+}
+
+func TestReadAllContext(t *testing.T) {
+	t.Run("reads until EOF", func(t *testing.T) {
+		got, err := ReadAllContext(context.Background(), io.NopCloser(strings.NewReader("payload")))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(got) != "payload" {
+			t.Errorf("got %q, want %q", got, "payload")
+		}
+	})
+
+	t.Run("cancellation aborts a blocked read", func(t *testing.T) {
+		pr, pw := io.Pipe()
+		defer pw.Close() //nolint:errcheck
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		start := time.Now()
+		_, err := ReadAllContext(ctx, pr)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("got error %v, want context.Canceled", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("read returned after %v, want prompt return", elapsed)
+		}
+	})
+}
+
 func TestProcessAttributeKeys(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -267,6 +552,53 @@ func TestProcessAttributeKeys(t *testing.T) {
 	}
 }
 
+func TestValidateCompositeAttribute(t *testing.T) {
+	valid := []string{
+		"",
+		"demo",
+		"platform:ios",
+		"build:",
+		"demo,platform:ios,build:1.2.3",
+		"env:prod,team:backend",
+	}
+	for _, value := range valid {
+		if err := ValidateCompositeAttribute("filter-has-compositeAttribute", value); err != nil {
+			t.Errorf("ValidateCompositeAttribute(%q) returned unexpected error: %v", value, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{name: "space after comma", value: "demo, platform:ios", wantErr: `segment 2 (" platform:ios") contains whitespace`},
+		{name: "space inside value", value: "team:back end", wantErr: "contains whitespace"},
+		{name: "tab", value: "demo\tsmoke", wantErr: "contains whitespace"},
+		{name: "empty segment", value: "demo,,smoke", wantErr: "segment 2 (\"\") is empty"},
+		{name: "leading comma", value: ",demo", wantErr: "segment 1 (\"\") is empty"},
+		{name: "trailing comma", value: "demo,", wantErr: "is empty"},
+		{name: "empty key", value: ":ios", wantErr: "has an empty key"},
+		{name: "lone colon", value: "demo,:", wantErr: "has an empty key"},
+		{name: "several colons", value: "url:http://host", wantErr: "contains more than one ':'"},
+		{name: "colon after value", value: "a:b:", wantErr: "contains more than one ':'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCompositeAttribute("filter-any-compositeAttribute", tt.value)
+			if err == nil {
+				t.Fatalf("ValidateCompositeAttribute(%q) = nil, want error", tt.value)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q does not contain %q", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), "filter-any-compositeAttribute") {
+				t.Errorf("error %q does not name the parameter", err)
+			}
+		})
+	}
+}
+
 func TestProcessAttributeKeys_Performance(t *testing.T) {
 	// Test with a large number of keys to ensure performance
 	filterAttributes := "existing1,existing2"
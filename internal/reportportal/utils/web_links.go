@@ -0,0 +1,189 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/reportportal/goRP/v5/pkg/openapi"
+)
+
+// Default ReportPortal UI paths of a launch and a test item. Placeholders: {project},
+// {launchId}, {itemId} and {itemPath} (the IDs of the item's ancestors and the item itself
+// joined by slashes, which is how the UI addresses nested items).
+const (
+	DefaultLaunchUIPath = "/ui/#{project}/launches/all/{launchId}"
+	DefaultItemUIPath   = "/ui/#{project}/launches/all/{launchId}/{itemPath}"
+)
+
+// IncludeLinksField is the MCP parameter name that turns on webUrl fields in tool results
+const IncludeLinksField = "include_links"
+
+// WebURLField is the field added to launches and test items when links are requested
+const WebURLField = "webUrl"
+
+// UIPaths holds the UI path templates of launch and test item links, for deployments that
+// serve the UI under a non-standard path. The zero value uses the default templates.
+type UIPaths struct {
+	launchPath string
+	itemPath   string
+}
+
+// NewUIPaths validates the UI path templates of launch and test item links. Empty templates
+// keep the defaults.
+func NewUIPaths(launchPath, itemPath string) (UIPaths, error) {
+	launchPath = strings.TrimSpace(launchPath)
+	if launchPath == "" {
+		launchPath = DefaultLaunchUIPath
+	}
+	itemPath = strings.TrimSpace(itemPath)
+	if itemPath == "" {
+		itemPath = DefaultItemUIPath
+	}
+
+	if !strings.Contains(launchPath, "{launchId}") {
+		return UIPaths{}, fmt.Errorf(
+			"invalid launch UI path %q: must contain {launchId}",
+			launchPath,
+		)
+	}
+	if !strings.Contains(itemPath, "{itemId}") && !strings.Contains(itemPath, "{itemPath}") {
+		return UIPaths{}, fmt.Errorf(
+			"invalid item UI path %q: must contain {itemId} or {itemPath}",
+			itemPath,
+		)
+	}
+
+	return UIPaths{launchPath: launchPath, itemPath: itemPath}, nil
+}
+
+// launchTemplate returns the UI path template of a launch
+func (p UIPaths) launchTemplate() string {
+	if p.launchPath == "" {
+		return DefaultLaunchUIPath
+	}
+	return p.launchPath
+}
+
+// itemTemplate returns the UI path template of a test item
+func (p UIPaths) itemTemplate() string {
+	if p.itemPath == "" {
+		return DefaultItemUIPath
+	}
+	return p.itemPath
+}
+
+// IncludeLinksSchema returns the JSON schema for the include_links tool parameter
+func IncludeLinksSchema() *jsonschema.Schema {
+	b, _ := json.Marshal(false)
+	return &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "Add a webUrl field linking to the ReportPortal UI to each returned launch or test item",
+		Default:     b,
+	}
+}
+
+// WebLinks builds ReportPortal UI URLs for the launches and test items of a project
+type WebLinks struct {
+	baseURL string
+	project string
+	paths   UIPaths
+}
+
+// NewWebLinks creates links rooted at the ReportPortal host the API client talks to
+func NewWebLinks(cfg *openapi.Configuration, project string, paths UIPaths) WebLinks {
+	return WebLinks{
+		baseURL: (&url.URL{Scheme: cfg.Scheme, Host: cfg.Host}).String(),
+		project: project,
+		paths:   paths,
+	}
+}
+
+// LaunchURL returns the UI URL of a launch
+func (l WebLinks) LaunchURL(launchID string) string {
+	return l.baseURL + strings.NewReplacer(
+		"{project}", url.PathEscape(l.project),
+		"{launchId}", launchID,
+	).Replace(l.paths.launchTemplate())
+}
+
+// ItemURL returns the UI URL of a test item. itemPath is the dot-separated ltree path of the
+// item as returned by ReportPortal; the item ID alone is used when it is empty.
+func (l WebLinks) ItemURL(launchID, itemID, itemPath string) string {
+	uiItemPath := itemID
+	if itemPath != "" {
+		uiItemPath = strings.ReplaceAll(itemPath, ".", "/")
+	}
+	return l.baseURL + strings.NewReplacer(
+		"{project}", url.PathEscape(l.project),
+		"{launchId}", launchID,
+		"{itemId}", itemID,
+		"{itemPath}", uiItemPath,
+	).Replace(l.paths.itemTemplate())
+}
+
+// AddLaunchURLs adds a webUrl field to the launch in rawBody, or to every launch of a page
+func (l WebLinks) AddLaunchURLs(rawBody []byte) ([]byte, error) {
+	return addWebURLs(rawBody, func(launch map[string]any) string {
+		launchID := jsonScalar(launch["id"])
+		if launchID == "" {
+			return ""
+		}
+		return l.LaunchURL(launchID)
+	})
+}
+
+// AddItemURLs adds a webUrl field to the test item in rawBody, or to every item of a page
+func (l WebLinks) AddItemURLs(rawBody []byte) ([]byte, error) {
+	return addWebURLs(rawBody, func(item map[string]any) string {
+		launchID, itemID := jsonScalar(item["launchId"]), jsonScalar(item["id"])
+		if launchID == "" || itemID == "" {
+			return ""
+		}
+		return l.ItemURL(launchID, itemID, jsonScalar(item["path"]))
+	})
+}
+
+// addWebURLs decodes a JSON object, or a page with a "content" array of objects, and sets
+// webUrl on each object for which webURL returns a non-empty URL
+func addWebURLs(rawBody []byte, webURL func(obj map[string]any) string) ([]byte, error) {
+	// Decode generically so that fields unknown to the client models are passed through as-is
+	decoder := json.NewDecoder(bytes.NewReader(rawBody))
+	decoder.UseNumber()
+	var body map[string]any
+	if err := decoder.Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to parse response for web links: %w", err)
+	}
+
+	objects := []map[string]any{body}
+	if content, ok := body["content"].([]any); ok {
+		objects = objects[:0]
+		for _, entry := range content {
+			if obj, ok := entry.(map[string]any); ok {
+				objects = append(objects, obj)
+			}
+		}
+	}
+	for _, obj := range objects {
+		if link := webURL(obj); link != "" {
+			obj[WebURLField] = link
+		}
+	}
+
+	return json.Marshal(body)
+}
+
+// jsonScalar formats a generically decoded JSON string or number, or returns "" for other values
+func jsonScalar(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/reportportal/goRP/v5/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebLinks(paths UIPaths) WebLinks {
+	cfg := openapi.NewConfiguration()
+	cfg.Scheme = "https"
+	cfg.Host = "rp.example.com"
+	return NewWebLinks(cfg, "my_project", paths)
+}
+
+func TestWebLinks_DefaultTemplates(t *testing.T) {
+	links := newTestWebLinks(UIPaths{})
+	assert.Equal(t,
+		"https://rp.example.com/ui/#my_project/launches/all/42",
+		links.LaunchURL("42"),
+	)
+	assert.Equal(t,
+		"https://rp.example.com/ui/#my_project/launches/all/42/100/101/102",
+		links.ItemURL("42", "102", "100.101.102"),
+	)
+	// Without a path the item ID alone is used
+	assert.Equal(t,
+		"https://rp.example.com/ui/#my_project/launches/all/42/102",
+		links.ItemURL("42", "102", ""),
+	)
+}
+
+func TestNewUIPaths(t *testing.T) {
+	paths, err := NewUIPaths(
+		"/reportportal/ui/#{project}/launches/all/{launchId}",
+		"/reportportal/ui/#{project}/launches/all/{launchId}/{itemId}/log",
+	)
+	require.NoError(t, err)
+	links := newTestWebLinks(paths)
+	assert.Equal(t,
+		"https://rp.example.com/reportportal/ui/#my_project/launches/all/42",
+		links.LaunchURL("42"),
+	)
+	assert.Equal(t,
+		"https://rp.example.com/reportportal/ui/#my_project/launches/all/42/102/log",
+		links.ItemURL("42", "102", "100.101.102"),
+	)
+
+	_, err = NewUIPaths("/ui/#{project}/launches", "")
+	require.ErrorContains(t, err, "{launchId}")
+	_, err = NewUIPaths("", "/ui/#{project}/items")
+	require.ErrorContains(t, err, "{itemId} or {itemPath}")
+}
+
+func TestWebLinks_AddURLs(t *testing.T) {
+	links := newTestWebLinks(UIPaths{})
+
+	launch, err := links.AddLaunchURLs([]byte(`{"id":42,"name":"Nightly"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": 42,
+		"name": "Nightly",
+		"webUrl": "https://rp.example.com/ui/#my_project/launches/all/42"
+	}`, string(launch))
+
+	page, err := links.AddItemURLs([]byte(`{"content":[` +
+		`{"id":9007199254740993,"launchId":42,"path":"7.9007199254740993"},` +
+		`{"name":"no ids"}` +
+		`],"page":{"totalElements":2}}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"content": [
+			{
+				"id": 9007199254740993,
+				"launchId": 42,
+				"path": "7.9007199254740993",
+				"webUrl": "https://rp.example.com/ui/#my_project/launches/all/42/7/9007199254740993"
+			},
+			{"name": "no ids"}
+		],
+		"page": {"totalElements": 2}
+	}`, string(page))
+
+	_, err = links.AddLaunchURLs([]byte(`not json`))
+	require.Error(t, err)
+}